@@ -0,0 +1,90 @@
+// Package config implements the layered configuration loading, shared by the server and the client.
+// Precedence: flags > environment > config file > defaults.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Load fills dst layer by layer. dst must be a pointer to a struct, that already holds the default values.
+//   - file: JSON config file, keys are taken from the `json` field tags. Skipped if path is empty;
+//   - environment: variable names are taken from the `env` field tags;
+//   - flags: for every flag name from changed, the value of the field with the same `flag` tag is copied
+//     from flagged (a struct of the same type, the flags were parsed to).
+func Load(dst, flagged interface{}, changed []string, path string) error {
+	if path != `` {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read config file: %w", err)
+		}
+		if err = json.Unmarshal(data, dst); err != nil {
+			return fmt.Errorf("parse config file %s: %w", path, err)
+		}
+	}
+
+	v := reflect.ValueOf(dst).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, ok := t.Field(i).Tag.Lookup("env")
+		if !ok {
+			continue
+		}
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setValue(v.Field(i), value); err != nil {
+			return fmt.Errorf("env %s: %w", name, err)
+		}
+	}
+
+	if flagged == nil {
+		return nil
+	}
+
+	fv := reflect.ValueOf(flagged).Elem()
+	for _, name := range changed {
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).Tag.Get("flag") == name {
+				v.Field(i).Set(fv.Field(i))
+			}
+		}
+	}
+
+	return nil
+}
+
+// setValue parses the string value to the field type.
+func setValue(f reflect.Value, value string) error {
+	switch {
+	case f.Type() == reflect.TypeOf(time.Duration(0)):
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(d))
+	case f.Kind() == reflect.String:
+		f.SetString(value)
+	case f.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case f.Kind() == reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(n))
+	default:
+		return fmt.Errorf("unsupported config field type %s", f.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Address string        `json:"address" env:"GK_TEST_ADDRESS" flag:"a"`
+	Path    string        `json:"path" env:"GK_TEST_PATH" flag:"p"`
+	Debug   bool          `json:"debug" env:"GK_TEST_DEBUG"`
+	Timeout time.Duration `json:"timeout" env:"GK_TEST_TIMEOUT"`
+	Default string        `json:"default"`
+}
+
+// TestLoad verifies, that the values are taken by precedence flags > env > file > defaults.
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"address":"file:1","path":"file/path","timeout":5}`), 0600))
+
+	t.Setenv("GK_TEST_PATH", "env/path")
+	t.Setenv("GK_TEST_DEBUG", "true")
+	t.Setenv("GK_TEST_TIMEOUT", "3s")
+
+	c := &testConfig{Address: "default:1", Path: "default/path", Default: "default"}
+	flagged := &testConfig{Address: "flag:1", Path: "flag/path"}
+
+	require.NoError(t, Load(c, flagged, []string{"a"}, path))
+	assert.Equal(t, &testConfig{
+		Address: "flag:1",   // flag
+		Path:    "env/path", // env, flag not changed
+		Debug:   true,       // env
+		Timeout: 3 * time.Second,
+		Default: "default", // default
+	}, c)
+
+	t.Setenv("GK_TEST_DEBUG", "maybe")
+	assert.Error(t, Load(&testConfig{}, nil, nil, ``))
+
+	assert.Error(t, Load(&testConfig{}, nil, nil, filepath.Join(t.TempDir(), "missing.json")))
+}
@@ -0,0 +1,293 @@
+package agent
+
+import (
+	"errors"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testID = "00000000-0000-4000-8000-000000000001"
+
+// testBackend serves the vault with one pair.
+type testBackend struct {
+	syncErr error
+}
+
+func (b *testBackend) Get(dataType, id, title string) (models.Item, error) {
+	if dataType == "pair" && (id == testID || (id == `` && title == "github")) {
+		return &models.Pair{ItemID: testID, Title: "github", Login: "me", Pass: "p4ss", Version: 2}, nil
+	}
+	return nil, clserv.ErrItemNotFound
+}
+
+func (b *testBackend) List(dataType, tag string) ([]models.ItemHead, error) {
+	if dataType != `` && dataType != "pair" {
+		return nil, nil
+	}
+	return []models.ItemHead{{Type: "pair", ItemID: testID, Title: "github", Version: 2}}, nil
+}
+
+func (b *testBackend) Sync() ([]string, error) {
+	return []string{`pair "github" version 2: saved`}, b.syncErr
+}
+
+func (b *testBackend) OTP(id, title string) (*OTPCode, error) {
+	if title != "bank" {
+		return nil, clserv.ErrItemNotFound
+	}
+	return &OTPCode{OTP: models.OTP{ItemID: testID, Title: "bank", Kind: clserv.OTPKindTOTP}, Code: "123456",
+		Remaining: 20 * time.Second}, nil
+}
+
+func (b *testBackend) Search(query, dataType, tag string, fuzzy, local bool) ([]clserv.SearchHit, []string, error) {
+	hits := []clserv.SearchHit{{ItemHead: models.ItemHead{Type: "pair", ItemID: testID, Title: "github"}, Field: "title"}}
+	if local {
+		return hits, nil, nil
+	}
+	return hits, []string{"server search failed"}, nil
+}
+
+// testAgent starts the agent with the policy and returns the client.
+func testAgent(t *testing.T, backend Backend, policy *Policy) *Client {
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	l, err := Listen(path)
+	require.NoError(t, err)
+	go NewServer(backend, policy).Serve(l)
+
+	c, err := Dial(path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		c.Close()
+		l.Close()
+	})
+	return c
+}
+
+// TestAgent verifies, that:
+// 1) items, otp codes, lists, search hits and sync reports are served, errors are returned as the service errors
+// 2) socket is the user's only, the running agent is not replaced
+// 3) requests, denied by the policy, are rejected, the listing and the sync are not confirmed
+// 4) socket of another user, in the open directory or not a socket is not dialed
+func TestAgent(t *testing.T) {
+	tests := []struct {
+		name   string
+		number uint8
+	}{
+		{name: "Test #1: item is served by id and title", number: 1},
+		{name: "Test #2: errors are returned as the service errors", number: 2},
+		{name: "Test #3: items are listed", number: 3},
+		{name: "Test #4: sync report is returned with the error", number: 4},
+		{name: "Test #5: socket is the user's only, running agent is not replaced", number: 5},
+		{name: "Test #6: denied request", number: 6},
+		{name: "Test #7: otp code is served", number: 7},
+		{name: "Test #8: search hits are returned with the warnings", number: 8},
+		{name: "Test #9: socket of another user or in the open directory is not dialed", number: 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			never, err := NewPolicy(ConfirmNever, nil, nil)
+			require.NoError(t, err)
+
+			switch tt.number {
+			case 1:
+				c := testAgent(t, &testBackend{}, never)
+				it, err := c.Get("pair", testID, ``)
+				require.NoError(t, err)
+				assert.Equal(t, "p4ss", it.(*models.Pair).Pass)
+				it, err = c.Get("pair", ``, "github")
+				require.NoError(t, err)
+				assert.Equal(t, uint32(2), it.Head().Version)
+			case 2:
+				c := testAgent(t, &testBackend{}, never)
+				_, err := c.Get("pair", ``, "gitlab")
+				assert.ErrorIs(t, err, clserv.ErrItemNotFound)
+				// the connection is still usable.
+				_, err = c.Get("pair", testID, ``)
+				assert.NoError(t, err)
+			case 3:
+				c := testAgent(t, &testBackend{}, never)
+				heads, err := c.List(``, ``)
+				require.NoError(t, err)
+				assert.Equal(t, []models.ItemHead{{Type: "pair", ItemID: testID, Title: "github", Version: 2}}, heads)
+				heads, err = c.List("text", ``)
+				assert.NoError(t, err)
+				assert.Empty(t, heads)
+			case 4:
+				c := testAgent(t, &testBackend{syncErr: errors.New("server unavailable")}, never)
+				lines, err := c.Sync()
+				assert.EqualError(t, err, "server unavailable")
+				assert.Equal(t, []string{`pair "github" version 2: saved`}, lines)
+			case 5:
+				dir := filepath.Join(t.TempDir(), "gophkeeper")
+				path := filepath.Join(dir, "agent.sock")
+				// the socket, left by the stopped agent.
+				require.NoError(t, os.MkdirAll(dir, 0755))
+				require.NoError(t, os.WriteFile(path, nil, 0600))
+
+				l, err := Listen(path)
+				require.NoError(t, err)
+				defer l.Close()
+				info, err := os.Stat(dir)
+				require.NoError(t, err)
+				assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+				info, err = os.Stat(path)
+				require.NoError(t, err)
+				assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+				_, err = Listen(path)
+				assert.ErrorIs(t, err, ErrAgentRunning)
+			case 6:
+				// no terminal to confirm.
+				once, err := NewPolicy(ConfirmOnce, nil, nil)
+				require.NoError(t, err)
+				c := testAgent(t, &testBackend{}, once)
+				_, err = c.Get("pair", testID, ``)
+				assert.ErrorIs(t, err, ErrDenied)
+				_, err = c.List(``, ``)
+				assert.NoError(t, err)
+				_, err = c.OTP(``, "bank")
+				assert.ErrorIs(t, err, ErrDenied)
+				_, _, err = c.Search("git", ``, ``, true, true)
+				assert.ErrorIs(t, err, ErrDenied)
+				_, err = c.Sync()
+				assert.NoError(t, err)
+			case 7:
+				c := testAgent(t, &testBackend{}, never)
+				code, err := c.OTP(``, "bank")
+				require.NoError(t, err)
+				assert.Equal(t, "123456", code.Code)
+				assert.Equal(t, 20*time.Second, code.Remaining)
+				assert.Equal(t, "bank", code.OTP.Title)
+				_, err = c.OTP(``, "mail")
+				assert.ErrorIs(t, err, clserv.ErrItemNotFound)
+			case 8:
+				c := testAgent(t, &testBackend{}, never)
+				hits, warnings, err := c.Search("git", ``, ``, true, true)
+				require.NoError(t, err)
+				assert.Len(t, hits, 1)
+				assert.Equal(t, "title", hits[0].Field)
+				assert.Empty(t, warnings)
+				_, warnings, err = c.Search("git", ``, ``, true, false)
+				require.NoError(t, err)
+				assert.Equal(t, []string{"server search failed"}, warnings)
+			case 9:
+				path := filepath.Join(t.TempDir(), "agent.sock")
+				l, err := Listen(path)
+				require.NoError(t, err)
+				defer l.Close()
+				go NewServer(&testBackend{}, never).Serve(l)
+
+				assert.NoError(t, checkSocket(path, os.Getuid()))
+				// the directory and the socket, made by another user.
+				assert.ErrorIs(t, checkSocket(path, os.Getuid()+1), ErrSocketNotOwned)
+				// the directory, others could write to.
+				require.NoError(t, os.Chmod(filepath.Dir(path), 0777))
+				_, err = Dial(path)
+				assert.ErrorIs(t, err, ErrSocketNotOwned)
+				// not a socket.
+				file := filepath.Join(t.TempDir(), "agent.sock")
+				require.NoError(t, os.WriteFile(file, nil, 0600))
+				_, err = Dial(file)
+				assert.ErrorIs(t, err, ErrSocketNotOwned)
+			}
+		})
+	}
+}
+
+// TestPolicy verifies, that:
+// 1) once - the approval is remembered per program, the denial and unknown programs are confirmed again
+// 2) always - each request is confirmed, allowed programs are not confirmed, others are denied without the prompt
+// 3) item, otp and search requests are confirmed, list and sync are not
+// 4) unknown confirm mode is rejected
+func TestPolicy(t *testing.T) {
+	script := Peer{UID: 1000, PID: 42, Exe: "/usr/bin/bash"}
+	get := Request{Op: OpGet, Type: "pair", Title: "github"}
+
+	tests := []struct {
+		name   string
+		number uint8
+	}{
+		{name: "Test #1: once - approval is remembered per program", number: 1},
+		{name: "Test #2: once - denial is not remembered", number: 2},
+		{name: "Test #3: always - each request is confirmed", number: 3},
+		{name: "Test #4: allowed program is not confirmed", number: 4},
+		{name: "Test #5: unknown program is confirmed each time", number: 5},
+		{name: "Test #6: unknown confirm mode", number: 6},
+		{name: "Test #7: otp request is confirmed", number: 7},
+		{name: "Test #8: search is confirmed, list and sync are not", number: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var asked []string
+			answer := true
+			ask := func(question string) bool {
+				asked = append(asked, question)
+				return answer
+			}
+
+			switch tt.number {
+			case 1:
+				p, err := NewPolicy(ConfirmOnce, nil, ask)
+				require.NoError(t, err)
+				assert.NoError(t, p.Check(script, get))
+				assert.NoError(t, p.Check(script, get))
+				assert.Equal(t, []string{`/usr/bin/bash (pid 42) requests pair "github". Allow?`}, asked)
+				// another process of the same program.
+				assert.NoError(t, p.Check(Peer{UID: 1000, PID: 43, Exe: "/usr/bin/bash"}, get))
+				assert.Len(t, asked, 2)
+				// list is not confirmed.
+				assert.NoError(t, p.Check(Peer{UID: 1000, PID: 44}, Request{Op: OpList}))
+				assert.Len(t, asked, 2)
+			case 2:
+				p, err := NewPolicy(ConfirmOnce, nil, ask)
+				require.NoError(t, err)
+				answer = false
+				assert.ErrorIs(t, p.Check(script, get), ErrDenied)
+				answer = true
+				assert.NoError(t, p.Check(script, get))
+				assert.Len(t, asked, 2)
+			case 3:
+				p, err := NewPolicy(ConfirmAlways, nil, ask)
+				require.NoError(t, err)
+				assert.NoError(t, p.Check(script, get))
+				assert.NoError(t, p.Check(script, get))
+				assert.Len(t, asked, 2)
+			case 4:
+				p, err := NewPolicy(ConfirmAlways, []string{"/usr/bin/bash"}, nil)
+				require.NoError(t, err)
+				assert.NoError(t, p.Check(script, get))
+				assert.ErrorIs(t, p.Check(Peer{UID: 1000, PID: 43, Exe: "/usr/bin/python3"}, get), ErrDenied)
+			case 5:
+				p, err := NewPolicy(ConfirmOnce, nil, ask)
+				require.NoError(t, err)
+				assert.NoError(t, p.Check(Peer{UID: 1000}, get))
+				assert.NoError(t, p.Check(Peer{UID: 1000}, get))
+				assert.Equal(t, `unknown program requests pair "github". Allow?`, asked[0])
+				assert.Len(t, asked, 2)
+			case 6:
+				_, err := NewPolicy("sometimes", nil, ask)
+				assert.ErrorIs(t, err, ErrUnknownConfirm)
+			case 7:
+				p, err := NewPolicy(ConfirmAlways, nil, ask)
+				require.NoError(t, err)
+				assert.NoError(t, p.Check(script, Request{Op: OpOTP, Type: "otp", Title: "bank"}))
+				assert.Equal(t, []string{`/usr/bin/bash (pid 42) requests otp "bank". Allow?`}, asked)
+			case 8:
+				p, err := NewPolicy(ConfirmAlways, nil, ask)
+				require.NoError(t, err)
+				assert.NoError(t, p.Check(script, Request{Op: OpSearch, Query: "bank"}))
+				assert.Equal(t, []string{`/usr/bin/bash (pid 42) searches the vault for "bank". Allow?`}, asked)
+				// list and sync return the titles only.
+				assert.NoError(t, p.Check(script, Request{Op: OpList}))
+				assert.NoError(t, p.Check(script, Request{Op: OpSync}))
+				assert.Len(t, asked, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	dialTimeout = 200 * time.Millisecond
+	// requestTimeout covers the user confirmation at the agent terminal and the server sync.
+	requestTimeout = time.Minute
+)
+
+var (
+	ErrSocketNotOwned = errors.New("agent socket is not the user's own")
+	errNoItem         = errors.New("agent returned no item")
+)
+
+// Client is the connection to the agent.
+type Client struct {
+	conn net.Conn
+	enc  *json.Encoder
+	dec  *json.Decoder
+}
+
+// Dial connects to the agent socket. Fails fast, if the agent doesn't run. The socket is not dialed, unless
+// it and its directory are the user's own: the socket in the shared temporary directory could be made by another user.
+func Dial(path string) (*Client, error) {
+	if err := checkSocket(path, os.Getuid()); err != nil {
+		return nil, err
+	}
+	conn, err := net.DialTimeout("unix", path, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, enc: json.NewEncoder(conn), dec: json.NewDecoder(conn)}, nil
+}
+
+// checkSocket fails with ErrSocketNotOwned, unless the socket and its directory are owned by uid and the directory
+// is closed to the others.
+func checkSocket(path string, uid int) error {
+	dir, err := os.Lstat(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	if !dir.IsDir() || dir.Mode().Perm() != 0700 || !ownedBy(dir, uid) {
+		return fmt.Errorf("%w: %s", ErrSocketNotOwned, filepath.Dir(path))
+	}
+	sock, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+	if sock.Mode().Type() != os.ModeSocket || !ownedBy(sock, uid) {
+		return fmt.Errorf("%w: %s", ErrSocketNotOwned, path)
+	}
+	return nil
+}
+
+func ownedBy(info os.FileInfo, uid int) bool {
+	owner, ok := fileOwner(info)
+	return ok && owner == uid
+}
+
+func (c *Client) do(req Request) (*Response, error) {
+	if err := c.conn.SetDeadline(time.Now().Add(requestTimeout)); err != nil {
+		return nil, err
+	}
+	if err := c.enc.Encode(req); err != nil {
+		return nil, err
+	}
+	resp := new(Response)
+	if err := c.dec.Decode(resp); err != nil {
+		return nil, err
+	}
+	return resp, resp.err()
+}
+
+// Get returns the item of the type by id or, if it is empty, by title.
+func (c *Client) Get(dataType, id, title string) (models.Item, error) {
+	resp, err := c.do(Request{Op: OpGet, Type: dataType, ID: id, Title: title})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Item) == 0 {
+		return nil, errNoItem
+	}
+	return decodeItem(dataType, resp.Item)
+}
+
+// List returns the items of the type and tag. Empty - any.
+func (c *Client) List(dataType, tag string) ([]models.ItemHead, error) {
+	resp, err := c.do(Request{Op: OpList, Type: dataType, Tag: tag})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// Sync synchronizes the agent vault with the server. The report lines are returned on failure too.
+func (c *Client) Sync() ([]string, error) {
+	resp, err := c.do(Request{Op: OpSync})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.Messages, err
+}
+
+// OTP returns the current code of the OTP item by id or, if it is empty, by title.
+func (c *Client) OTP(id, title string) (*OTPCode, error) {
+	resp, err := c.do(Request{Op: OpOTP, Type: "otp", ID: id, Title: title})
+	if err != nil {
+		return nil, err
+	}
+	if resp.OTP == nil {
+		return nil, errNoItem
+	}
+	return resp.OTP, nil
+}
+
+// Search returns the items of the type and tag, that match the query, and the warnings. Empty type and tag - any.
+func (c *Client) Search(query, dataType, tag string, fuzzy, local bool) ([]clserv.SearchHit, []string, error) {
+	resp, err := c.do(Request{Op: OpSearch, Type: dataType, Tag: tag, Query: query, Fuzzy: fuzzy, Local: local})
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Hits, resp.Messages, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,17 @@
+//go:build linux || darwin || freebsd
+
+package agent
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileOwner returns the uid of the file owner.
+func fileOwner(info os.FileInfo) (int, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return int(st.Uid), true
+}
@@ -0,0 +1,36 @@
+//go:build darwin || freebsd
+
+package agent
+
+import (
+	"golang.org/x/sys/unix"
+	"net"
+)
+
+const peerSupported = true
+
+// peerOf returns the process on the other end of the connection: its uid from LOCAL_PEERCRED, the pid, if the system
+// reports it. The program is not known here: each get request is confirmed, unless the policy is never.
+func peerOf(conn *net.UnixConn, self string) (Peer, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return Peer{}, err
+	}
+	var (
+		cred    *unix.Xucred
+		pid     int
+		credErr error
+	)
+	if err = raw.Control(func(fd uintptr) {
+		if cred, credErr = unix.GetsockoptXucred(int(fd), unix.SOL_LOCAL, unix.LOCAL_PEERCRED); credErr == nil {
+			pid = peerPID(int(fd))
+		}
+	}); err != nil {
+		return Peer{}, err
+	}
+	if credErr != nil {
+		return Peer{}, credErr
+	}
+
+	return Peer{UID: int(cred.Uid), PID: pid}, nil
+}
@@ -0,0 +1,14 @@
+//go:build darwin
+
+package agent
+
+import "golang.org/x/sys/unix"
+
+// peerPID returns the pid of the peer process from LOCAL_PEERPID. 0, if it can't be read.
+func peerPID(fd int) int {
+	pid, err := unix.GetsockoptInt(fd, unix.SOL_LOCAL, unix.LOCAL_PEERPID)
+	if err != nil {
+		return 0
+	}
+	return pid
+}
@@ -0,0 +1,8 @@
+//go:build freebsd
+
+package agent
+
+// peerPID returns 0: LOCAL_PEERCRED doesn't report the pid of the peer process.
+func peerPID(fd int) int {
+	return 0
+}
@@ -0,0 +1,75 @@
+//go:build linux
+
+package agent
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const peerSupported = true
+
+// peerOf returns the process on the other end of the connection: its uid from SO_PEERCRED, the program
+// from /proc. The gophkeeperclient commands are not the programs, that need the secret: the process,
+// that run the command (the script), is returned for them.
+func peerOf(conn *net.UnixConn, self string) (Peer, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return Peer{}, err
+	}
+	var (
+		cred    *syscall.Ucred
+		credErr error
+	)
+	if err = raw.Control(func(fd uintptr) {
+		cred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil {
+		return Peer{}, err
+	}
+	if credErr != nil {
+		return Peer{}, credErr
+	}
+
+	p := Peer{UID: int(cred.Uid), PID: int(cred.Pid), Exe: processExe(int(cred.Pid))}
+	if self != `` && p.Exe == self {
+		if ppid := parentPID(p.PID); ppid > 0 {
+			p.PID, p.Exe = ppid, processExe(ppid)
+		}
+	}
+	return p, nil
+}
+
+// processExe returns the executable of the process. Empty, if it can't be read.
+func processExe(pid int) string {
+	exe, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
+	if err != nil {
+		return ``
+	}
+	return exe
+}
+
+// parentPID returns the parent process id. 0, if it can't be read.
+func parentPID(pid int) int {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0
+	}
+	// pid (comm) state ppid ... - the comm could contain spaces and parentheses.
+	i := strings.LastIndexByte(string(stat), ')')
+	if i < 0 {
+		return 0
+	}
+	fields := strings.Fields(string(stat[i+1:]))
+	if len(fields) < 2 {
+		return 0
+	}
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0
+	}
+	return ppid
+}
@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !freebsd
+
+package agent
+
+import "net"
+
+// peerSupported is false: the peer credentials of the unix socket can't be read, so the agent is not started.
+const peerSupported = false
+
+// peerOf is never called: Listen fails with ErrPeerUnsupported.
+func peerOf(conn *net.UnixConn, self string) (Peer, error) {
+	return Peer{}, ErrPeerUnsupported
+}
@@ -0,0 +1,109 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+)
+
+// confirmation policies of the get, otp and search requests.
+const (
+	ConfirmNever  = "never"  // secrets are served without confirmation.
+	ConfirmOnce   = "once"   // the first request of each program is confirmed.
+	ConfirmAlways = "always" // each request is confirmed.
+)
+
+var ErrUnknownConfirm = errors.New("confirm policy must be never, once or always")
+
+// confirmed are the requests, that return the item data.
+var confirmed = map[string]bool{OpGet: true, OpOTP: true, OpSearch: true}
+
+// Peer is the program, that requests the agent.
+type Peer struct {
+	UID int
+	PID int    // 0, if not known.
+	Exe string // empty, if not known.
+}
+
+func (p Peer) String() string {
+	switch {
+	case p.PID == 0:
+		return "unknown program"
+	case p.Exe == ``:
+		return fmt.Sprintf("process %d", p.PID)
+	}
+	return fmt.Sprintf("%s (pid %d)", p.Exe, p.PID)
+}
+
+// Policy decides, if the vault data is served to the program: the get, otp and search requests are confirmed, search
+// matches the item logins, comments and fields. The list and sync requests are not: they return the item titles only,
+// that the server keeps in plaintext anyway.
+type Policy struct {
+	confirm string
+	allow   map[string]bool
+	// ask asks the user at the agent terminal. Nil - the requests, that need the confirmation, are denied.
+	ask func(question string) bool
+
+	// mu is held, while the user is asked: the questions are asked one after another.
+	mu       sync.Mutex
+	approved map[Peer]bool
+}
+
+// NewPolicy returns the policy of the confirm mode. The allowed programs, passed as the executable paths,
+// are served without confirmation.
+func NewPolicy(confirm string, allow []string, ask func(question string) bool) (*Policy, error) {
+	switch confirm {
+	case ConfirmNever, ConfirmOnce, ConfirmAlways:
+	default:
+		return nil, ErrUnknownConfirm
+	}
+
+	p := &Policy{confirm: confirm, allow: make(map[string]bool), ask: ask, approved: make(map[Peer]bool)}
+	for _, exe := range allow {
+		if abs, err := filepath.Abs(exe); err == nil {
+			exe = abs
+		}
+		p.allow[exe] = true
+	}
+	return p, nil
+}
+
+// Check returns ErrDenied, if the get, otp or search request must not be served to the peer. Other requests are
+// not checked.
+func (p *Policy) Check(peer Peer, req Request) error {
+	if !confirmed[req.Op] || p.confirm == ConfirmNever || (peer.Exe != `` && p.allow[peer.Exe]) {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// the approval is remembered for the known process only: the pid of the unknown one could be reused.
+	remember := p.confirm == ConfirmOnce && peer.PID != 0 && peer.Exe != ``
+	if remember && p.approved[peer] {
+		return nil
+	}
+	if p.ask == nil {
+		return ErrDenied
+	}
+
+	if !p.ask(question(peer, req)) {
+		return ErrDenied
+	}
+	if remember {
+		p.approved[peer] = true
+	}
+	return nil
+}
+
+// question asks the user to allow the request of the peer.
+func question(peer Peer, req Request) string {
+	if req.Op == OpSearch {
+		return fmt.Sprintf("%s searches the vault for %q. Allow?", peer, req.Query)
+	}
+	ref := req.Title
+	if req.ID != `` {
+		ref = req.ID
+	}
+	return fmt.Sprintf("%s requests %s %q. Allow?", peer, req.Type, ref)
+}
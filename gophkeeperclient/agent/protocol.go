@@ -0,0 +1,128 @@
+// Package agent implements the local agent: the process, that holds the unlocked vault in memory and serves
+// the items to the other client processes of the same OS user over a unix socket.
+//
+// The protocol is JSON lines: the client sends a Request, the agent answers with a Response. A connection
+// could carry any number of requests.
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"time"
+)
+
+// request operations.
+const (
+	OpGet    = "get"
+	OpList   = "list"
+	OpSync   = "sync"
+	OpOTP    = "otp"
+	OpSearch = "search"
+)
+
+// response error codes, the client converts to the errors.
+const (
+	codeNotFound  = "not_found"
+	codeAmbiguous = "ambiguous"
+	codeInvalidID = "invalid_id"
+	codeDenied    = "denied"
+)
+
+var (
+	ErrDenied    = errors.New("request denied by the agent")
+	ErrUnknownOp = errors.New("unknown agent operation")
+)
+
+// Request is the client request. Get and otp find the item by id or, if it is empty, by title. List filters
+// the items by type and tag, search does it too.
+type Request struct {
+	Op    string `json:"op"`
+	Type  string `json:"type,omitempty"`
+	ID    string `json:"id,omitempty"`
+	Title string `json:"title,omitempty"`
+	Tag   string `json:"tag,omitempty"`
+
+	// search only.
+	Query string `json:"query,omitempty"`
+	Fuzzy bool   `json:"fuzzy,omitempty"`
+	Local bool   `json:"local,omitempty"` // the server is not searched.
+}
+
+// Response is the agent response. Error is empty on success.
+type Response struct {
+	Error string `json:"error,omitempty"`
+	Code  string `json:"code,omitempty"`
+
+	Item     json.RawMessage    `json:"item,omitempty"` // get: the item of the request type.
+	Items    []models.ItemHead  `json:"items,omitempty"`
+	OTP      *OTPCode           `json:"otp,omitempty"`
+	Hits     []clserv.SearchHit `json:"hits,omitempty"`     // search: the best matches first.
+	Messages []string           `json:"messages,omitempty"` // sync: the report lines, search: the warnings.
+}
+
+// OTPCode is the current code of the OTP item. The item is sent without the secret: HOTP item has the advanced
+// counter, the next version is queued by the agent.
+type OTPCode struct {
+	OTP       models.OTP    `json:"otp"`
+	Code      string        `json:"code"`
+	Remaining time.Duration `json:"remaining,omitempty"` // TOTP only: the code lifetime left.
+}
+
+// errorResponse returns the response of the failed request.
+func errorResponse(err error) *Response {
+	r := &Response{Error: err.Error()}
+	switch {
+	case errors.Is(err, clserv.ErrItemNotFound):
+		r.Code = codeNotFound
+	case errors.Is(err, clserv.ErrAmbiguousTitle):
+		r.Code = codeAmbiguous
+	case errors.Is(err, clserv.ErrInvalidItemID):
+		r.Code = codeInvalidID
+	case errors.Is(err, ErrDenied):
+		r.Code = codeDenied
+	}
+	return r
+}
+
+// err returns the error of the response. The known codes are converted to the errors, the commands check.
+func (r *Response) err() error {
+	switch r.Code {
+	case codeNotFound:
+		return clserv.ErrItemNotFound
+	case codeAmbiguous:
+		return clserv.ErrAmbiguousTitle
+	case codeInvalidID:
+		return clserv.ErrInvalidItemID
+	case codeDenied:
+		return ErrDenied
+	}
+	if r.Error != `` {
+		return errors.New(r.Error)
+	}
+	return nil
+}
+
+// decodeItem decodes the item of the type.
+func decodeItem(dataType string, data []byte) (models.Item, error) {
+	var it models.Item
+	switch dataType {
+	case "pair":
+		it = new(models.Pair)
+	case "text":
+		it = new(models.Text)
+	case "bin":
+		it = new(models.Bin)
+	case "card":
+		it = new(models.Card)
+	case "otp":
+		it = new(models.OTP)
+	default:
+		return nil, models.ErrUnknownItemType
+	}
+	if err := json.Unmarshal(data, it); err != nil {
+		return nil, err
+	}
+	return it, nil
+}
@@ -0,0 +1,170 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+var (
+	ErrAgentRunning    = errors.New("agent is already running on the socket")
+	ErrPeerUnsupported = errors.New("agent is not supported on this system: the socket peer can't be checked")
+)
+
+// Backend is the unlocked vault, the agent serves.
+type Backend interface {
+	// Get returns the item by id or, if it is empty, by title.
+	Get(dataType, id, title string) (models.Item, error)
+	// List returns the items of the type and tag. Empty - any.
+	List(dataType, tag string) ([]models.ItemHead, error)
+	// Sync synchronizes the vault with the server. Returns the report lines.
+	Sync() ([]string, error)
+	// OTP returns the current code of the OTP item, found by id or title. HOTP item counter is advanced.
+	OTP(id, title string) (*OTPCode, error)
+	// Search returns the items, that match the query, see service.SearchItems. The server is searched too,
+	// unless local. Returns the warnings, if the server search failed.
+	Search(query, dataType, tag string, fuzzy, local bool) ([]clserv.SearchHit, []string, error)
+}
+
+// Server serves the requests of the user programs to the backend.
+type Server struct {
+	backend Backend
+	policy  *Policy
+	self    string // the client executable: its requests are made for the program, that run it.
+}
+
+func NewServer(backend Backend, policy *Policy) *Server {
+	self, _ := os.Executable()
+	return &Server{backend: backend, policy: policy, self: self}
+}
+
+// Listen creates the socket, that only the user could connect to: the socket directory is the user's only.
+// The socket, left by the stopped agent, is replaced. Fails with ErrPeerUnsupported, if the system doesn't report
+// the socket peer credentials.
+func Listen(path string) (net.Listener, error) {
+	if !peerSupported {
+		return nil, ErrPeerUnsupported
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	// the directory could be created before: chmod fails, if it's not the user's one.
+	if err := os.Chmod(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return nil, ErrAgentRunning
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(path, 0600); err != nil {
+		l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// Serve accepts the connections, until the listener is closed.
+func (s *Server) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle serves the requests of the connection. The connections of the other users are refused.
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	enc := json.NewEncoder(conn)
+
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return
+	}
+	peer, err := peerOf(uc, s.self)
+	if err != nil || peer.UID != os.Getuid() {
+		if err != nil {
+			log.Println(`[ERROR]:`, err)
+		}
+		enc.Encode(errorResponse(ErrDenied))
+		return
+	}
+
+	dec := json.NewDecoder(conn)
+	for {
+		var req Request
+		if err = dec.Decode(&req); err != nil {
+			return
+		}
+		if err = enc.Encode(s.serve(peer, req)); err != nil {
+			return
+		}
+	}
+}
+
+// serve makes the request.
+func (s *Server) serve(peer Peer, req Request) *Response {
+	if err := s.policy.Check(peer, req); err != nil {
+		return errorResponse(err)
+	}
+
+	switch req.Op {
+	case OpGet:
+		it, err := s.backend.Get(req.Type, req.ID, req.Title)
+		if err != nil {
+			return errorResponse(err)
+		}
+		data, err := json.Marshal(it)
+		if err != nil {
+			return errorResponse(err)
+		}
+		return &Response{Item: data}
+	case OpList:
+		heads, err := s.backend.List(req.Type, req.Tag)
+		if err != nil {
+			return errorResponse(err)
+		}
+		return &Response{Items: heads}
+	case OpSync:
+		lines, err := s.backend.Sync()
+		r := &Response{}
+		if err != nil {
+			r = errorResponse(err)
+		}
+		r.Messages = lines
+		return r
+	case OpOTP:
+		code, err := s.backend.OTP(req.ID, req.Title)
+		if err != nil {
+			return errorResponse(err)
+		}
+		return &Response{OTP: code}
+	case OpSearch:
+		hits, warnings, err := s.backend.Search(req.Query, req.Type, req.Tag, req.Fuzzy, req.Local)
+		if err != nil {
+			return errorResponse(err)
+		}
+		return &Response{Hits: hits, Messages: warnings}
+	}
+	return errorResponse(ErrUnknownOp)
+}
@@ -1,7 +1,103 @@
 package cfg
 
-const (
-	UsersFileStoragePath = "tmp/users"
-	VaultFileStoragePath = "tmp/usersData"
-	GrpcServerPath       = "localhost:3200"
+import (
+	"errors"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/config"
+	"os"
+	"path/filepath"
 )
+
+var (
+	ErrMissingValue = errors.New("required config value is not set")
+
+	// Current is the client runtime configuration. Set by the root command before any command runs.
+	Current = Default()
+)
+
+// Config is the client runtime configuration.
+// Sources precedence: flags > environment > config file (--config / GOPHKEEPER_CLIENT_CONFIG) > defaults.
+type Config struct {
+	ServerAddress        string `json:"server_address" env:"GOPHKEEPER_CLIENT_SERVER_ADDRESS" flag:"server"`
+	UsersFile            string `json:"users_file" env:"GOPHKEEPER_CLIENT_USERS_FILE" flag:"users-file"`                // local users auth data.
+	VaultFile            string `json:"vault_file" env:"GOPHKEEPER_CLIENT_VAULT_FILE" flag:"vault-file"`                // local users vault data.
+	OutboxFile           string `json:"outbox_file" env:"GOPHKEEPER_CLIENT_OUTBOX_FILE" flag:"outbox-file"`             // local changes, not acknowledged by the server yet.
+	KeyFile              string `json:"key_file" env:"GOPHKEEPER_CLIENT_KEY_FILE" flag:"key-file"`                      // OS user secret, that encrypts the local files.
+	TLSCAFile            string `json:"tls_ca" env:"GOPHKEEPER_CLIENT_TLS_CA" flag:"tls-ca"`                            // CA bundle to verify the server certificate. Empty - system roots are used.
+	TLSServerFingerprint string `json:"tls_fingerprint" env:"GOPHKEEPER_CLIENT_TLS_FINGERPRINT" flag:"tls-fingerprint"` // hex sha256 of the server certificate. If set, the server certificate must match it.
+	TLSClientCertFile    string `json:"tls_cert" env:"GOPHKEEPER_CLIENT_TLS_CERT" flag:"tls-cert"`                      // client certificate for servers, that require mutual TLS.
+	TLSClientKeyFile     string `json:"tls_key" env:"GOPHKEEPER_CLIENT_TLS_KEY" flag:"tls-key"`                         // client certificate key.
+	AgentSocket          string `json:"agent_socket" env:"GOPHKEEPER_CLIENT_AGENT_SOCKET" flag:"agent-socket"`          // unix socket of the agent. Empty - the agent is not used.
+}
+
+// Default returns the configuration defaults.
+func Default() *Config {
+	return &Config{
+		ServerAddress: "localhost:3200",
+		UsersFile:     "tmp/users",
+		VaultFile:     "tmp/usersData",
+		OutboxFile:    "tmp/outbox",
+		KeyFile:       defaultKeyFile(),
+		TLSCAFile:     "certs/ca.crt",
+		AgentSocket:   defaultAgentSocket(),
+	}
+}
+
+// defaultKeyFile returns the local storage key location in the OS user config directory, apart from the
+// storage files. Falls back to the storage directory, if the user config directory is unknown.
+func defaultKeyFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "tmp/local.key"
+	}
+	return filepath.Join(dir, "gophkeeper", "local.key")
+}
+
+// defaultAgentSocket returns the agent socket location in the user runtime directory. Falls back to the user
+// directory in the temporary one.
+func defaultAgentSocket() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != `` {
+		return filepath.Join(dir, "gophkeeper", "agent.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("gophkeeper-%d", os.Getuid()), "agent.sock")
+}
+
+// Load builds the configuration from the defaults, config file, environment and the changed flags,
+// parsed to flagged. The result is validated.
+func Load(flagged *Config, changed []string, path string) (*Config, error) {
+	c := Default()
+	if err := config.Load(c, flagged, changed, path); err != nil {
+		return nil, err
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Validate checks that all the required values are set.
+func (c *Config) Validate() error {
+	required := []struct {
+		name  string
+		value string
+	}{
+		{"server address", c.ServerAddress},
+		{"users file", c.UsersFile},
+		{"vault file", c.VaultFile},
+		{"outbox file", c.OutboxFile},
+		{"key file", c.KeyFile},
+	}
+	for _, r := range required {
+		if r.value == `` {
+			return fmt.Errorf("%s: %w", r.name, ErrMissingValue)
+		}
+	}
+
+	if (c.TLSClientCertFile == ``) != (c.TLSClientKeyFile == ``) {
+		return fmt.Errorf("tls client certificate and key must be set together: %w", ErrMissingValue)
+	}
+
+	return nil
+}
@@ -0,0 +1,428 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/agent"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/cfg"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"golang.org/x/term"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var errAgentLocked = errors.New("vault is locked. Please login and restart the agent")
+
+// agentCmd represents the agent command
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Keep the unlocked vault in memory and serve it to the other commands",
+	Long: `
+This command runs the agent of the authenticated user: the vault is read once and kept in memory, the items
+are served over the unix socket (--agent-socket), only the user could connect to. While the agent runs,
+getPair, getText, getCard, getOTP, list, search and syncVault use it: they don't read the local storage and
+don't dial the server themselves. Pass --no-agent to the command to read the local storage. getBinary is not
+served by the agent: it streams the file from the server and reads the local storage itself.
+The changes, made by the other commands, are read by the agent. The items, the vault doesn't have, are
+requested from the server.
+The secrets and the searches are confirmed at the agent terminal: --confirm=once asks once for each program
+(the script, that runs the command), always asks for each request, never doesn't ask. list and syncVault are
+not confirmed: they return the item titles only. The programs, passed with --allow,
+are not asked. Without the terminal the requests, that must be confirmed, are denied.
+The agent stops and locks the vault after --ttl, on ctrl+c or when the vault is locked: the key is removed
+from memory and from the local storage, like the shell lock does it.
+The agent runs on Linux, macOS and FreeBSD: the system must report, who connects to the socket.
+Usage: gophkeeperclient agent [--confirm=never|once|always] [--allow=<program path>] [--ttl=1h].`,
+	Run: func(cmd *cobra.Command, args []string) {
+		userName, _, _, ok := userVault()
+		if !ok {
+			return
+		}
+		if cfg.Current.AgentSocket == `` {
+			fmt.Println("Please pass the agent socket with --agent-socket.")
+			return
+		}
+		policy, err := agent.NewPolicy(agentConfirm, agentAllow, askTerminal())
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		l, err := agent.Listen(cfg.Current.AgentSocket)
+		if errors.Is(err, agent.ErrAgentRunning) {
+			fmt.Println("The agent is already running.")
+			return
+		}
+		if errors.Is(err, agent.ErrPeerUnsupported) {
+			fmt.Println("The agent is not supported on this system: it can't check, who connects to it.")
+			return
+		}
+		if err != nil {
+			log.Println(`[ERROR]:`, err)
+			fmt.Println("agent socket setup failed. please check your configuration.")
+			return
+		}
+
+		var once sync.Once
+		stop := func() {
+			once.Do(func() { l.Close() })
+		}
+		// the storage is released: the other commands use it, while the agent runs.
+		if err = releaseStorage(); err != nil {
+			l.Close()
+			log.Println(`[ERROR]:`, err)
+			fmt.Println("local storage update failed. please try again.")
+			return
+		}
+		b := &agentBackend{userName: userName, stamp: storageStamp(), stop: stop}
+
+		go func() {
+			sig := make(chan os.Signal, 1)
+			signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+			defer signal.Stop(sig)
+			var ttl <-chan time.Time
+			if agentTTL > 0 {
+				ttl = time.After(agentTTL)
+			}
+			select {
+			case <-ttl:
+				fmt.Println("Agent TTL passed.")
+			case <-sig:
+			}
+			stop()
+		}()
+
+		fmt.Printf("Agent is listening on %s. Press ctrl+c to stop it.\n", cfg.Current.AgentSocket)
+		if err = agent.NewServer(b, policy).Serve(l); err != nil {
+			log.Println(`[ERROR]:`, err)
+		}
+		b.lock()
+		fmt.Println("Agent stopped, the vault is locked.")
+	},
+}
+
+var (
+	agentConfirm string
+	agentAllow   []string
+	agentTTL     time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(agentCmd)
+	agentCmd.Flags().StringVar(&agentConfirm, "confirm", agent.ConfirmOnce, "Confirm the secrets: never, once (for each program) or always.")
+	agentCmd.Flags().StringSliceVar(&agentAllow, "allow", nil, "Program path, served without confirmation. Repeat or separate with comma.")
+	agentCmd.Flags().DurationVar(&agentTTL, "ttl", time.Hour, "Stop the agent and lock the vault after the time. 0 - never.")
+}
+
+// releaseStorage saves and unlocks the local storage, read by the command. The command keeps the data in memory.
+func releaseStorage() error {
+	storageLoaded = false
+	if err := clstor.UpdateFiles(); err != nil {
+		clstor.Unlock()
+		return err
+	}
+	return clstor.Unlock()
+}
+
+// askTerminal returns the question prompt at the agent terminal. Nil, if the agent has no terminal.
+func askTerminal() func(question string) bool {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil
+	}
+	in := bufio.NewReader(os.Stdin)
+	return func(question string) bool {
+		fmt.Printf("%s [y/N]: ", question)
+		answer, err := in.ReadString('\n')
+		return err == nil && strings.EqualFold(strings.TrimSpace(answer), "y")
+	}
+}
+
+// storageStamp returns the modification times of the users and vault files: they change, when the other
+// commands rewrite the storage.
+func storageStamp() string {
+	var stamp []string
+	for _, path := range []string{cfg.Current.UsersFile, cfg.Current.VaultFile} {
+		if info, err := os.Stat(path); err == nil {
+			stamp = append(stamp, info.ModTime().String())
+		}
+	}
+	return strings.Join(stamp, ";")
+}
+
+// agentBackend serves the vault of the user from memory. The local storage is read again, when the other
+// commands change it, and locked only while it is read or written.
+type agentBackend struct {
+	userName string
+	stop     func() // stops the agent.
+
+	mu     sync.Mutex
+	stamp  string
+	locked bool
+}
+
+// load reads the local storage, if it was changed. The agent is stopped, if the vault was locked
+// or the user logged out meanwhile.
+func (b *agentBackend) load() (*models.Vault, clserv.VaultKey, error) {
+	if b.locked {
+		return nil, nil, errAgentLocked
+	}
+	if stamp := storageStamp(); stamp != b.stamp {
+		if err := clstor.InitStorage(); err != nil {
+			return nil, nil, err
+		}
+		if err := clstor.Unlock(); err != nil {
+			return nil, nil, err
+		}
+		b.stamp = stamp
+	}
+	return b.userVault()
+}
+
+// userVault returns the vault and the key of the user from memory.
+func (b *agentBackend) userVault() (*models.Vault, clserv.VaultKey, error) {
+	auth, ok := clstor.Users[b.userName]
+	vault, found := clstor.Local[b.userName]
+	if !ok || len(auth.VaultKey) == 0 || !found {
+		b.locked = true
+		go b.stop()
+		return nil, nil, errAgentLocked
+	}
+	return vault, clserv.VaultKey(auth.VaultKey), nil
+}
+
+// lock removes the key and the vault from memory. The key is removed from the local storage too: the vault is locked
+// for the other commands, like the shell lock does it.
+func (b *agentBackend) lock() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.locked = true
+	clearVaultKey(clstor.Users[b.userName])
+	// the storage is read again: the other commands could change it, while the agent ran.
+	if err := clstor.InitStorage(); err != nil {
+		log.Println(`[ERROR]:`, err)
+		fmt.Println("local storage update failed, the vault key is still saved. please run logoutUser to remove it.")
+	} else {
+		clearVaultKey(clstor.Users[b.userName])
+		if err = clstor.UpdateFiles(); err != nil {
+			log.Println(`[ERROR]:`, err)
+		}
+		clstor.Unlock()
+	}
+	clstor.Local = nil
+}
+
+// clearVaultKey zeroes the vault key of the user.
+func clearVaultKey(auth *clstor.UserAuth) {
+	if auth == nil {
+		return
+	}
+	for i := range auth.VaultKey {
+		auth.VaultKey[i] = 0
+	}
+	auth.VaultKey = nil
+}
+
+func (b *agentBackend) Get(dataType, id, title string) (models.Item, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.get(dataType, id, title)
+}
+
+// get returns the item from memory or, if the vault doesn't have it, from the server.
+func (b *agentBackend) get(dataType, id, title string) (models.Item, error) {
+	vault, key, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := clserv.FindItem(vault, dataType, id, title)
+	if err != nil && !errors.Is(err, clserv.ErrItemNotFound) {
+		return nil, err
+	}
+	if it, ok := vault.Item(dataType, found); ok {
+		if it.Head().Deleted {
+			return nil, clserv.ErrItemNotFound
+		}
+		return it, nil
+	}
+
+	// local version not found - search on server. The item is kept in memory: the agent doesn't write
+	// the vault outside of sync.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	c, err := grpcclient.DialUp()
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.GetItem(ctx, &pb.GetItemRequest{Type: dataType, Id: id, Title: title})
+	if status.Code(err) == codes.NotFound {
+		return nil, clserv.ErrItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	it, err := models.OpenItem(response.GetItem(), key)
+	if err != nil {
+		return nil, err
+	}
+	vault.PutItem(it)
+	return it, nil
+}
+
+func (b *agentBackend) List(dataType, tag string) ([]models.ItemHead, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	vault, _, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+	return clserv.ListItems(vault, dataType, tag)
+}
+
+// OTP returns the current code of the OTP item, like getOTP does it. The next version of the HOTP item keeps
+// the advanced counter: it is saved and queued, the local storage is locked meanwhile.
+func (b *agentBackend) OTP(id, title string) (*agent.OTPCode, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	it, err := b.get("otp", id, title)
+	if err != nil {
+		return nil, err
+	}
+	otp := *it.(*models.OTP)
+	if otp.Kind == clserv.OTPKindHOTP {
+		return b.nextHOTP(otp)
+	}
+	code, remaining, err := clserv.OTPCode(&otp, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	otp.Secret = ``
+	return &agent.OTPCode{OTP: otp, Code: code, Remaining: remaining}, nil
+}
+
+// Search searches the vault in memory and, unless local, the server, like search does it.
+func (b *agentBackend) Search(query, dataType, tag string, fuzzy, local bool) ([]clserv.SearchHit, []string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	vault, key, err := b.load()
+	if err != nil {
+		return nil, nil, err
+	}
+	return searchVault(vault, key, query, dataType, tag, fuzzy, local)
+}
+
+// Sync sends the queued changes and pulls the server changes, like syncVault does. The local storage
+// is locked, while the vault is synchronized and saved.
+func (b *agentBackend) Sync() ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.locked {
+		return nil, errAgentLocked
+	}
+	if err := clstor.InitStorage(); err != nil {
+		return nil, err
+	}
+	defer clstor.Unlock()
+	vault, key, err := b.userVault()
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := grpcclient.DialUp()
+	if err != nil {
+		return nil, err
+	}
+	outbox := clstor.UserOutbox(b.userName)
+	var lines []string
+	// merged versions and conflict copies are queued by the sync itself - they are sent with one more round.
+	for round := 0; round < 2; round++ {
+		if round > 0 && (len(outbox.Ops) == 0 || outbox.Attempts > 0) {
+			break
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+		next, report, err := clserv.SyncVault(ctx, c, vault, outbox, key)
+		cancel()
+		lines = append(lines, syncReportLines(report)...)
+		if err != nil {
+			log.Println(`[ERROR]:`, err)
+			// the outbox backoff is saved.
+			if uerr := b.save(); uerr != nil {
+				log.Println(`[ERROR]:`, uerr)
+			}
+			if len(outbox.Ops) > 0 {
+				return lines, fmt.Errorf("server request failed. %d change(s) queued and will be sent later", len(outbox.Ops))
+			}
+			return lines, errors.New("request failed. please try again")
+		}
+		clstor.Local[b.userName], vault = next, next
+	}
+
+	if len(outbox.Ops) > 0 {
+		lines = append(lines, fmt.Sprintf("%d change(s) are still queued. Run pending to see them.", len(outbox.Ops)))
+	}
+	if n := len(vault.Conflicts); n > 0 {
+		lines = append(lines, fmt.Sprintf("%d unresolved conflict(s). Run conflicts to resolve them.", n))
+	}
+	return lines, b.save()
+}
+
+// nextHOTP returns the HOTP code and saves the next version of the item with the advanced counter to the vault
+// and queues it: it is sent with the next sync. The item is read again, while the local storage is locked: the other
+// commands could use the counter meanwhile, the code must not be returned twice. otp is used, if the vault doesn't
+// have the item: it was requested from the server.
+func (b *agentBackend) nextHOTP(otp models.OTP) (*agent.OTPCode, error) {
+	if err := clstor.InitStorage(); err != nil {
+		return nil, err
+	}
+	defer clstor.Unlock()
+	vault, key, err := b.userVault()
+	if err != nil {
+		return nil, err
+	}
+	if it, ok := vault.Item("otp", otp.ItemID); ok {
+		if it.Head().Deleted {
+			return nil, clserv.ErrItemNotFound
+		}
+		otp = *it.(*models.OTP)
+	}
+
+	code, _, err := clserv.OTPCode(&otp, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	otp.Counter++
+	next := otp
+	if err = clserv.SaveItem(vault, clstor.UserOutbox(b.userName), &next, key); err != nil {
+		return nil, err
+	}
+	if err = b.save(); err != nil {
+		return nil, err
+	}
+
+	otp.Secret = ``
+	return &agent.OTPCode{OTP: otp, Code: code}, nil
+}
+
+// save rewrites the local storage files, the agent has read.
+func (b *agentBackend) save() error {
+	if err := clstor.UpdateFiles(); err != nil {
+		return err
+	}
+	b.stamp = storageStamp()
+	return nil
+}
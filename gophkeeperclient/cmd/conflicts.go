@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"log"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// conflictsCmd represents the conflicts command
+var conflictsCmd = &cobra.Command{
+	Use:   "conflicts",
+	Short: "List and resolve the conflicting changes",
+	Long: `
+This command lists the items, changed on this device and on another one at the same time, when the changes could not be merged.
+The server version is kept under the item title, the local version - as the conflict copy.
+With --keep the conflicts are resolved: local - the local version replaces the server one, remote - the conflict copy is deleted,
+both - both versions are kept as separate items. Without --keep, you are asked for every conflict.
+Usage: gophkeeperclient conflicts [--id=<item_id>] [--title=<title>] [--keep=local|remote|both]`,
+	Run: func(cmd *cobra.Command, args []string) {
+		u, err := user.Current()
+		if err != nil {
+			log.Fatalln(err)
+		}
+		auth, ok := clstor.Users[u.Username]
+		if !ok {
+			fmt.Println("User not authenticated.")
+			return
+		}
+		if len(auth.VaultKey) == 0 {
+			fmt.Println("Vault is locked. Please login with your master password.")
+			return
+		}
+		key := clserv.VaultKey(auth.VaultKey)
+		vault, ok := clstor.Local[u.Username]
+		if !ok {
+			fmt.Println("User not found. Please register.")
+			return
+		}
+
+		var list []*models.Conflict
+		for _, c := range vault.Conflicts {
+			if (conflictsID == `` || c.ItemID == conflictsID) && (conflictsTitle == `` || c.Title == conflictsTitle) {
+				list = append(list, c)
+			}
+		}
+		if len(list) == 0 {
+			fmt.Println("No conflicts.")
+			return
+		}
+
+		in := bufio.NewReader(os.Stdin)
+		resolved := 0
+		for _, c := range list {
+			printConflict(c)
+
+			keep := conflictsKeep
+			if keep == `` {
+				fmt.Print("Keep [l]ocal / [r]emote / [b]oth / [s]kip: ")
+				answer, _ := in.ReadString('\n')
+				switch strings.ToLower(strings.TrimSpace(answer)) {
+				case "l", "local":
+					keep = clserv.KeepLocal
+				case "r", "remote":
+					keep = clserv.KeepRemote
+				case "b", "both":
+					keep = clserv.KeepBoth
+				default:
+					continue
+				}
+			}
+
+			if err = clserv.ResolveConflict(vault, clstor.UserOutbox(u.Username), c, keep, key); err != nil {
+				log.Println(`[ERROR]:`, err)
+				fmt.Println("conflict resolution failed:", err)
+				return
+			}
+			resolved++
+		}
+		if resolved == 0 {
+			return
+		}
+
+		if err = clstor.UpdateFiles(); err != nil {
+			log.Println(`[ERROR]:`, err)
+			fmt.Println("local storage update failed. please try again.")
+			return
+		}
+		fmt.Printf("%d conflict(s) resolved locally\n", resolved)
+
+		replayOutbox(u.Username, key, false)
+	},
+}
+
+// printConflict prints the conflict description.
+func printConflict(c *models.Conflict) {
+	switch {
+	case c.LocalDeleted:
+		fmt.Printf("%s %q: deleted locally, changed on another device (version %d)", c.Type, c.Title, c.RemoteVersion)
+	case len(c.Fields) > 0:
+		fmt.Printf("%s %q: fields %s changed on both devices, local version is %q",
+			c.Type, c.Title, strings.Join(c.Fields, ", "), c.CopyTitle)
+	default:
+		fmt.Printf("%s %q: deleted on another device, local version is %q", c.Type, c.Title, c.CopyTitle)
+	}
+	if c.LocalTitle != `` {
+		fmt.Printf(", local title is %q", c.LocalTitle)
+	}
+	fmt.Printf(", detected at %s\n", c.DetectedAt.Format(time.RFC3339))
+}
+
+var (
+	conflictsID    string
+	conflictsTitle string
+	conflictsKeep  string
+)
+
+func init() {
+	rootCmd.AddCommand(conflictsCmd)
+	conflictsCmd.Flags().StringVar(&conflictsID, "id", "", "Item id to resolve. Optional.")
+	conflictsCmd.Flags().StringVarP(&conflictsTitle, "title", "t", "", "Item title to resolve. Optional.")
+	conflictsCmd.Flags().StringVarP(&conflictsKeep, "keep", "k", "", "Version to keep: local, remote or both. Optional.")
+}
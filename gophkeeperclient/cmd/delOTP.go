@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+
+	"github.com/spf13/cobra"
+)
+
+// delOTPCmd represents the delOTP command
+var delOTPCmd = &cobra.Command{
+	Use:   "delOTP",
+	Short: "Delete the one-time password data by id or title",
+	Long: `
+This command allows to the authenticated user to delete the one-time password data.
+Usage: gophkeeperclient delOTP --id=<item_id> | --title=<title>.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		deleteItem("otp", delOTP.Id, delOTP.Title)
+	},
+}
+
+var (
+	delOTP pb.DeleteItemRequest
+)
+
+func init() {
+	rootCmd.AddCommand(delOTPCmd)
+	delOTPCmd.Flags().StringVarP(&delOTP.Id, "id", "", "", "Item id to delete.")
+	delOTPCmd.Flags().StringVarP(&delOTP.Title, "title", "t", "", "OTP title to delete.")
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// itemExtras are the custom fields and tags, passed to the save commands.
+type itemExtras struct {
+	fields  []string
+	tags    []string
+	secrets *secretInput // reads the hidden field values, passed without the value.
+}
+
+// addFlags adds the --field and --tag flags to the save command. The hidden field values are read by the secret input
+// of the command: it must be added after.
+func (e *itemExtras) addFlags(cmd *cobra.Command, secrets *secretInput) {
+	e.secrets, secrets.fields = secrets, true
+	cmd.Flags().StringArrayVar(&e.fields, "field", nil,
+		"Custom field as name=value or name:type=value, type is text, hidden, url or date. The hidden value is "+
+			"prompted, if passed as name:hidden, or read with the other secrets. Repeatable. Optional.")
+	cmd.Flags().StringSliceVar(&e.tags, "tag", nil, "Tag of the item. Repeatable or comma separated. Optional.")
+}
+
+// parse returns the passed custom fields and tags. The hidden field values, passed without the value, are filled,
+// when the secret input is read: parse must be called before. The reason is printed and ok is false, if a field
+// is invalid.
+func (e *itemExtras) parse() (fields []models.Field, tags []string, ok bool) {
+	var omitted []int
+	for _, s := range e.fields {
+		if name, hidden := clserv.OmittedHidden(s); hidden {
+			omitted = append(omitted, len(fields))
+			fields = append(fields, models.Field{Name: name, Type: clserv.FieldHidden})
+			continue
+		}
+		f, err := clserv.ParseField(s)
+		if err != nil {
+			fmt.Println(err)
+			return nil, nil, false
+		}
+		if f.Type == clserv.FieldHidden {
+			warnHiddenField(f.Name)
+		}
+		fields = append(fields, f)
+	}
+
+	e.secrets.hidden = nil
+	for _, i := range omitted {
+		e.secrets.hidden = append(e.secrets.hidden, clserv.Secret{Prompt: "Value of " + fields[i].Name, Value: &fields[i].Value})
+	}
+	return fields, clserv.NormalizeTags(e.tags), true
+}
+
+// formatExtras returns the URLs, custom fields and tags of the item for the get commands: a line for each,
+// starting with the line break. Empty, if the item has none.
+func formatExtras(urls []string, fields []models.Field, tags []string) string {
+	var b strings.Builder
+	for _, u := range urls {
+		fmt.Fprintf(&b, "\nURL: %s", u)
+	}
+	for _, f := range fields {
+		fmt.Fprintf(&b, "\n%s (%s): %s", f.Name, f.Type, f.Value)
+	}
+	if len(tags) > 0 {
+		fmt.Fprintf(&b, "\nTags: %s", strings.Join(tags, ", "))
+	}
+	return b.String()
+}
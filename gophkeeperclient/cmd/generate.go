@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+
+	"github.com/spf13/cobra"
+)
+
+// generateCmd represents the generate command
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a random password or passphrase",
+	Long: `
+This command generates a random password or passphrase with the cryptographic random numbers generator
+and reports its entropy estimate. No authentication needed, nothing is saved.
+The password has the lower and upper case letters, digits and symbols by default: each class could be turned off,
+like --symbols=false. --no-ambiguous leaves out the characters, confused in some fonts, like l, 1 and I.
+--pronounceable password alternates the lower case consonants and vowels: it is easier to type, but needs to be longer.
+--passphrase is made of the random words of the embedded wordlist, like diceware does.
+Usage: gophkeeperclient generate [--length=20] [--lower] [--upper] [--digits] [--symbols] [--no-ambiguous] [--pronounceable].
+Usage: gophkeeperclient generate --passphrase [--words=6] [--separator=-] [--capitalize].`,
+	Run: func(cmd *cobra.Command, args []string) {
+		g, err := generator.generate()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(g.Secret)
+		fmt.Printf("Entropy: %.0f bits (%s)\n", g.Entropy, g.Strength())
+	},
+}
+
+var (
+	generator generatorFlags
+)
+
+func init() {
+	rootCmd.AddCommand(generateCmd)
+	generator.addFlags(generateCmd)
+}
+
+// generatorFlags are the password generator parameters of the generate and savePair commands.
+type generatorFlags struct {
+	password      clserv.PasswordOptions
+	passphrase    clserv.PassphraseOptions
+	usePassphrase bool
+}
+
+// addFlags adds the password generator flags to the command.
+func (g *generatorFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&g.password.Length, "length", 20, "Password length.")
+	cmd.Flags().BoolVar(&g.password.Lower, "lower", true, "Use lower case letters.")
+	cmd.Flags().BoolVar(&g.password.Upper, "upper", true, "Use upper case letters.")
+	cmd.Flags().BoolVar(&g.password.Digits, "digits", true, "Use digits.")
+	cmd.Flags().BoolVar(&g.password.Symbols, "symbols", true, "Use symbols.")
+	cmd.Flags().BoolVar(&g.password.NoAmbiguous, "no-ambiguous", false, "Leave out the characters, confused in some fonts.")
+	cmd.Flags().BoolVar(&g.password.Pronounceable, "pronounceable", false, "Generate the pronounceable password.")
+	cmd.Flags().BoolVar(&g.usePassphrase, "passphrase", false, "Generate the passphrase of random words.")
+	cmd.Flags().IntVar(&g.passphrase.Words, "words", 6, "Passphrase words number.")
+	cmd.Flags().StringVar(&g.passphrase.Separator, "separator", "-", "Passphrase words separator.")
+	cmd.Flags().BoolVar(&g.passphrase.Capitalize, "capitalize", false, "Capitalize the passphrase words.")
+}
+
+// generate generates the password or passphrase with the passed parameters.
+func (g *generatorFlags) generate() (clserv.Generated, error) {
+	if g.usePassphrase {
+		return clserv.GeneratePassphrase(g.passphrase)
+	}
+	return clserv.GeneratePassword(g.password)
+}
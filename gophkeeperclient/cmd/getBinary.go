@@ -8,14 +8,14 @@ import (
 	"context"
 	"fmt"
 	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
-	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
 	"github.com/EestiChameleon/gophkeeper/models"
 	pb "github.com/EestiChameleon/gophkeeper/proto"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"io"
 	"log"
-	"os/user"
-	"time"
+	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 )
@@ -23,79 +23,108 @@ import (
 // getBinaryCmd represents the getBinary command
 var getBinaryCmd = &cobra.Command{
 	Use:   "getBinary",
-	Short: "Get a binary data by title",
+	Short: "Get a binary data by id or title",
 	Long: `
-This command returns to the authenticated user the binary data requested by title.
-Usage: gophkeeperclient getBinary --title=<title>.`,
+This command returns to the authenticated user the binary data requested by id or title.
+With --out the data is written to the file. Uploaded files are streamed from the server by chunks.
+Usage: gophkeeperclient getBinary --id=<item_id> | --title=<title> [--out=<path_to_file>].`,
 	Run: func(cmd *cobra.Command, args []string) {
-		user, err := user.Current()
-		if err != nil {
-			log.Fatalln(err)
+		if getBinOut == `` {
+			showItem("bin", getBin.Id, getBin.Title, func(it models.Item) { printBinary(it.(*models.Bin)) })
+			return
 		}
-		jwt, ok := clstor.Users[user.Username]
+
+		_, key, vault, ok := userVault()
 		if !ok {
-			fmt.Println("User not authenticated.")
 			return
 		}
-		// search local version
-		vault, ok := clstor.Local[user.Username]
+		it, ok := localItem(vault, "bin", getBin.Id, getBin.Title)
 		if !ok {
-			fmt.Println("User not found. Please register.")
 			return
 		}
-		binData, ok := vault.Bin[getBin.Title]
-		// local version exists - return it.
-		if ok {
-			msg := fmt.Sprintf("Title: %s\nBody: %s\nComment: %s\nMake sure you have the latest version by synchronizing your vault.",
-				binData.Title, binData.Body, binData.Comment)
-			fmt.Println(msg)
-			return
+		var local *models.Bin
+		if it != nil {
+			local = it.(*models.Bin)
 		}
-		// local version not found - search on server
+		downloadBinary(local, key)
+	},
+}
 
-		// request with 3s timeout. ctx WithTimeOut
-		ctxWTO, cancel := context.WithTimeout(context.Background(), time.Second*3)
-		defer cancel()
+// printBinary prints the binary data. Body of the streamed item is kept on the server only.
+func printBinary(binData *models.Bin) {
+	if binData.Streamed {
+		msg := fmt.Sprintf("ID: %s\nTitle: %s\nSize: %d bytes (encrypted)\nComment: %s%s\nUse --out=<path_to_file> to download the data.",
+			binData.ItemID, binData.Title, binData.Size, binData.Comment,
+			formatExtras(nil, binData.Fields, binData.Tags))
+		fmt.Println(msg)
+		return
+	}
+	msg := fmt.Sprintf("ID: %s\nTitle: %s\nBody: %s\nComment: %s%s\nMake sure you have the latest version by synchronizing your vault.",
+		binData.ItemID, binData.Title, binData.Body, binData.Comment,
+		formatExtras(nil, binData.Fields, binData.Tags))
+	fmt.Println(msg)
+}
 
-		c, err := grpcclient.DialUp()
-		if err != nil {
-			log.Fatalln(err)
-			return
+// downloadBinary writes the binary data to the getBinOut file. The local body is written, if the vault has it.
+// Otherwise the data is streamed from the server.
+func downloadBinary(local *models.Bin, key clserv.VaultKey) {
+	err := writeOut(getBinOut, func(w io.Writer) error {
+		if local != nil && !local.Streamed {
+			_, err := w.Write(local.Body)
+			return err
 		}
 
-		// Add token to gRPC Request. ctx WithToKeN
-		ctxWTKN := metadata.AppendToOutgoingContext(ctxWTO, "authorization", "Bearer "+jwt)
-
-		// send data to server and receive JWT in case of success. then save it in Users
-		response, err := c.GetBin(ctxWTKN, &getBin)
+		c, err := grpcclient.DialUp()
 		if err != nil {
-			st, ok := status.FromError(err)
-			if !ok {
-				// Error was not a status error
-				fmt.Println("request failed. please try again.")
-			}
-			msg := fmt.Sprintf("success\nStatusCode: %v\nMessage: %s", st.Code(), st.Message())
-			fmt.Println(msg)
+			return err
+		}
+		// the local item is requested by id: the title could be shared.
+		id := getBin.Id
+		if local != nil {
+			id = local.ItemID
+		}
+		_, err = clserv.DownloadBin(context.Background(), c, id, getBin.Title, w, key)
+		return err
+	})
+	if err != nil {
+		log.Println(`[ERROR]:`, err)
+		if st, ok := status.FromError(err); ok {
+			fmt.Printf("download failed\nStatusCode: %v\nMessage: %s\n", st.Code(), st.Message())
 			return
 		}
+		fmt.Println("download failed. please try again.")
+		return
+	}
+	fmt.Println("saved to", getBinOut)
+}
 
-		// successful response
-		// save pair to local
-		vault.Bin[response.BinData.Title] = models.ProtoToModelsBin(response.BinData)
-		// return pair data
-		msg := fmt.Sprintf("Title: %s\nBody: %s\nComment: %s\nMake sure you have the latest version by synchronizing your vault.",
-			response.BinData.Title, response.BinData.Body, response.BinData.Comment)
-		fmt.Println(response.GetStatus())
-		fmt.Println(msg)
-	},
+// writeOut writes the file with the write function. The data goes to a temporary file first, which replaces
+// the target only if write succeeds.
+func writeOut(path string, write func(w io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".part-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err = write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
 }
 
 var (
-	getBin pb.GetBinRequest
+	getBin    pb.GetBinRequest
+	getBinOut string
 )
 
 func init() {
 	rootCmd.AddCommand(getBinaryCmd)
+	getBinaryCmd.Flags().StringVarP(&getBin.Id, "id", "", "", "Item id to search for.")
 	getBinaryCmd.Flags().StringVarP(&getBin.Title, "title", "t", "", "Text title to search for.")
-	getBinaryCmd.MarkFlagRequired("title")
+	getBinaryCmd.Flags().StringVarP(&getBinOut, "out", "o", "", "File to write the data to. Optional.")
 }
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/agent"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// getOTPCmd represents the getOTP command
+var getOTPCmd = &cobra.Command{
+	Use:   "getOTP",
+	Short: "Get the current one-time password by id or title",
+	Long: `
+This command returns to the authenticated user the current one-time password code, generated by the OTP item
+requested by id or title. TOTP code is shown with the seconds it remains valid. HOTP code is valid until used:
+the item counter is advanced, so the next call shows the next code.
+Usage: gophkeeperclient getOTP --id=<item_id> | --title=<title>.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		getOTP.Type = "otp"
+		if agentClient != nil {
+			showAgentOTP(getOTP.Id, getOTP.Title)
+			return
+		}
+		showItem("otp", getOTP.Id, getOTP.Title, func(it models.Item) {
+			otp := it.(*models.OTP)
+			code, remaining, err := clserv.OTPCode(otp, time.Now())
+			if err != nil {
+				log.Println(`[ERROR]:`, err)
+				fmt.Println("code generation failed:", err)
+				return
+			}
+			printOTP(otp, code, remaining)
+
+			if otp.Kind == clserv.OTPKindHOTP {
+				// the code is used: the next version keeps the next counter.
+				next := *otp
+				next.Counter++
+				fmt.Printf("Counter: %d\n", next.Counter)
+				saveItem("otp", &next, &next.ItemID)
+			}
+		})
+	},
+}
+
+var (
+	getOTP pb.GetItemRequest
+)
+
+func init() {
+	rootCmd.AddCommand(getOTPCmd)
+	getOTPCmd.Flags().StringVarP(&getOTP.Id, "id", "", "", "Item id to search for.")
+	getOTPCmd.Flags().StringVarP(&getOTP.Title, "title", "t", "", "OTP title to search for.")
+}
+
+// printOTP prints the OTP item with the current code.
+func printOTP(otp *models.OTP, code string, remaining time.Duration) {
+	msg := fmt.Sprintf("ID: %s\nTitle: %s\nIssuer: %s\nAccount: %s\nComment: %s%s\nCode: %s", otp.ItemID, otp.Title,
+		otp.Issuer, otp.Account, otp.Comment, formatExtras(nil, otp.Fields, otp.Tags), code)
+	if otp.Kind == clserv.OTPKindTOTP {
+		msg += fmt.Sprintf("\nValid for: %d seconds", int(remaining.Seconds()))
+	}
+	fmt.Println(msg)
+}
+
+// showAgentOTP prints the code, generated by the agent. The agent queues the next version of the HOTP item:
+// it is sent at once, like saveItem does it.
+func showAgentOTP(id, title string) {
+	if id == `` && title == `` {
+		fmt.Println("Please pass the item --id or --title.")
+		return
+	}
+	code, err := agentClient.OTP(id, title)
+	switch {
+	case err == nil:
+	case errors.Is(err, clserv.ErrItemNotFound):
+		fmt.Printf("Nothing found for %s\nMake sure you have the latest version by synchronizing your vault.\n",
+			itemRef(id, title))
+		return
+	case errors.Is(err, agent.ErrDenied):
+		fmt.Println("The agent denied the request.")
+		return
+	default:
+		printItemError(err, title)
+		return
+	}
+
+	printOTP(&code.OTP, code.Code, code.Remaining)
+	if code.OTP.Kind != clserv.OTPKindHOTP {
+		return
+	}
+	fmt.Printf("Counter: %d\n", code.OTP.Counter)
+	lines, err := agentClient.Sync()
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	if err != nil {
+		fmt.Println(err)
+	}
+}
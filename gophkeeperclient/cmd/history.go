@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"google.golang.org/grpc/status"
+	"log"
+	"os/user"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the stored versions of an item",
+	Long: `
+This command lists all the versions of the item, stored on the server, the latest first, with their titles.
+Deleted versions are marked.
+With --version the data of that version is shown. Use restore to make an old version the latest one.
+Usage: gophkeeperclient history --type=pair|text|bin|card --id=<item_id> | --title=<title> [--version=<version>]`,
+	Run: func(cmd *cobra.Command, args []string) {
+		u, err := user.Current()
+		if err != nil {
+			log.Fatalln(err)
+		}
+		auth, ok := clstor.Users[u.Username]
+		if !ok {
+			fmt.Println("User not authenticated.")
+			return
+		}
+		if len(auth.VaultKey) == 0 {
+			fmt.Println("Vault is locked. Please login with your master password.")
+			return
+		}
+		if historyReq.Id == `` && historyReq.Title == `` {
+			fmt.Println("Please pass the item --id or --title.")
+			return
+		}
+
+		// request with 3s timeout. ctx WithTimeOut
+		ctxWTO, cancel := context.WithTimeout(context.Background(), time.Second*3)
+		defer cancel()
+
+		c, err := grpcclient.DialUp()
+		if err != nil {
+			log.Println(`[ERROR]:`, err)
+			fmt.Println("connection setup failed. please check your configuration.")
+			return
+		}
+
+		if historyReq.Version > 0 {
+			resp, err := c.GetVersion(ctxWTO, &historyReq)
+			if err != nil {
+				printStatusError(err)
+				return
+			}
+			if err = printVersion(resp, clserv.VaultKey(auth.VaultKey)); err != nil {
+				log.Println(`[ERROR]:`, err)
+				fmt.Println("data decryption failed. please check your master password.")
+			}
+			return
+		}
+
+		resp, err := c.ListVersions(ctxWTO, &pb.ListVersionsRequest{Type: historyReq.Type, Id: historyReq.Id, Title: historyReq.Title})
+		if err != nil {
+			printStatusError(err)
+			return
+		}
+		fmt.Printf("%s %s:\n", historyReq.Type, itemRef(historyReq.Id, historyReq.Title))
+		for _, v := range resp.GetVersions() {
+			line := fmt.Sprintf("  version %d %q, %d bytes", v.GetVersion(), v.GetTitle(), v.GetSize())
+			switch {
+			case v.GetDeleted():
+				line = fmt.Sprintf("  version %d %q, deleted at %s", v.GetVersion(), v.GetTitle(),
+					time.Unix(v.GetDeletedAt(), 0).Format(time.RFC3339))
+			case v.GetDeletedAt() > 0:
+				line += ", item deleted later"
+			}
+			fmt.Println(line)
+		}
+	},
+}
+
+// printStatusError prints the failed request status.
+func printStatusError(err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		log.Println(`[ERROR]:`, err)
+		fmt.Println("request failed. please try again.")
+		return
+	}
+	fmt.Printf("request failed\nStatusCode: %v\nMessage: %s\n", st.Code(), st.Message())
+}
+
+// printVersion opens and prints the item version data.
+func printVersion(resp *pb.GetVersionResponse, key clserv.VaultKey) error {
+	// tombstone has no data
+	if resp.GetPair().GetDeleted() || resp.GetText().GetDeleted() || resp.GetBinData().GetDeleted() || resp.GetCard().GetDeleted() ||
+		resp.GetEnvelope().GetDeleted() {
+		fmt.Println("The item was deleted in this version.")
+		return nil
+	}
+
+	var msg string
+	switch item := resp.GetItem().(type) {
+	case *pb.GetVersionResponse_Pair:
+		pair, err := models.ProtoToModelsPair(item.Pair, key)
+		if err != nil {
+			return err
+		}
+		msg = fmt.Sprintf("Title: %s\nVersion: %d\nLogin: %s\nPassword: %s\nComment: %s",
+			pair.Title, pair.Version, pair.Login, pair.Pass, pair.Comment)
+		msg += formatExtras(pair.URLs, pair.Fields, pair.Tags)
+	case *pb.GetVersionResponse_Text:
+		text, err := models.ProtoToModelsText(item.Text, key)
+		if err != nil {
+			return err
+		}
+		msg = fmt.Sprintf("Title: %s\nVersion: %d\nBody: %s\nComment: %s", text.Title, text.Version, text.Body, text.Comment)
+		msg += formatExtras(nil, text.Fields, text.Tags)
+	case *pb.GetVersionResponse_BinData:
+		bin, err := models.ProtoToModelsBin(item.BinData, key)
+		if err != nil {
+			return err
+		}
+		msg = fmt.Sprintf("Title: %s\nVersion: %d\nBody: %s\nComment: %s", bin.Title, bin.Version, bin.Body, bin.Comment)
+		if bin.Streamed {
+			msg = fmt.Sprintf("Title: %s\nVersion: %d\nSize: %d bytes (encrypted)\nComment: %s",
+				bin.Title, bin.Version, bin.Size, bin.Comment)
+		}
+		msg += formatExtras(nil, bin.Fields, bin.Tags)
+	case *pb.GetVersionResponse_Card:
+		card, err := models.ProtoToModelsCard(item.Card, key)
+		if err != nil {
+			return err
+		}
+		msg = fmt.Sprintf("Title: %s\nVersion: %d\nNumber: %s\nExpiration date: %s\nComment: %s",
+			card.Title, card.Version, card.Number, card.ExpirationDate, card.Comment)
+		msg += formatExtras(nil, card.Fields, card.Tags)
+	case *pb.GetVersionResponse_Envelope:
+		it, err := models.OpenItem(item.Envelope, key)
+		if err != nil {
+			return err
+		}
+		if otp, ok := it.(*models.OTP); ok {
+			msg = fmt.Sprintf("Title: %s\nVersion: %d\nKind: %s\nIssuer: %s\nAccount: %s\nComment: %s",
+				otp.Title, otp.Version, otp.Kind, otp.Issuer, otp.Account, otp.Comment)
+			msg += formatExtras(nil, otp.Fields, otp.Tags)
+		}
+	}
+
+	fmt.Println(msg)
+	return nil
+}
+
+var (
+	historyReq pb.GetVersionRequest
+)
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().StringVar(&historyReq.Type, "type", "", "Item type: pair, text, bin, card or otp.")
+	historyCmd.Flags().StringVar(&historyReq.Id, "id", "", "Item id.")
+	historyCmd.Flags().StringVarP(&historyReq.Title, "title", "t", "", "Item title.")
+	historyCmd.Flags().Uint32VarP(&historyReq.Version, "version", "v", 0, "Version to show. Optional.")
+	historyCmd.MarkFlagRequired("type")
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/agent"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"google.golang.org/grpc/status"
+	"log"
+	"os/user"
+	"time"
+)
+
+// itemRef describes the item, passed with --id or --title, for the messages.
+func itemRef(id, title string) string {
+	if id != `` {
+		return "id: " + id
+	}
+	return "title: " + title
+}
+
+// printItemError prints the reason, the item reference can't be used.
+func printItemError(err error, title string) {
+	switch {
+	case errors.Is(err, clserv.ErrAmbiguousTitle):
+		fmt.Printf("Several items have the title %q. Please pass the item --id.\n", title)
+	case errors.Is(err, clserv.ErrInvalidItemID):
+		fmt.Println("Invalid item id. Please pass the id, shown by the get commands.")
+	default:
+		fmt.Println(err)
+	}
+}
+
+// findItem returns the id of the vault item, passed with --id or --title. Empty id, if the vault has no such item.
+// The reason is printed and ok is false, if the reference is missing, invalid or ambiguous.
+func findItem(vault *models.Vault, dataType, id, title string) (found string, ok bool) {
+	if id == `` && title == `` {
+		fmt.Println("Please pass the item --id or --title.")
+		return ``, false
+	}
+
+	found, err := clserv.FindItem(vault, dataType, id, title)
+	if err != nil && !errors.Is(err, clserv.ErrItemNotFound) {
+		printItemError(err, title)
+		return ``, false
+	}
+	return found, true
+}
+
+// userVault returns the current user name, vault key and local vault. The reason is printed and ok is false,
+// if the user is not authenticated, the vault is locked or not found.
+func userVault() (userName string, key clserv.VaultKey, vault *models.Vault, ok bool) {
+	u, err := user.Current()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	auth, ok := clstor.Users[u.Username]
+	if !ok {
+		fmt.Println("User not authenticated.")
+		return ``, nil, nil, false
+	}
+	if len(auth.VaultKey) == 0 {
+		fmt.Println("Vault is locked. Please login with your master password.")
+		return ``, nil, nil, false
+	}
+	vault, ok = clstor.Local[u.Username]
+	if !ok {
+		fmt.Println("User not found. Please register.")
+		return ``, nil, nil, false
+	}
+	return u.Username, clserv.VaultKey(auth.VaultKey), vault, true
+}
+
+// localItem returns the local version of the item, passed with --id or --title. Nil, if the vault has no such item.
+// The reason is printed and ok is false, if the reference can't be used or the item was deleted.
+func localItem(vault *models.Vault, dataType, id, title string) (models.Item, bool) {
+	found, ok := findItem(vault, dataType, id, title)
+	if !ok {
+		return nil, false
+	}
+	it, exists := vault.Item(dataType, found)
+	if !exists {
+		return nil, true
+	}
+	// local version is a tombstone - the item was deleted.
+	if it.Head().Deleted {
+		fmt.Printf("Nothing found for %s\nThe data was deleted. Make sure you have the latest version by synchronizing your vault.\n",
+			itemRef(id, title))
+		return nil, false
+	}
+	return it, true
+}
+
+// showItem prints the item, passed with --id or --title, with the show function. The local version is shown,
+// if the vault has it. Otherwise the item is requested from the server and saved to the local vault.
+func showItem(dataType, id, title string, show func(it models.Item)) {
+	if agentClient != nil {
+		showAgentItem(dataType, id, title, show)
+		return
+	}
+	userName, key, vault, ok := userVault()
+	if !ok {
+		return
+	}
+	it, ok := localItem(vault, dataType, id, title)
+	if !ok {
+		return
+	}
+	// local version exists - return it.
+	if it != nil {
+		show(it)
+		return
+	}
+	// local version not found - search on server
+
+	// request with 3s timeout. ctx WithTimeOut
+	ctxWTO, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	c, err := grpcclient.DialUp()
+	if err != nil {
+		log.Fatalln(err)
+		return
+	}
+
+	response, err := c.GetItem(ctxWTO, &pb.GetItemRequest{Type: dataType, Id: id, Title: title})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			// Error was not a status error
+			fmt.Println("request failed. please try again.")
+			return
+		}
+		msg := fmt.Sprintf("success\nStatusCode: %v\nMessage: %s", st.Code(), st.Message())
+		fmt.Println(msg)
+		return
+	}
+
+	// successful response
+	// open the sealed payload and save to local
+	it, err = models.OpenItem(response.GetItem(), key)
+	if err != nil {
+		log.Println(`[ERROR]:`, err)
+		fmt.Println("data decryption failed. please check your master password.")
+		return
+	}
+	vault.PutItem(it)
+	fmt.Println(response.GetStatus())
+	show(it)
+
+	// server is reachable - send the queued local changes, if their backoff has passed.
+	if clstor.UserOutbox(userName).Due(time.Now()) {
+		replayOutbox(userName, key, false)
+	}
+}
+
+// showAgentItem prints the item, served by the agent.
+func showAgentItem(dataType, id, title string, show func(it models.Item)) {
+	if id == `` && title == `` {
+		fmt.Println("Please pass the item --id or --title.")
+		return
+	}
+	it, err := agentClient.Get(dataType, id, title)
+	switch {
+	case err == nil:
+		show(it)
+	case errors.Is(err, clserv.ErrItemNotFound):
+		fmt.Printf("Nothing found for %s\nMake sure you have the latest version by synchronizing your vault.\n",
+			itemRef(id, title))
+	case errors.Is(err, agent.ErrDenied):
+		fmt.Println("The agent denied the request.")
+	default:
+		printItemError(err, title)
+	}
+}
+
+// saveItem saves the item as the next version. The item is passed by *id or found by title, a new item gets
+// a new id: it is set to *id, before the item is sealed. The change is saved to the local vault and the outbox
+// first, then sent to the server.
+func saveItem(dataType string, it models.Item, id *string) {
+	userName, key, vault, ok := userVault()
+	if !ok {
+		return
+	}
+	title := it.Head().Title
+	var err error
+	if *id, err = clserv.SaveItemID(vault, dataType, *id, title); err != nil {
+		printItemError(err, title)
+		return
+	}
+
+	// the item is sealed with the vault key. Server receives only the item id, title, version and sealed payload.
+	if err = clserv.SaveItem(vault, clstor.UserOutbox(userName), it, key); err != nil {
+		log.Println(`[ERROR]:`, err)
+		fmt.Println("data encryption failed. please try again.")
+		return
+	}
+	if err = clstor.UpdateFiles(); err != nil {
+		log.Println(`[ERROR]:`, err)
+		fmt.Println("local storage update failed. please try again.")
+		return
+	}
+	fmt.Println("saved locally")
+
+	replayOutbox(userName, key, false)
+}
+
+// deleteItem deletes the item, passed with --id or --title. The local tombstone is recorded and the deletion
+// is queued: tombstone is the next version, so the deleted item is not resurrected by the older versions during sync.
+func deleteItem(dataType, id, title string) {
+	userName, key, vault, ok := userVault()
+	if !ok {
+		return
+	}
+	found, ok := findItem(vault, dataType, id, title)
+	if !ok {
+		return
+	}
+
+	err := clserv.DeleteItem(vault, clstor.UserOutbox(userName), dataType, found, key)
+	// local version doesn't exist or already deleted: nothing to delete.
+	if errors.Is(err, clserv.ErrItemNotFound) {
+		fmt.Printf("Nothing found for %s\nMake sure you have the latest version by synchronizing your vault.\n",
+			itemRef(id, title))
+		return
+	}
+	if err != nil {
+		log.Println(`[ERROR]:`, err)
+		fmt.Println("data encryption failed. please try again.")
+		return
+	}
+	if err = clstor.UpdateFiles(); err != nil {
+		log.Println(`[ERROR]:`, err)
+		fmt.Println("local storage update failed. please try again.")
+		return
+	}
+	fmt.Println("deleted locally")
+
+	replayOutbox(userName, key, false)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the items of the local vault",
+	Long: `
+This command lists to the authenticated user the items of the local vault: type, id, title and tags.
+The deleted items are not listed. Synchronize the vault to list the items, saved on other devices.
+With --json the items are printed as JSON array, for the scripts.
+Usage: gophkeeperclient list [--type=pair|text|bin|card|otp] [--tag=<tag>] [--json].`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			heads []models.ItemHead
+			err   error
+		)
+		if agentClient != nil {
+			heads, err = agentClient.List(listType, listTag)
+		} else {
+			_, _, vault, ok := userVault()
+			if !ok {
+				return
+			}
+			heads, err = clserv.ListItems(vault, listType, listTag)
+		}
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		if listJSON {
+			if heads == nil {
+				heads = []models.ItemHead{}
+			}
+			printJSON(heads)
+			return
+		}
+		printHeads(heads)
+	},
+}
+
+var (
+	listType string
+	listTag  string
+	listJSON bool
+)
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+	listCmd.Flags().StringVar(&listType, "type", "", "Item type: pair, text, bin, card or otp. Optional.")
+	listCmd.Flags().StringVar(&listTag, "tag", "", "Tag to filter by. Optional.")
+	listCmd.Flags().BoolVar(&listJSON, "json", false, "Print the items as JSON.")
+}
+
+// printHeads prints the items as a table.
+func printHeads(heads []models.ItemHead) {
+	if len(heads) == 0 {
+		fmt.Println("Nothing found.")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tID\tTITLE\tTAGS")
+	for _, h := range heads {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", h.Type, h.ItemID, h.Title, strings.Join(h.Tags, ", "))
+	}
+	w.Flush()
+}
+
+// printJSON prints the value as indented JSON.
+func printJSON(v interface{}) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Println(`[ERROR]:`, err)
+		fmt.Println("JSON encoding failed.")
+		return
+	}
+	fmt.Println(string(out))
+}
@@ -8,7 +8,6 @@ import (
 	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
 	pb "github.com/EestiChameleon/gophkeeper/proto"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc/metadata"
 	"log"
 	"os/user"
 )
@@ -19,8 +18,14 @@ var loginUserCmd = &cobra.Command{
 	Short: "Login user to the service",
 	Long: `
 This command login user.
-Usage: gophkeeperclient loginUser --login=<login> --password=<password>.`,
+The master password is used to derive the vault key on this device. If the account has no master password yet,
+the passed one is set.
+The password and master password are prompted without echo, if omitted, or read with --from-stdin or --from-file.
+Usage: gophkeeperclient loginUser --login=<login> [--password=<password>] [--master=<master_password>] [--from-stdin|--from-file=<path>].`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if !loginSecrets.read(cmd) {
+			return
+		}
 		// get current user from os/user. Like this we can locally identify if the user changed.
 		u, err := user.Current()
 		if err != nil {
@@ -48,8 +53,48 @@ Usage: gophkeeperclient loginUser --login=<login> --password=<password>.`,
 
 		fmt.Println("login status: ", logResp.GetStatus())
 
-		// save JWT
-		clstor.Users[u.Username] = logResp.Jwt
+		// session tokens. The saved session of the user is replaced, once the master password is verified.
+		auth := &clstor.UserAuth{
+			JWT:          logResp.GetJwt(),
+			RefreshToken: logResp.GetRefreshToken(),
+			ExpiresAt:    logResp.GetExpiresAt(),
+		}
+
+		// derive the vault key. Accounts, created before the vault encryption, get the new key derivation data.
+		kdf := logResp.GetKdf()
+		if kdf != nil {
+			auth.VaultKey, err = clserv.DeriveVaultKey(loginMaster, kdf)
+			if err != nil {
+				log.Println(`[ERROR]:`, err)
+				fmt.Println("vault unlock failed. please check your master password.")
+				return
+			}
+		} else {
+			var key clserv.VaultKey
+			kdf, key, err = clserv.NewVaultKDF(loginMaster)
+			if err != nil {
+				log.Println(`[ERROR]:`, err)
+				fmt.Println("vault key generation failed. please try again.")
+				return
+			}
+			// the request is authorized with the new session: the saved one is restored, if it fails.
+			prev, saved := clstor.Users[u.Username]
+			clstor.Users[u.Username] = auth
+			if _, err = c.SetVaultKDF(context.Background(), &pb.SetVaultKDFRequest{Kdf: kdf}); err != nil {
+				if saved {
+					clstor.Users[u.Username] = prev
+				} else {
+					delete(clstor.Users, u.Username)
+				}
+				log.Println(`[ERROR]:`, err)
+				fmt.Println("master password setup failed. please try again.")
+				return
+			}
+			auth.VaultKey = key
+		}
+		auth.KDFParams, auth.KDFCheck = kdf.GetParams(), kdf.GetCheck()
+		clstor.Users[u.Username] = auth
+
 		// check for nil vault
 		// update to latest data
 		locV, ok := clstor.Local[u.Username]
@@ -59,35 +104,31 @@ Usage: gophkeeperclient loginUser --login=<login> --password=<password>.`,
 			clstor.Local[u.Username] = locV
 		}
 
-		// after successful login - get JWT and send to server to synchronize data.
-		ctxWTKN := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+logResp.Jwt)
-
-		syncResp, err := c.SyncVault(ctxWTKN, &syncData)
-		if err != nil {
-			log.Println(`[ERROR]:`, err)
-			fmt.Println("request failed. please try again.")
-			return
+		// after successful login - full synchronization (cursor 0), the local cursor could belong to another account.
+		// Queued local changes are sent with it, the plaintext items, kept by the server, are sealed and sent first.
+		// JWT is added to the request by the client interceptor.
+		locV.Cursor = 0
+		locV.LegacySealed = false
+		fmt.Println("Synchronizing: ")
+		if replayOutbox(u.Username, clserv.VaultKey(auth.VaultKey), true) {
+			fmt.Println("success")
 		}
-
-		fmt.Println("Get latest data from server: ", syncResp.GetStatus())
-
-		fmt.Print("Synchronizing: ")
-		updVault := clserv.CombineVault(clstor.Local[u.Username], clserv.VaultSyncConvert(syncResp))
-		//save actual data
-		clstor.Local[u.Username] = updVault
-
-		fmt.Println(syncResp.GetStatus())
 	},
 }
 
 var (
-	loginUser pb.LoginUserRequest
+	loginUser    pb.LoginUserRequest
+	loginMaster  string
+	loginSecrets secretInput
 )
 
 func init() {
 	rootCmd.AddCommand(loginUserCmd)
 	loginUserCmd.Flags().StringVarP(&loginUser.ServiceLogin, "login", "l", "", "New user login value.")
 	loginUserCmd.Flags().StringVarP(&loginUser.ServicePass, "password", "p", "", "New user password value.")
+	loginUserCmd.Flags().StringVarP(&loginMaster, "master", "m", "", "Master password. Used to decrypt the vault data.")
 	loginUserCmd.MarkFlagRequired("login")
-	loginUserCmd.MarkFlagRequired("password")
+	loginSecrets.add("password", "Password", &loginUser.ServicePass, false)
+	loginSecrets.add("master", "Master password", &loginMaster, false)
+	loginSecrets.addFlags(loginUserCmd)
 }
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+	"log"
+	"os/user"
+	"time"
+)
+
+// logoutUserCmd represents the logoutUser command
+var logoutUserCmd = &cobra.Command{
+	Use:   "logoutUser",
+	Short: "Logout user from the service",
+	Long: `
+This command ends the current session on the server and removes the local session tokens.
+Local vault data is kept.
+Usage: gophkeeperclient logoutUser`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// get current user from os/user. Like this we can locally identify if the user changed.
+		u, err := user.Current()
+		if err != nil {
+			log.Fatalln(err)
+			return
+		}
+		if _, ok := clstor.Users[u.Username]; !ok {
+			fmt.Println("User not authenticated.")
+			return
+		}
+
+		// request with 3s timeout.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+		defer cancel()
+
+		c, err := grpcclient.DialUp()
+		if err != nil {
+			log.Fatalln(err)
+			return
+		}
+
+		response, err := c.Logout(ctx, &pb.LogoutRequest{})
+		if err != nil {
+			// tokens are removed anyway - session will expire on its own.
+			st, _ := status.FromError(err)
+			fmt.Printf("Server session was not closed.\nStatusCode: %v\nMessage: %s\n", st.Code(), st.Message())
+		} else {
+			fmt.Println(response.GetStatus())
+		}
+
+		delete(clstor.Users, u.Username)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(logoutUserCmd)
+}
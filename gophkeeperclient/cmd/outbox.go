@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"log"
+	"time"
+)
+
+// replayOutbox sends the queued changes of the user and pulls the server changes. Without force, the replay is
+// skipped until the outbox backoff passes. Returns true, if the server was reached.
+func replayOutbox(userName string, key clserv.VaultKey, force bool) bool {
+	outbox := clstor.UserOutbox(userName)
+	if !force && !outbox.Due(time.Now()) {
+		if len(outbox.Ops) > 0 {
+			fmt.Printf("%d change(s) queued. Next automatic retry after %s, or run syncVault.\n",
+				len(outbox.Ops), outbox.NextAttempt.Format(time.RFC3339))
+		}
+		return false
+	}
+
+	c, err := grpcclient.DialUp()
+	if err != nil {
+		log.Println(`[ERROR]:`, err)
+		fmt.Println("connection setup failed. please check your configuration.")
+		return false
+	}
+
+	// merged versions and conflict copies are queued by the sync itself - they are sent with one more round.
+	for round := 0; round < 2; round++ {
+		if round > 0 && (len(outbox.Ops) == 0 || outbox.Attempts > 0) {
+			break
+		}
+		if !syncRound(c, userName, outbox, key) {
+			return false
+		}
+	}
+
+	if len(outbox.Ops) > 0 {
+		fmt.Printf("%d change(s) are still queued. Run pending to see them.\n", len(outbox.Ops))
+	}
+	if n := len(clstor.Local[userName].Conflicts); n > 0 {
+		fmt.Printf("%d unresolved conflict(s). Run conflicts to resolve them.\n", n)
+	}
+
+	return true
+}
+
+// syncRound makes one synchronization request. Returns true, if the server was reached.
+func syncRound(c pb.KeeperClient, userName string, outbox *clstor.Outbox, key clserv.VaultKey) bool {
+	// request with 3s timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	vault, report, err := clserv.SyncVault(ctx, c, clstor.Local[userName], outbox, key)
+	printSyncReport(report)
+	if err != nil {
+		log.Println(`[ERROR]:`, err)
+		if len(outbox.Ops) > 0 {
+			fmt.Printf("Server request failed. %d change(s) queued and will be sent later.\n", len(outbox.Ops))
+		} else {
+			fmt.Println("request failed. please try again.")
+		}
+		return false
+	}
+
+	clstor.Local[userName] = vault
+	return true
+}
+
+// printSyncReport prints the results of the replayed changes and the conflicts handling.
+func printSyncReport(report *clserv.SyncReport) {
+	for _, line := range syncReportLines(report) {
+		fmt.Println(line)
+	}
+}
+
+// syncReportLines returns the lines of the sync report: the agent sends them to the client.
+func syncReportLines(report *clserv.SyncReport) []string {
+	if report == nil {
+		return nil
+	}
+
+	var lines []string
+	if report.Sealed > 0 {
+		lines = append(lines, fmt.Sprintf("%d item(s), kept by the server in plaintext, sealed", report.Sealed))
+	}
+	if report.SealErr != nil {
+		lines = append(lines, fmt.Sprintf("WARNING: plaintext items are not sealed, will be retried: %v", report.SealErr))
+	}
+	for _, r := range report.Results {
+		switch r.GetStatus() {
+		case pb.SyncItemStatus_SYNC_ACCEPTED:
+			lines = append(lines, fmt.Sprintf("%s %q version %d: saved", r.GetType(), r.GetTitle(), r.GetVersion()))
+		case pb.SyncItemStatus_SYNC_CONFLICT:
+			lines = append(lines, fmt.Sprintf("%s %q: changed on another device, server version is %d",
+				r.GetType(), r.GetTitle(), r.GetVersion()))
+		case pb.SyncItemStatus_SYNC_FAILED:
+			lines = append(lines, fmt.Sprintf("WARNING: %s %q version %d: not saved, will be retried: %s",
+				r.GetType(), r.GetTitle(), r.GetVersion(), r.GetMessage()))
+		default:
+			lines = append(lines, fmt.Sprintf("WARNING: %s %q version %d: rejected: %s",
+				r.GetType(), r.GetTitle(), r.GetVersion(), r.GetMessage()))
+		}
+	}
+
+	for _, m := range report.Merges {
+		switch {
+		case m.Merged:
+			lines = append(lines, fmt.Sprintf("%s %q: concurrent changes merged", m.Type, m.Title))
+		case m.Conflict == nil:
+			lines = append(lines, fmt.Sprintf("%s %q: deleted on both devices", m.Type, m.Title))
+		case m.Conflict.LocalDeleted:
+			lines = append(lines, fmt.Sprintf("WARNING: %s %q: deleted locally, but changed on another device. The item is kept",
+				m.Type, m.Title))
+		default:
+			lines = append(lines, fmt.Sprintf("WARNING: %s %q: conflicting changes, the local version is kept as %q",
+				m.Type, m.Title, m.Conflict.CopyTitle))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"log"
+	"os/user"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// pendingCmd represents the pending command
+var pendingCmd = &cobra.Command{
+	Use:   "pending",
+	Short: "List the local changes, not acknowledged by the server yet",
+	Long: `
+This command lists the queued local changes in the order they will be sent to the server.
+Changes are sent automatically by the next command, that connects to the server, or by syncVault.
+Usage: gophkeeperclient pending`,
+	Run: func(cmd *cobra.Command, args []string) {
+		u, err := user.Current()
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		outbox := clstor.UserOutbox(u.Username)
+		if len(outbox.Ops) == 0 {
+			fmt.Println("No pending changes.")
+			return
+		}
+
+		for i, op := range outbox.Ops {
+			action := "save"
+			if op.Deleted {
+				action = "delete"
+			}
+			fmt.Printf("%d. %s %s %q version %d, queued at %s\n",
+				i+1, action, op.Type, op.Title, op.Version, op.CreatedAt.Format(time.RFC3339))
+		}
+
+		if outbox.Attempts > 0 {
+			fmt.Printf("Failed attempts: %d. Last error: %s\nNext automatic retry after %s.\n",
+				outbox.Attempts, outbox.LastError, outbox.NextAttempt.Format(time.RFC3339))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pendingCmd)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"log"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// purgeCmd represents the purge command
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Erase all the versions of an item",
+	Long: `
+This command erases the item and all its stored versions on the server. Unlike the del* commands, the data can't be restored.
+The item is left as deleted, so your other devices delete their copies with the next sync. Then your vault is synchronized.
+Usage: gophkeeperclient purge --type=pair|text|bin|card --id=<item_id> | --title=<title> [--yes]`,
+	Run: func(cmd *cobra.Command, args []string) {
+		u, err := user.Current()
+		if err != nil {
+			log.Fatalln(err)
+		}
+		auth, ok := clstor.Users[u.Username]
+		if !ok {
+			fmt.Println("User not authenticated.")
+			return
+		}
+		if len(auth.VaultKey) == 0 {
+			fmt.Println("Vault is locked. Please login with your master password.")
+			return
+		}
+		if purgeReq.Id == `` && purgeReq.Title == `` {
+			fmt.Println("Please pass the item --id or --title.")
+			return
+		}
+		key := clserv.VaultKey(auth.VaultKey)
+
+		if !purgeYes {
+			fmt.Printf("Erase all the versions of %s %s? This can't be undone [y/N]: ", purgeReq.Type,
+				itemRef(purgeReq.Id, purgeReq.Title))
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+				fmt.Println("cancelled")
+				return
+			}
+		}
+
+		// queued changes go first, so they don't bring the erased item back.
+		if len(clstor.UserOutbox(u.Username).Ops) > 0 && !replayOutbox(u.Username, key, true) {
+			fmt.Println("Purge needs the server connection. Please try again later.")
+			return
+		}
+
+		// request with 3s timeout. ctx WithTimeOut
+		ctxWTO, cancel := context.WithTimeout(context.Background(), time.Second*3)
+		defer cancel()
+
+		c, err := grpcclient.DialUp()
+		if err != nil {
+			log.Println(`[ERROR]:`, err)
+			fmt.Println("connection setup failed. please check your configuration.")
+			return
+		}
+
+		resp, err := c.PurgeItem(ctxWTO, &purgeReq)
+		if err != nil {
+			printStatusError(err)
+			return
+		}
+		fmt.Printf("%d version(s) erased\n", resp.GetPurged())
+
+		// the local copy is replaced with the tombstone by the sync.
+		replayOutbox(u.Username, key, true)
+	},
+}
+
+var (
+	purgeReq pb.PurgeItemRequest
+	purgeYes bool
+)
+
+func init() {
+	rootCmd.AddCommand(purgeCmd)
+	purgeCmd.Flags().StringVar(&purgeReq.Type, "type", "", "Item type: pair, text, bin, card or otp.")
+	purgeCmd.Flags().StringVar(&purgeReq.Id, "id", "", "Item id.")
+	purgeCmd.Flags().StringVarP(&purgeReq.Title, "title", "t", "", "Item title.")
+	purgeCmd.Flags().BoolVarP(&purgeYes, "yes", "y", false, "Don't ask for the confirmation.")
+	purgeCmd.MarkFlagRequired("type")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
 	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
 	pb "github.com/EestiChameleon/gophkeeper/proto"
 	"github.com/spf13/cobra"
@@ -18,8 +19,14 @@ var registerUserCmd = &cobra.Command{
 	Short: "Register new user in the service.",
 	Long: `
 This command register a new user.
-Usage: gophkeeperclient registerUser --login=<login> --password=<password>.`,
+Items are encrypted on this device with a key derived from the master password. The server never receives
+the master password or the key, so it can't be restored - keep it safe.
+The password and master password are prompted without echo, if omitted, or read with --from-stdin or --from-file.
+Usage: gophkeeperclient registerUser --login=<login> [--password=<password>] [--master=<master_password>] [--from-stdin|--from-file=<path>].`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if !registerSecrets.read(cmd) {
+			return
+		}
 		// get current user from os/user. Like this we can locally identify if the user changed.
 		u, err := user.Current()
 		if err != nil {
@@ -27,6 +34,15 @@ Usage: gophkeeperclient registerUser --login=<login> --password=<password>.`,
 			return
 		}
 
+		// derive the vault key. Server keeps only the derivation parameters and the check value.
+		kdf, key, err := clserv.NewVaultKDF(registerMaster)
+		if err != nil {
+			log.Println(`[ERROR]:`, err)
+			fmt.Println("vault key generation failed. please try again.")
+			return
+		}
+		registerUser.Kdf = kdf
+
 		// request with 3s timeout.
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 		defer cancel()
@@ -45,8 +61,15 @@ Usage: gophkeeperclient registerUser --login=<login> --password=<password>.`,
 			return
 		}
 
-		// save local pair - localUserName -> JWT
-		clstor.Users[u.Username] = response.GetJwt()
+		// save local pair - localUserName -> session tokens
+		clstor.Users[u.Username] = &clstor.UserAuth{
+			JWT:          response.GetJwt(),
+			RefreshToken: response.GetRefreshToken(),
+			ExpiresAt:    response.GetExpiresAt(),
+			VaultKey:     key,
+			KDFParams:    kdf.GetParams(),
+			KDFCheck:     kdf.GetCheck(),
+		}
 		// init for the new user local storage
 		clstor.Local[u.Username] = clstor.MakeVault()
 		// return response
@@ -55,13 +78,18 @@ Usage: gophkeeperclient registerUser --login=<login> --password=<password>.`,
 }
 
 var (
-	registerUser pb.RegisterUserRequest
+	registerUser    pb.RegisterUserRequest
+	registerMaster  string
+	registerSecrets secretInput
 )
 
 func init() {
 	rootCmd.AddCommand(registerUserCmd)
 	registerUserCmd.Flags().StringVarP(&registerUser.ServiceLogin, "login", "l", "", "New user login value.")
 	registerUserCmd.Flags().StringVarP(&registerUser.ServicePass, "password", "p", "", "New user password value.")
+	registerUserCmd.Flags().StringVarP(&registerMaster, "master", "m", "", "Master password. Used to encrypt the vault data.")
 	registerUserCmd.MarkFlagRequired("login")
-	registerUserCmd.MarkFlagRequired("password")
+	registerSecrets.add("password", "Password", &registerUser.ServicePass, true)
+	registerSecrets.add("master", "Master password", &registerMaster, true)
+	registerSecrets.addFlags(registerUserCmd)
 }
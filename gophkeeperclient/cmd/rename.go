@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"log"
+	"os/user"
+
+	"github.com/spf13/cobra"
+)
+
+// renameCmd represents the rename command
+var renameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Rename an item",
+	Long: `
+This command saves the next version of the item with the new title. The item data and id are not changed,
+the old versions keep their titles, see history.
+Usage: gophkeeperclient rename --type=pair|text|bin|card --id=<item_id> | --title=<title> --new-title=<new_title>`,
+	Run: func(cmd *cobra.Command, args []string) {
+		u, err := user.Current()
+		if err != nil {
+			log.Fatalln(err)
+		}
+		auth, ok := clstor.Users[u.Username]
+		if !ok {
+			fmt.Println("User not authenticated.")
+			return
+		}
+		if len(auth.VaultKey) == 0 {
+			fmt.Println("Vault is locked. Please login with your master password.")
+			return
+		}
+		key := clserv.VaultKey(auth.VaultKey)
+		vault, ok := clstor.Local[u.Username]
+		if !ok {
+			fmt.Println("User not found. Please register.")
+			return
+		}
+		if renameTo == `` {
+			fmt.Println("Please pass the new title.")
+			return
+		}
+
+		id, ok := findItem(vault, renameType, renameID, renameTitle)
+		if !ok {
+			return
+		}
+		err = clserv.RenameItem(vault, clstor.UserOutbox(u.Username), renameType, id, renameTo, key)
+		if errors.Is(err, clserv.ErrItemNotFound) {
+			fmt.Printf("Nothing found for %s\nMake sure you have the latest version by synchronizing your vault.\n",
+				itemRef(renameID, renameTitle))
+			return
+		}
+		if err != nil {
+			log.Println(`[ERROR]:`, err)
+			fmt.Println("rename failed:", err)
+			return
+		}
+
+		if err = clstor.UpdateFiles(); err != nil {
+			log.Println(`[ERROR]:`, err)
+			fmt.Println("local storage update failed. please try again.")
+			return
+		}
+		fmt.Println("renamed locally")
+
+		replayOutbox(u.Username, key, false)
+	},
+}
+
+var (
+	renameType  string
+	renameID    string
+	renameTitle string
+	renameTo    string
+)
+
+func init() {
+	rootCmd.AddCommand(renameCmd)
+	renameCmd.Flags().StringVar(&renameType, "type", "", "Item type: pair, text, bin, card or otp.")
+	renameCmd.Flags().StringVar(&renameID, "id", "", "Item id.")
+	renameCmd.Flags().StringVarP(&renameTitle, "title", "t", "", "Current item title.")
+	renameCmd.Flags().StringVarP(&renameTo, "new-title", "n", "", "New item title.")
+	renameCmd.MarkFlagRequired("type")
+	renameCmd.MarkFlagRequired("new-title")
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"log"
+	"os/user"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore an old version or a deleted item",
+	Long: `
+This command saves the old version of the item on the server as the new latest version, then synchronizes your vault.
+Without --version the latest version before the deletion is restored, so the deleted item comes back.
+The version data is sealed for its version, so it is opened and sealed again for the new latest version on this device.
+See history for the stored versions.
+Usage: gophkeeperclient restore --type=pair|text|bin|card --id=<item_id> | --title=<title> [--version=<version>]`,
+	Run: func(cmd *cobra.Command, args []string) {
+		u, err := user.Current()
+		if err != nil {
+			log.Fatalln(err)
+		}
+		auth, ok := clstor.Users[u.Username]
+		if !ok {
+			fmt.Println("User not authenticated.")
+			return
+		}
+		if len(auth.VaultKey) == 0 {
+			fmt.Println("Vault is locked. Please login with your master password.")
+			return
+		}
+		if restoreReq.Id == `` && restoreReq.Title == `` {
+			fmt.Println("Please pass the item --id or --title.")
+			return
+		}
+		key := clserv.VaultKey(auth.VaultKey)
+
+		// queued changes go first, so the restored version is the latest one.
+		if len(clstor.UserOutbox(u.Username).Ops) > 0 && !replayOutbox(u.Username, key, true) {
+			fmt.Println("Restore needs the server connection. Please try again later.")
+			return
+		}
+
+		// request with 3s timeout. ctx WithTimeOut
+		ctxWTO, cancel := context.WithTimeout(context.Background(), time.Second*3)
+		defer cancel()
+
+		c, err := grpcclient.DialUp()
+		if err != nil {
+			log.Println(`[ERROR]:`, err)
+			fmt.Println("connection setup failed. please check your configuration.")
+			return
+		}
+
+		version, err := clserv.RestoreItem(ctxWTO, c, &restoreReq, key)
+		if errors.Is(err, clserv.ErrVersionNotFound) {
+			fmt.Println("No version to restore. See history for the stored versions.")
+			return
+		}
+		if err != nil {
+			printStatusError(err)
+			return
+		}
+		fmt.Printf("restored as version %d\n", version)
+
+		// the restored version is received with the sync.
+		replayOutbox(u.Username, key, true)
+	},
+}
+
+var (
+	restoreReq pb.RestoreItemRequest
+)
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+	restoreCmd.Flags().StringVar(&restoreReq.Type, "type", "", "Item type: pair, text, bin, card or otp.")
+	restoreCmd.Flags().StringVar(&restoreReq.Id, "id", "", "Item id.")
+	restoreCmd.Flags().StringVarP(&restoreReq.Title, "title", "t", "", "Item title.")
+	restoreCmd.Flags().Uint32VarP(&restoreReq.Version, "version", "v", 0, "Version to restore. Optional.")
+	restoreCmd.MarkFlagRequired("type")
+}
@@ -5,10 +5,15 @@ Copyright © 2022 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/agent"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/cfg"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -35,3 +40,104 @@ func Execute() {
 		os.Exit(1)
 	}
 }
+
+var (
+	flagged    = cfg.Default()
+	configPath = os.Getenv("GOPHKEEPER_CLIENT_CONFIG")
+	// inShell is set, while the shell runs the commands: the config and storage are loaded by the shell.
+	inShell bool
+	// storageLoaded is set, while the storage is read and locked by the command.
+	storageLoaded bool
+	// agentClient is the connection to the running agent. The commands, it serves, don't read the storage.
+	agentClient *agent.Client
+	noAgent     bool
+)
+
+// agentCommands are the commands, the agent serves: they read the vault, only getOTP and syncVault change it.
+// getBinary is not served: the file is streamed from the server by the command itself.
+var agentCommands = map[string]bool{"getPair": true, "getText": true, "getCard": true, "getOTP": true, "list": true,
+	"search": true, "syncVault": true}
+
+// loadConfig builds the runtime config (flags > env > config file > defaults) and reads the local storage.
+func loadConfig(cmd *cobra.Command, args []string) error {
+	if inShell {
+		return nil
+	}
+	var changed []string
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		changed = append(changed, f.Name)
+	})
+
+	conf, err := cfg.Load(flagged, changed, configPath)
+	if err != nil {
+		return err
+	}
+	cfg.Current = conf
+
+	if dialAgent(cmd) {
+		return nil
+	}
+	if err = clstor.InitStorage(); err != nil {
+		return err
+	}
+	storageLoaded = true
+	return nil
+}
+
+// dialAgent connects to the agent, if it runs and serves the command. Returns false, if the command
+// reads the local storage itself.
+func dialAgent(cmd *cobra.Command) bool {
+	if noAgent || cfg.Current.AgentSocket == `` || !agentCommands[cmd.Name()] {
+		return false
+	}
+	c, err := agent.Dial(cfg.Current.AgentSocket)
+	if err != nil {
+		if errors.Is(err, agent.ErrSocketNotOwned) {
+			fmt.Fprintf(os.Stderr, "Warning: %v. The agent is not used.\n", err)
+		}
+		return false
+	}
+	agentClient = c
+	return true
+}
+
+// saveStorage rewrites the local storage files with the actual data and releases the storage lock.
+func saveStorage(cmd *cobra.Command, args []string) error {
+	if inShell {
+		return nil
+	}
+	if agentClient != nil {
+		err := agentClient.Close()
+		agentClient = nil
+		return err
+	}
+	// the storage is released by the command itself - the agent does it.
+	if !storageLoaded {
+		return nil
+	}
+	storageLoaded = false
+
+	fmt.Println("Update service data")
+	if err := clstor.UpdateFiles(); err != nil {
+		return err
+	}
+	return clstor.Unlock()
+}
+
+func init() {
+	rootCmd.PersistentPreRunE = loadConfig
+	rootCmd.PersistentPostRunE = saveStorage
+
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", configPath, "Path to JSON config file.")
+	rootCmd.PersistentFlags().StringVar(&flagged.ServerAddress, "server", flagged.ServerAddress, "Server address host:port.")
+	rootCmd.PersistentFlags().StringVar(&flagged.UsersFile, "users-file", flagged.UsersFile, "Local users auth data file.")
+	rootCmd.PersistentFlags().StringVar(&flagged.VaultFile, "vault-file", flagged.VaultFile, "Local vault data file.")
+	rootCmd.PersistentFlags().StringVar(&flagged.OutboxFile, "outbox-file", flagged.OutboxFile, "Local changes queue file.")
+	rootCmd.PersistentFlags().StringVar(&flagged.KeyFile, "key-file", flagged.KeyFile, "Local files encryption key. Created on the first run.")
+	rootCmd.PersistentFlags().StringVar(&flagged.TLSCAFile, "tls-ca", flagged.TLSCAFile, "CA bundle to verify the server certificate. Empty - system CAs are used.")
+	rootCmd.PersistentFlags().StringVar(&flagged.TLSServerFingerprint, "tls-fingerprint", flagged.TLSServerFingerprint, "Pinned sha256 fingerprint of the server certificate (hex). Optional.")
+	rootCmd.PersistentFlags().StringVar(&flagged.TLSClientCertFile, "tls-cert", flagged.TLSClientCertFile, "Client certificate for mutual TLS. Optional.")
+	rootCmd.PersistentFlags().StringVar(&flagged.TLSClientKeyFile, "tls-key", flagged.TLSClientKeyFile, "Client certificate key for mutual TLS. Optional.")
+	rootCmd.PersistentFlags().StringVar(&flagged.AgentSocket, "agent-socket", flagged.AgentSocket, "Unix socket of the agent. Empty - the agent is not used.")
+	rootCmd.PersistentFlags().BoolVar(&noAgent, "no-agent", false, "Don't use the running agent: read the local storage.")
+}
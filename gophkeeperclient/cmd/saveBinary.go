@@ -4,14 +4,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
 	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
 	"github.com/EestiChameleon/gophkeeper/models"
-	pb "github.com/EestiChameleon/gophkeeper/proto"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"log"
-	"os/user"
-	"time"
+	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -22,77 +20,105 @@ var saveBinaryCmd = &cobra.Command{
 	Short: "Save a new binary data",
 	Long: `
 This command allows to the authenticated user to save new binary data.
-Usage: gophkeeperclient saveBinary --title=<title_for_saved_data> --body=<binary_data> --comment=<comment_for_saved_data>.`,
+The item is found by title, if --id is not passed. With --id the new title renames the item.
+The data is passed base64 encoded with --body, or as a file path with --file. Files are streamed to the server
+by chunks, they could be of any size. The file is uploaded at once and is not kept in the local vault.
+Custom fields are passed with --field: the type is text (default), hidden, url or date. The tags are passed with --tag.
+Usage: gophkeeperclient saveBinary [--id=<item_id>] --title=<title_for_saved_data> --body=<binary_data> --comment=<comment_for_saved_data> [--field=<name[:type]=value>...] [--tag=<tag>...].
+Usage: gophkeeperclient saveBinary [--id=<item_id>] --title=<title_for_saved_data> --file=<path_to_file> --comment=<comment_for_saved_data> [--field=<name[:type]=value>...] [--tag=<tag>...].`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// check for user auth
-		user, err := user.Current()
-		if err != nil {
-			log.Fatalln(err)
+		if (saveBinFile == ``) == (saveBin.Body == nil) {
+			fmt.Println("Please pass the data with --body or --file.")
+			return
 		}
-		jwt, ok := clstor.Users[user.Username]
-		if !ok {
-			fmt.Println("User not authenticated.")
+		var ok bool
+		if saveBin.Fields, saveBin.Tags, ok = saveBinExtras.parse(); !ok {
 			return
 		}
-		// search user local vault
-		vault, ok := clstor.Local[user.Username]
-		if !ok {
-			fmt.Println("User not found. Please register.")
+		if !saveBinSecrets.read(cmd) {
 			return
 		}
-		// search for local version
-		bin, ok := vault.Bin[saveBin.Title]
-		// local version exists - return it.
-		if ok {
-			// we save new version - so we take current version + 1
-			saveBin.Version = bin.Version + 1
-		} else {
-			// not found - version = 1 - first new
-			saveBin.Version = 1
+		if saveBinFile == `` {
+			saveItem("bin", &saveBin, &saveBin.ItemID)
+			return
 		}
 
-		// request with 3s timeout. ctx WithTimeOut
-		ctxWTO, cancel := context.WithTimeout(context.Background(), time.Second*3)
-		defer cancel()
-
-		c, err := grpcclient.DialUp()
-		if err != nil {
-			log.Fatalln(err)
+		userName, key, vault, ok := userVault()
+		if !ok {
 			return
 		}
-
-		// Add token to gRPC Request. ctx WithToKeN
-		ctxWTKN := metadata.AppendToOutgoingContext(ctxWTO, "authorization", "Bearer "+jwt)
-
-		// send data to server and receive JWT in case of success. then save it in Users
-		response, err := c.PostBin(ctxWTKN, &pb.PostBinRequest{BinData: &saveBin})
+		// the item is passed by id or found by title. New item gets a new id.
+		var err error
+		saveBin.ItemID, err = clserv.SaveItemID(vault, "bin", saveBin.ItemID, saveBin.Title)
 		if err != nil {
-			st, ok := status.FromError(err)
-			if !ok {
-				// Error was not a status error
-				fmt.Println("request failed. please try again.")
-			}
-			msg := fmt.Sprintf("Request failed.\nStatusCode: %v\nMessage: %s", st.Code(), st.Message())
-			fmt.Println(msg)
+			printItemError(err, saveBin.Title)
 			return
 		}
-
-		// save data to local
-		vault.Bin[saveBin.Title] = models.ProtoToModelsBin(&saveBin)
-		// successful response
-		fmt.Println(response.GetStatus())
+		uploadBinary(userName, key)
 	},
 }
 
 var (
-	saveBin pb.Bin
+	saveBin        models.Bin
+	saveBinFile    string
+	saveBinExtras  itemExtras
+	saveBinSecrets secretInput
 )
 
 func init() {
 	rootCmd.AddCommand(saveBinaryCmd)
+	saveBinaryCmd.Flags().StringVarP(&saveBin.ItemID, "id", "", "", "Item id to save the new version of. Optional.")
 	saveBinaryCmd.Flags().StringVarP(&saveBin.Title, "title", "t", "", "Binary data title to save.")
-	saveBinaryCmd.Flags().BytesBase64VarP(&saveBin.Body, "body", "b", nil, "Binary data to save.")
+	saveBinaryCmd.Flags().BytesBase64VarP(&saveBin.Body, "body", "b", nil, "Binary data to save, base64 encoded.")
+	saveBinaryCmd.Flags().StringVarP(&saveBinFile, "file", "f", "", "File to upload instead of --body.")
 	saveBinaryCmd.Flags().StringVarP(&saveBin.Comment, "comment", "c", "", "Comment for the saved binary data (optional).")
+	saveBinExtras.addFlags(saveBinaryCmd, &saveBinSecrets)
+	saveBinSecrets.addFlags(saveBinaryCmd)
 	saveBinaryCmd.MarkFlagRequired("title")
-	saveBinaryCmd.MarkFlagRequired("body")
+}
+
+// uploadBinary streams the file to the server as the new version of the binary data. The local vault keeps
+// the item without the body.
+func uploadBinary(userName string, key clserv.VaultKey) {
+	f, err := os.Open(saveBinFile)
+	if err != nil {
+		log.Println(`[ERROR]:`, err)
+		fmt.Println("file open failed:", err)
+		return
+	}
+	defer f.Close()
+
+	// queued changes go first, so the versions are sent in order.
+	if len(clstor.UserOutbox(userName).Ops) > 0 && !replayOutbox(userName, key, true) {
+		fmt.Println("File upload needs the server connection. Please try again later.")
+		return
+	}
+
+	vault := clstor.Local[userName]
+	saveBin.Version = 1
+	if bin, ok := vault.Bin[saveBin.ItemID]; ok {
+		saveBin.Version = bin.Version + 1
+	}
+
+	c, err := grpcclient.DialUp()
+	if err != nil {
+		log.Println(`[ERROR]:`, err)
+		fmt.Println("connection setup failed. please check your configuration.")
+		return
+	}
+
+	size, err := clserv.UploadBin(context.Background(), c, &saveBin, f, key)
+	if err != nil {
+		log.Println(`[ERROR]:`, err)
+		if st, ok := status.FromError(err); ok {
+			fmt.Printf("upload failed\nStatusCode: %v\nMessage: %s\n", st.Code(), st.Message())
+			return
+		}
+		fmt.Println("upload failed. please try again.")
+		return
+	}
+
+	saveBin.Streamed, saveBin.Size = true, size
+	vault.Bin[saveBin.ItemID] = &saveBin
+	fmt.Printf("uploaded: %d bytes\n", size)
 }
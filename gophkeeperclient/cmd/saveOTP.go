@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	"github.com/EestiChameleon/gophkeeper/models"
+
+	"github.com/spf13/cobra"
+)
+
+// saveOTPCmd represents the saveOTP command
+var saveOTPCmd = &cobra.Command{
+	Use:   "saveOTP",
+	Short: "Save a new one-time password generator",
+	Long: `
+This command allows to the authenticated user to save the one-time password (TOTP or HOTP) generator data.
+The data is passed with the flags, or imported from the otpauth:// URI, exported by the authenticator apps.
+The secret is prompted without echo, if both --secret and --uri are omitted, or read with --from-stdin or --from-file.
+The flags, passed with --uri, replace the URI values. The URI label is the default title.
+The item is found by title, if --id is not passed. With --id the new title renames the item.
+Custom fields are passed with --field: the type is text (default), hidden, url or date. The tags are passed with --tag.
+Usage: gophkeeperclient saveOTP [--id=<item_id>] --title=<title> [--secret=<base32_secret>|--from-stdin|--from-file=<path>] [--issuer=<issuer>] [--account=<account>] [--kind=totp|hotp] [--algorithm=SHA1|SHA256|SHA512] [--digits=6] [--period=30] [--counter=0] [--comment=<comment>] [--field=<name[:type]=value>...] [--tag=<tag>...].
+Usage: gophkeeperclient saveOTP [--id=<item_id>] [--title=<title>] --uri=<otpauth_uri> [--comment=<comment>] [--field=<name[:type]=value>...] [--tag=<tag>...].`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fields, tags, ok := saveOTPExtras.parse()
+		if !ok {
+			return
+		}
+		var skip []string
+		if saveOTPURI != `` {
+			skip = append(skip, "secret")
+		}
+		if !saveOTPSecrets.read(cmd, skip...) {
+			return
+		}
+		otp := saveOTP
+		if saveOTPURI != `` {
+			warnSecretFlag("uri")
+			imported, err := clserv.ParseOTPURI(saveOTPURI)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			// passed flags replace the URI values
+			flags := cmd.Flags()
+			for name, set := range map[string]func(){
+				"title":     func() { imported.Title = otp.Title },
+				"secret":    func() { imported.Secret = otp.Secret },
+				"issuer":    func() { imported.Issuer = otp.Issuer },
+				"account":   func() { imported.Account = otp.Account },
+				"kind":      func() { imported.Kind = otp.Kind },
+				"algorithm": func() { imported.Algorithm = otp.Algorithm },
+				"digits":    func() { imported.Digits = otp.Digits },
+				"period":    func() { imported.Period = otp.Period },
+				"counter":   func() { imported.Counter = otp.Counter },
+			} {
+				if flags.Changed(name) {
+					set()
+				}
+			}
+			imported.ItemID, imported.Comment = otp.ItemID, otp.Comment
+			otp = *imported
+		}
+		if otp.Title == `` || otp.Secret == `` {
+			fmt.Println("Please pass the --title and --secret, or the --uri.")
+			return
+		}
+		if err := clserv.NormalizeOTP(&otp); err != nil {
+			fmt.Println(err)
+			return
+		}
+		otp.Fields, otp.Tags = fields, tags
+
+		saveItem("otp", &otp, &otp.ItemID)
+	},
+}
+
+var (
+	saveOTP        models.OTP
+	saveOTPURI     string
+	saveOTPExtras  itemExtras
+	saveOTPSecrets secretInput
+)
+
+func init() {
+	rootCmd.AddCommand(saveOTPCmd)
+	saveOTPCmd.Flags().StringVarP(&saveOTP.ItemID, "id", "", "", "Item id to save the new version of. Optional.")
+	saveOTPCmd.Flags().StringVarP(&saveOTP.Title, "title", "t", "", "OTP title to save.")
+	saveOTPCmd.Flags().StringVarP(&saveOTPURI, "uri", "u", "", "otpauth:// URI to import.")
+	saveOTPCmd.Flags().StringVarP(&saveOTP.Secret, "secret", "s", "", "Base32 encoded secret.")
+	saveOTPCmd.Flags().StringVarP(&saveOTP.Issuer, "issuer", "i", "", "Service, the OTP is issued by. Optional.")
+	saveOTPCmd.Flags().StringVarP(&saveOTP.Account, "account", "a", "", "Account name at the issuer. Optional.")
+	saveOTPCmd.Flags().StringVar(&saveOTP.Kind, "kind", clserv.OTPKindTOTP, "OTP kind: totp or hotp.")
+	saveOTPCmd.Flags().StringVar(&saveOTP.Algorithm, "algorithm", "SHA1", "Hash algorithm: SHA1, SHA256 or SHA512.")
+	saveOTPCmd.Flags().IntVar(&saveOTP.Digits, "digits", 6, "Code length.")
+	saveOTPCmd.Flags().IntVar(&saveOTP.Period, "period", 30, "TOTP code lifetime, seconds.")
+	saveOTPCmd.Flags().Uint64Var(&saveOTP.Counter, "counter", 0, "HOTP counter of the next code.")
+	saveOTPCmd.Flags().StringVarP(&saveOTP.Comment, "comment", "c", "", "Comment for the saved OTP (optional).")
+	saveOTPExtras.addFlags(saveOTPCmd, &saveOTPSecrets)
+	saveOTPSecrets.add("secret", "Secret", &saveOTP.Secret, false)
+	saveOTPSecrets.addFlags(saveOTPCmd)
+}
@@ -5,17 +5,9 @@ Copyright © 2022 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
-	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
 	"github.com/EestiChameleon/gophkeeper/models"
-	pb "github.com/EestiChameleon/gophkeeper/proto"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
-	"log"
-	"os/user"
-	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -26,81 +18,68 @@ var savePairCmd = &cobra.Command{
 	Short: "Save a new pair of login&password",
 	Long: `
 This command allows to the authenticated user to save new pair data.
-Usage: gophkeeperclient savePair --title=<title_for_saved_login&password> --login=<login_to_save> --password=<password_to_save> --comment=<comment_for_saved_login&password>.`,
+The item is found by title, if --id is not passed. With --id the new title renames the item.
+The password is generated with --generate, see the generate command for its flags. Otherwise it is prompted without
+echo, if omitted, or read with --from-stdin or --from-file.
+The sites, the login is used on, are passed with --url. Custom fields, like the security questions, are passed
+with --field: the type is text (default), hidden, url or date. The tags are passed with --tag.
+Usage: gophkeeperclient savePair [--id=<item_id>] --title=<title_for_saved_login&password> --login=<login_to_save> [--password=<password_to_save>|--from-stdin|--from-file=<path>|--generate [<generator_flags>]] --comment=<comment_for_saved_login&password> [--url=<url>...] [--field=<name[:type]=value>...] [--tag=<tag>...].`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// check for user auth
-		user, err := user.Current()
-		if err != nil {
-			log.Fatalln(err)
-		}
-		jwt, ok := clstor.Users[user.Username]
-		if !ok {
-			fmt.Println("User not authenticated.")
+		if savePairGenerate && cmd.Flags().Changed("password") {
+			fmt.Println("Please pass only one of --password and --generate.")
 			return
 		}
-		// search user local vault
-		vault, ok := clstor.Local[user.Username]
-		if !ok {
-			fmt.Println("User not found. Please register.")
+		var ok bool
+		if savePair.Fields, savePair.Tags, ok = savePairExtras.parse(); !ok {
 			return
 		}
-		// search for local version
-		pair, ok := vault.Pair[savePair.Title]
-		// local version exists - return it.
-		if ok {
-			// we save new version - so we take current version + 1
-			savePair.Version = pair.Version + 1
-		} else {
-			// not found - version = 1 - first new
-			savePair.Version = 1
+		var skip []string
+		if savePairGenerate {
+			skip = append(skip, "password")
 		}
-
-		// request with 3s timeout. ctx WithTimeOut
-		ctxWTO, cancel := context.WithTimeout(context.Background(), time.Second*3)
-		defer cancel()
-
-		c, err := grpcclient.DialUp()
-		if err != nil {
-			log.Fatalln(err)
+		if !savePairSecrets.read(cmd, skip...) {
 			return
 		}
-
-		// Add token to gRPC Request. ctx WithToKeN
-		ctxWTKN := metadata.AppendToOutgoingContext(ctxWTO, "authorization", "Bearer "+jwt)
-
-		// send data to server and receive JWT in case of success. then save it in Users
-		response, err := c.PostPair(ctxWTKN, &pb.PostPairRequest{Pair: &savePair})
-		if err != nil {
-			st, ok := status.FromError(err)
-			if !ok {
-				// Error was not a status error
-				fmt.Println("request failed. please try again.")
+		if savePairGenerate {
+			g, err := savePairGenerator.generate()
+			if err != nil {
+				fmt.Println(err)
+				return
 			}
-			msg := fmt.Sprintf("Request failed.\nStatusCode: %v\nMessage: %s", st.Code(), st.Message())
-			fmt.Println(msg)
+			savePair.Pass = g.Secret
+			fmt.Printf("Password generated, entropy: %.0f bits (%s). Use getPair to see it.\n", g.Entropy, g.Strength())
+		}
+		var err error
+		if savePair.URLs, err = clserv.NormalizeURLs(savePairURLs); err != nil {
+			fmt.Println(err)
 			return
 		}
-
-		// successful response
-		// save pair to local
-		vault.Pair[savePair.Title] = models.ProtoToModelsPair(&savePair)
-		// return pair data
-		fmt.Println(response.GetStatus())
-
+		saveItem("pair", &savePair, &savePair.ItemID)
 	},
 }
 
 var (
-	savePair pb.Pair
+	savePair          models.Pair
+	savePairURLs      []string
+	savePairExtras    itemExtras
+	savePairGenerate  bool
+	savePairGenerator generatorFlags
+	savePairSecrets   secretInput
 )
 
 func init() {
 	rootCmd.AddCommand(savePairCmd)
+	savePairCmd.Flags().StringVarP(&savePair.ItemID, "id", "", "", "Item id to save the new version of. Optional.")
 	savePairCmd.Flags().StringVarP(&savePair.Title, "title", "t", "", "Pair title to save.")
 	savePairCmd.Flags().StringVarP(&savePair.Login, "login", "l", "", "Login to save.")
 	savePairCmd.Flags().StringVarP(&savePair.Pass, "password", "p", "", "Password to save.")
 	savePairCmd.Flags().StringVarP(&savePair.Comment, "comment", "c", "", "Comment for the saved pair. Optional.")
+	savePairCmd.Flags().StringArrayVar(&savePairURLs, "url", nil, "Site, the login is used on. Repeatable. Optional.")
+	savePairExtras.addFlags(savePairCmd, &savePairSecrets)
+	savePairCmd.Flags().BoolVar(&savePairGenerate, "generate", false, "Generate the password instead of --password.")
+	savePairGenerator.addFlags(savePairCmd)
+	savePairSecrets.add("password", "Password", &savePair.Pass, false)
+	savePairSecrets.addFlags(savePairCmd)
 	savePairCmd.MarkFlagRequired("title")
 	savePairCmd.MarkFlagRequired("login")
-	savePairCmd.MarkFlagRequired("password")
 }
@@ -1,17 +1,7 @@
 package cmd
 
 import (
-	"context"
-	"fmt"
-	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
-	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
 	"github.com/EestiChameleon/gophkeeper/models"
-	pb "github.com/EestiChameleon/gophkeeper/proto"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
-	"log"
-	"os/user"
-	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -22,81 +12,36 @@ var saveTextCmd = &cobra.Command{
 	Short: "Save a new text data",
 	Long: `
 This command allows to the authenticated user to save new text data.
-Usage: gophkeeperclient saveText --title=<title_for_saved_text> --body=<text_content_to_save> --comment=<comment_for_saved_text>.`,
+The item is found by title, if --id is not passed. With --id the new title renames the item.
+Custom fields are passed with --field: the type is text (default), hidden, url or date. The tags are passed with --tag.
+The text is prompted without echo, if omitted, or read with --from-stdin or --from-file: multiline text is read as is.
+Usage: gophkeeperclient saveText [--id=<item_id>] --title=<title_for_saved_text> [--body=<text_content_to_save>|--from-stdin|--from-file=<path>] --comment=<comment_for_saved_text> [--field=<name[:type]=value>...] [--tag=<tag>...].`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// check for user auth
-		user, err := user.Current()
-		if err != nil {
-			log.Fatalln(err)
-		}
-		jwt, ok := clstor.Users[user.Username]
-		if !ok {
-			fmt.Println("User not authenticated.")
-			return
-		}
-		// search user local vault
-		vault, ok := clstor.Local[user.Username]
-		if !ok {
-			fmt.Println("User not found. Please register.")
+		var ok bool
+		if saveText.Fields, saveText.Tags, ok = saveTextExtras.parse(); !ok {
 			return
 		}
-		// search for local version
-		text, ok := vault.Text[saveText.Title]
-		// local version exists - return it.
-		if ok {
-			// we save new version - so we take current version + 1
-			saveText.Version = text.Version + 1
-		} else {
-			// not found - version = 1 - first new
-			saveText.Version = 1
-		}
-
-		log.Println(saveText.Version)
-
-		// request with 3s timeout. ctx WithTimeOut
-		ctxWTO, cancel := context.WithTimeout(context.Background(), time.Second*3)
-		defer cancel()
-
-		c, err := grpcclient.DialUp()
-		if err != nil {
-			log.Fatalln(err)
+		if !saveTextSecrets.read(cmd) {
 			return
 		}
-
-		// Add token to gRPC Request. ctx WithToKeN
-		ctxWTKN := metadata.AppendToOutgoingContext(ctxWTO, "authorization", "Bearer "+jwt)
-
-		// send data to server and receive JWT in case of success. then save it in Users
-		response, err := c.PostText(ctxWTKN, &pb.PostTextRequest{Text: &saveText})
-		if err != nil {
-			st, ok := status.FromError(err)
-			if !ok {
-				// Error was not a status error
-				fmt.Println("request failed. please try again.")
-			}
-			msg := fmt.Sprintf("Request failed.\nStatusCode: %v\nMessage: %s", st.Code(), st.Message())
-			fmt.Println(msg)
-			return
-		}
-
-		// successful response
-		// save pair to local
-		vault.Text[saveText.Title] = models.ProtoToModelsText(&saveText)
-		// return pair data
-		fmt.Println(response.GetStatus())
-
+		saveItem("text", &saveText, &saveText.ItemID)
 	},
 }
 
 var (
-	saveText pb.Text
+	saveText        models.Text
+	saveTextExtras  itemExtras
+	saveTextSecrets secretInput
 )
 
 func init() {
 	rootCmd.AddCommand(saveTextCmd)
+	saveTextCmd.Flags().StringVarP(&saveText.ItemID, "id", "", "", "Item id to save the new version of. Optional.")
 	saveTextCmd.Flags().StringVarP(&saveText.Title, "title", "t", "", "Text title to save.")
 	saveTextCmd.Flags().StringVarP(&saveText.Body, "body", "b", "", "Text to save.")
 	saveTextCmd.Flags().StringVarP(&saveText.Comment, "comment", "c", "", "Comment for the saved text (optional).")
+	saveTextExtras.addFlags(saveTextCmd, &saveTextSecrets)
 	saveTextCmd.MarkFlagRequired("title")
-	saveTextCmd.MarkFlagRequired("body")
+	saveTextSecrets.add("body", "Text", &saveText.Body, false)
+	saveTextSecrets.addFlags(saveTextCmd)
 }
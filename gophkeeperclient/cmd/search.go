@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search the items by title, login, comment and custom fields",
+	Long: `
+This command lists to the authenticated user the items, that match the query. The titles, logins, URLs, comments,
+and custom fields of the local vault are searched, the case is ignored. The titles, logins and custom field names
+are matched fuzzy too: "gthb" finds "GitHub". Use --exact to match only the texts, containing the query.
+The server is searched for the items, not synchronized yet: the server knows only the titles, so they are matched.
+Such items are marked as remote. Use --local to search only the local vault.
+Usage: gophkeeperclient search <query> [--type=pair|text|bin|card|otp] [--tag=<tag>] [--exact] [--local] [--json].`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		query, fuzzy := args[0], !searchExact
+		var (
+			hits     []clserv.SearchHit
+			warnings []string
+			err      error
+		)
+		if agentClient != nil {
+			hits, warnings, err = agentClient.Search(query, searchType, searchTag, fuzzy, searchLocal)
+		} else {
+			_, key, vault, ok := userVault()
+			if !ok {
+				return
+			}
+			hits, warnings, err = searchVault(vault, key, query, searchType, searchTag, fuzzy, searchLocal)
+		}
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		if !searchJSON {
+			for _, w := range warnings {
+				fmt.Println(w)
+			}
+		}
+
+		if searchJSON {
+			if hits == nil {
+				hits = []clserv.SearchHit{}
+			}
+			printJSON(hits)
+			return
+		}
+		printHits(hits)
+	},
+}
+
+var (
+	searchType  string
+	searchTag   string
+	searchExact bool
+	searchLocal bool
+	searchJSON  bool
+)
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+	searchCmd.Flags().StringVar(&searchType, "type", "", "Item type: pair, text, bin, card or otp. Optional.")
+	searchCmd.Flags().StringVar(&searchTag, "tag", "", "Tag to filter by. Optional.")
+	searchCmd.Flags().BoolVar(&searchExact, "exact", false, "Match only the texts, containing the query.")
+	searchCmd.Flags().BoolVar(&searchLocal, "local", false, "Search only the local vault.")
+	searchCmd.Flags().BoolVar(&searchJSON, "json", false, "Print the found items as JSON.")
+}
+
+const searchServerFailed = "server search failed, only the local vault is searched."
+
+// searchVault searches the vault and, unless local, the server. The local hits are returned with the warning,
+// if the server search failed.
+func searchVault(vault *models.Vault, key clserv.VaultKey, query, dataType, tag string, fuzzy, local bool) (
+	[]clserv.SearchHit, []string, error) {
+	hits, err := clserv.SearchItems(vault, query, dataType, tag, fuzzy)
+	if err != nil || local {
+		return hits, nil, err
+	}
+
+	remote, err := searchServer(query, dataType, fuzzy)
+	if err == nil {
+		var all []clserv.SearchHit
+		if all, err = clserv.AddRemoteHits(vault, hits, remote, query, tag, fuzzy, key); err == nil {
+			return all, nil, nil
+		}
+	}
+	// local results are still useful.
+	log.Println(`[ERROR]:`, err)
+	return hits, []string{searchServerFailed}, nil
+}
+
+// searchServer requests the items of the type, whose title matches the query, from the server.
+func searchServer(query, dataType string, fuzzy bool) ([]*pb.Item, error) {
+	// request with 3s timeout. ctx WithTimeOut
+	ctxWTO, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	c, err := grpcclient.DialUp()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.SearchItems(ctxWTO, &pb.SearchItemsRequest{Query: query, Type: dataType, Fuzzy: fuzzy})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetItems(), nil
+}
+
+// printHits prints the found items as a table.
+func printHits(hits []clserv.SearchHit) {
+	if len(hits) == 0 {
+		fmt.Println("Nothing found.")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tID\tTITLE\tMATCHED\tTAGS")
+	for _, h := range hits {
+		title := h.Title
+		if h.Remote {
+			title += " (remote)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", h.Type, h.ItemID, title, h.Field, strings.Join(h.Tags, ", "))
+	}
+	w.Flush()
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	"golang.org/x/term"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	errNoTerminal      = errors.New("no terminal to prompt the secret. Please pass it with --from-stdin or --from-file")
+	errSecretsMismatch = errors.New("the values don't match")
+)
+
+// secretFlag is the command flag, that carries a secret.
+type secretFlag struct {
+	name string
+	clserv.Secret
+}
+
+// secretInput reads the secrets, omitted on the command line: from stdin, from the file or with the no-echo prompt.
+// Secrets, passed on the command line, are kept in the shell history and seen in the process list - it is warned.
+type secretInput struct {
+	flags []secretFlag
+	// the hidden custom field values, passed without the value, see itemExtras. They are read after the flags.
+	fields    bool
+	hidden    []clserv.Secret
+	fromStdin bool
+	fromFile  string
+}
+
+// add registers the secret flag. The flag itself is added by the command.
+func (s *secretInput) add(name, prompt string, value *string, confirm bool) {
+	s.flags = append(s.flags, secretFlag{name: name, Secret: clserv.Secret{Prompt: prompt, Confirm: confirm, Value: value}})
+}
+
+// addFlags adds the --from-stdin and --from-file flags to the command.
+func (s *secretInput) addFlags(cmd *cobra.Command) {
+	names := make([]string, 0, len(s.flags)+1)
+	for _, f := range s.flags {
+		names = append(names, "--"+f.name)
+	}
+	if s.fields {
+		names = append(names, "the hidden --field values")
+	}
+	order := ``
+	if len(names) > 1 {
+		order = " One per line, in order: " + strings.Join(names, ", ") + "."
+	}
+	cmd.Flags().BoolVar(&s.fromStdin, "from-stdin", false, "Read the omitted secrets from stdin."+order)
+	cmd.Flags().StringVar(&s.fromFile, "from-file", "", "Read the omitted secrets from the file."+order)
+}
+
+// read reads the secrets, not passed on the command line, and the omitted hidden field values. The skipped flags
+// are not read: the command gets the value otherwise. The reason is printed and ok is false, if they can't be read.
+func (s *secretInput) read(cmd *cobra.Command, skip ...string) (ok bool) {
+	var missing []clserv.Secret
+	for _, f := range s.flags {
+		switch {
+		case skipped(f.name, skip):
+		case cmd.Flags().Changed(f.name):
+			warnSecretFlag(f.name)
+		default:
+			missing = append(missing, f.Secret)
+		}
+	}
+	missing = append(missing, s.hidden...)
+
+	src := clserv.SecretSource{FromStdin: s.fromStdin, FromFile: s.fromFile, Stdin: os.Stdin, Prompt: promptSecret}
+	if err := clserv.ReadSecrets(src, missing); err != nil {
+		fmt.Println(err)
+		return false
+	}
+	return true
+}
+
+func skipped(name string, skip []string) bool {
+	for _, s := range skip {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// promptSecret reads the secret from the terminal without echo. The new secret is read twice.
+func promptSecret(prompt string, confirm bool) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return ``, errNoTerminal
+	}
+
+	read := func(prompt string) (string, error) {
+		fmt.Fprint(os.Stderr, prompt+": ")
+		secret, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		return string(secret), err
+	}
+	secret, err := read(prompt)
+	if err != nil || !confirm {
+		return secret, err
+	}
+	again, err := read("Repeat " + strings.ToLower(prompt[:1]) + prompt[1:])
+	if err != nil {
+		return ``, err
+	}
+	if again != secret {
+		return ``, errSecretsMismatch
+	}
+	return secret, nil
+}
+
+// warnSecretFlag warns, that the secret is passed on the command line.
+func warnSecretFlag(name string) {
+	fmt.Fprintf(os.Stderr, "Warning: --%s is passed on the command line: it is kept in the shell history and seen "+
+		"in the process list. Omit it to be prompted, or use --from-stdin or --from-file.\n", name)
+}
+
+// warnHiddenField warns, that the hidden field value is passed on the command line.
+func warnHiddenField(name string) {
+	fmt.Fprintf(os.Stderr, "Warning: the value of the hidden field %q is passed on the command line: it is kept in the "+
+		"shell history and seen in the process list. Pass --field %s:hidden to be prompted, or use --from-stdin "+
+		"or --from-file.\n", name, name)
+}
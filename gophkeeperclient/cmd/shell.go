@@ -0,0 +1,339 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"golang.org/x/term"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	shellPrompt       = "gophkeeper> "
+	shellLockedPrompt = "gophkeeper (locked)> "
+)
+
+var errUnterminatedQuote = errors.New("unterminated quote")
+
+// shellCmd represents the shell command
+var shellCmd = &cobra.Command{
+	Use:   "shell",
+	Short: "Run the commands in one interactive session",
+	Long: `
+This command opens the interactive session of the authenticated user: the commands are typed as lines,
+without the program name, like getPair --title=github. The local storage is read once and saved on exit,
+the server connection is shared by the commands. Up and down keys walk the history of the session,
+tab completes the command and flag names. Quote the values with spaces: saveText --title="my note".
+The config and storage flags of the shell apply to all the commands.
+The vault is locked after --idle-timeout without input: the key is removed from memory and from the local
+storage, the master password is asked to unlock it. Type lock to lock it at once, exit or ctrl+d to quit.
+Usage: gophkeeperclient shell [--idle-timeout=5m].`,
+	Run: func(cmd *cobra.Command, args []string) {
+		userName, _, _, ok := userVault()
+		if !ok {
+			return
+		}
+		fd := int(os.Stdin.Fd())
+		if !term.IsTerminal(fd) {
+			fmt.Println("The shell needs a terminal.")
+			return
+		}
+
+		s := &shell{userName: userName, idle: shellIdle, fd: fd}
+		s.run()
+	},
+}
+
+var (
+	shellIdle time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(shellCmd)
+	shellCmd.Flags().DurationVar(&shellIdle, "idle-timeout", 5*time.Minute,
+		"Lock the vault after the time without input. 0 - never.")
+}
+
+// shell reads the command lines from the terminal and runs them with rootCmd. The terminal is in raw mode only,
+// while the line is read: the commands print and prompt the secrets, as they do out of the shell.
+type shell struct {
+	userName string
+	idle     time.Duration
+	fd       int
+	term     *term.Terminal
+
+	// mu is held, while the command runs: the vault is not locked in the middle of it.
+	mu         sync.Mutex
+	lastActive time.Time
+	locked     bool
+	timer      *time.Timer
+}
+
+func (s *shell) run() {
+	s.term = term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, shellPrompt)
+	s.term.AutoCompleteCallback = completeLine
+	if width, height, err := term.GetSize(s.fd); err == nil && width > 0 {
+		s.term.SetSize(width, height)
+	}
+
+	inShell = true
+	defer func() { inShell = false }()
+	if s.idle > 0 {
+		s.lastActive = time.Now()
+		s.timer = time.AfterFunc(s.idle, s.autoLock)
+		defer s.timer.Stop()
+	}
+
+	fmt.Println("Type help to list the commands, exit or ctrl+d to quit.")
+	for {
+		line, err := s.readLine()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				log.Println(`[ERROR]:`, err)
+			}
+			fmt.Println()
+			return
+		}
+		if !s.exec(line) {
+			return
+		}
+	}
+}
+
+// readLine reads the line in raw mode: the line is edited, the history and the completion are handled by the term.
+func (s *shell) readLine() (string, error) {
+	state, err := term.MakeRaw(s.fd)
+	if err != nil {
+		return ``, err
+	}
+	defer term.Restore(s.fd, state)
+	return s.term.ReadLine()
+}
+
+// exec runs the command line. Returns false, if the shell must exit.
+func (s *shell) exec(line string) bool {
+	s.mu.Lock()
+	defer func() {
+		s.lastActive = time.Now()
+		if s.timer != nil {
+			s.timer.Reset(s.idle)
+		}
+		s.mu.Unlock()
+	}()
+
+	args, err := splitArgs(line)
+	if err != nil {
+		fmt.Println(err)
+		return true
+	}
+	if len(args) == 0 {
+		return true
+	}
+	switch args[0] {
+	case "exit", "quit":
+		return false
+	case "lock":
+		s.lock()
+		return true
+	case "shell":
+		fmt.Println("Already in the shell.")
+		return true
+	case "loginUser", "registerUser", "logoutUser", "help":
+		// these don't need the vault key: login unlocks the vault itself.
+	default:
+		if s.locked && !s.unlock() {
+			return true
+		}
+	}
+
+	resetFlags(rootCmd)
+	rootCmd.SetArgs(args)
+	rootCmd.Execute()
+
+	if auth, ok := clstor.Users[s.userName]; ok && len(auth.VaultKey) > 0 && s.locked {
+		s.locked = false
+		s.term.SetPrompt(shellPrompt)
+	}
+	return true
+}
+
+// lock removes the vault key from memory and from the local storage.
+func (s *shell) lock() {
+	auth, ok := clstor.Users[s.userName]
+	if !ok || len(auth.VaultKey) == 0 {
+		return
+	}
+	for i := range auth.VaultKey {
+		auth.VaultKey[i] = 0
+	}
+	auth.VaultKey = nil
+	s.locked = true
+	s.term.SetPrompt(shellLockedPrompt)
+	if err := clstor.UpdateFiles(); err != nil {
+		log.Println(`[ERROR]:`, err)
+	}
+	fmt.Fprintln(s.term, "Vault locked.")
+}
+
+// autoLock locks the vault, if there was no input for the idle timeout.
+func (s *shell) autoLock() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if idle := time.Since(s.lastActive); idle < s.idle {
+		s.timer.Reset(s.idle - idle)
+		return
+	}
+	s.lock()
+}
+
+// unlock derives the vault key from the master password. The sessions, that don't keep the key derivation data,
+// are unlocked with loginUser. Returns false, if the vault is still locked.
+func (s *shell) unlock() bool {
+	auth, ok := clstor.Users[s.userName]
+	if !ok || auth.KDFParams == `` {
+		fmt.Println("Vault is locked. Please login with your master password.")
+		return false
+	}
+
+	master, err := promptSecret("Master password", false)
+	if err != nil {
+		fmt.Println(err)
+		return false
+	}
+	key, err := clserv.DeriveVaultKey(master, &pb.VaultKDF{Params: auth.KDFParams, Check: auth.KDFCheck})
+	if err != nil {
+		log.Println(`[ERROR]:`, err)
+		fmt.Println("vault unlock failed. please check your master password.")
+		return false
+	}
+	auth.VaultKey = key
+	s.locked = false
+	s.term.SetPrompt(shellPrompt)
+	return true
+}
+
+// resetFlags sets the flags of the command and its subcommands to the defaults: the shell runs them many times,
+// the values of the previous line must not be used.
+func resetFlags(c *cobra.Command) {
+	c.Flags().VisitAll(func(f *pflag.Flag) {
+		if v, ok := f.Value.(pflag.SliceValue); ok {
+			v.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+	for _, sub := range c.Commands() {
+		resetFlags(sub)
+	}
+}
+
+// splitArgs splits the line to the arguments by spaces, like the shell does. Single and double quotes keep
+// the spaces, backslash escapes the next character out of single quotes.
+func splitArgs(line string) ([]string, error) {
+	var (
+		args    []string
+		arg     strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range line {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			arg.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, errUnterminatedQuote
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+// completeLine completes the word before the cursor on tab: the command name or the flag of the command.
+// The word is completed up to the common prefix of the candidates.
+func completeLine(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return ``, 0, false
+	}
+
+	head := line[:pos]
+	start := strings.LastIndexAny(head, " \t") + 1
+	word := head[start:]
+	var candidates []string
+	switch words := strings.Fields(head[:start]); {
+	case len(words) == 0:
+		candidates = []string{"exit", "lock"}
+		for _, c := range rootCmd.Commands() {
+			if c.IsAvailableCommand() || c.Name() == "help" {
+				candidates = append(candidates, c.Name())
+			}
+		}
+	case strings.HasPrefix(word, "-"):
+		c, _, err := rootCmd.Find(words[:1])
+		if err != nil || c == rootCmd {
+			return line, pos, true
+		}
+		c.Flags().VisitAll(func(f *pflag.Flag) {
+			if !f.Hidden {
+				candidates = append(candidates, "--"+f.Name)
+			}
+		})
+	}
+
+	var matches []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, word) {
+			matches = append(matches, c)
+		}
+	}
+	if len(matches) == 0 {
+		return line, pos, true
+	}
+	sort.Strings(matches)
+	completed := matches[0]
+	for _, m := range matches[1:] {
+		for !strings.HasPrefix(m, completed) {
+			completed = completed[:len(completed)-1]
+		}
+	}
+	if len(matches) == 1 {
+		completed += " "
+	}
+	return head[:start] + completed + line[pos:], start + len(completed), true
+}
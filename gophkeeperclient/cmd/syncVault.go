@@ -5,16 +5,11 @@ Copyright © 2022 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
 	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
 	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
-	pb "github.com/EestiChameleon/gophkeeper/proto"
-	"google.golang.org/grpc/metadata"
 	"log"
 	"os/user"
-	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -24,64 +19,47 @@ var syncVaultCmd = &cobra.Command{
 	Use:   "syncVault",
 	Short: "Synchronize your local vault with the server database.",
 	Long: `
-This command provides latest data from the server database.
+This command sends the queued local changes (see pending), and provides latest data from the server database.
 Then the database data, with version higher, that the local version, is saved to local storage.
-During the saving of local data to the database, in case of version conflict(database version is higher/newer), you will be alerted by a warning.
+If a local change conflicts with a change made on another device, the changes are merged, when they touch different fields.
+Otherwise the server version is kept, the local one is saved as the conflict copy and you will be alerted by a warning (see conflicts).
 Usage: gophkeeperclient syncVault`,
 	Run: func(cmd *cobra.Command, args []string) {
+		// the agent synchronizes its vault and the local storage.
+		if agentClient != nil {
+			lines, err := agentClient.Sync()
+			for _, line := range lines {
+				fmt.Println(line)
+			}
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			fmt.Println("success")
+			return
+		}
 		// get current user from os/user. Like this we can locally identify if the user changed.
 		u, err := user.Current()
 		if err != nil {
 			log.Fatalln(err)
 			return
 		}
-		jwt, ok := clstor.Users[u.Username]
+		auth, ok := clstor.Users[u.Username]
 		if !ok {
 			fmt.Println("User not authenticated.")
 			return
 		}
-
-		// request with 3s timeout.
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
-		defer cancel()
-
-		// Add token to gRPC Request. ctx WithToKeN
-		ctxWTKN := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+jwt)
-
-		c, err := grpcclient.DialUp()
-		if err != nil {
-			log.Fatalln(err)
+		if len(auth.VaultKey) == 0 {
+			fmt.Println("Vault is locked. Please login with your master password.")
 			return
 		}
-
-		// send data to server and receive all users data.
-		response, err := c.SyncVault(ctxWTKN, &syncData)
-		if err != nil {
-			log.Println(`[ERROR]:`, err)
-			fmt.Println("request failed. please try again.")
-			return
-		}
-
-		// check server response
-		if response.GetStatus() != "success" {
-			log.Println(response.GetStatus())
-			fmt.Println("request failed. please try again.")
-			return
+		// explicit sync replays the queued changes at once, ignoring the backoff.
+		if replayOutbox(u.Username, clserv.VaultKey(auth.VaultKey), true) {
+			fmt.Println("success")
 		}
-
-		//check for latest version data
-		syncVault := clserv.CombineVault(clstor.Local[u.Username], clserv.VaultSyncConvert(response))
-
-		// save actual data
-		clstor.Local[u.Username] = syncVault
-		fmt.Println(response.GetStatus())
 	},
 }
 
-var (
-	syncData pb.SyncVaultRequest
-)
-
 func init() {
 	rootCmd.AddCommand(syncVaultCmd)
 }
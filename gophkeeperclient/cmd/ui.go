@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/grpcclient"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/tui"
+	"log"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+// uiCmd represents the ui command
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "Browse and edit the vault in the full-screen terminal UI",
+	Long: `
+This command opens to the authenticated user the full-screen terminal UI: the type and tag sidebar,
+the item list, filtered as you type, and the item details. Secrets are masked until revealed with r.
+Items are added, edited and deleted in the local vault and synchronized with the server, like the other
+commands do: the vault is synchronized on start and after each change, the status bar shows the sync state.
+Binary data is added with saveBinary, the streamed one is edited with saveBinary too.
+Usage: gophkeeperclient ui.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		userName, key, _, ok := userVault()
+		if !ok {
+			return
+		}
+		session := &tui.Session{
+			UserName: userName,
+			Key:      key,
+			Dial:     grpcclient.DialUp,
+			Persist:  clstor.UpdateFiles,
+		}
+
+		// log messages would break the screen: they are printed, when the UI is closed.
+		var logs bytes.Buffer
+		log.SetOutput(&logs)
+		err := tea.NewProgram(tui.New(session), tea.WithAltScreen()).Start()
+		log.SetOutput(os.Stderr)
+		os.Stderr.Write(logs.Bytes())
+		if err != nil {
+			fmt.Println("UI failed:", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(uiCmd)
+}
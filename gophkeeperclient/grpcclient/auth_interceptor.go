@@ -0,0 +1,115 @@
+package grpcclient
+
+import (
+	"context"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log"
+	"os/user"
+)
+
+var (
+	// authMethods are called without the bearer token.
+	authMethods = map[string]struct{}{
+		"/gophkeeper.proto.Keeper/RegisterUser": {},
+		"/gophkeeper.proto.Keeper/LoginUser":    {},
+		"/gophkeeper.proto.Keeper/RefreshToken": {},
+	}
+)
+
+// authInterceptor adds the bearer token of the current local user to the request.
+// Lapsed access token is refreshed before the request. If the server still rejects the token,
+// it is refreshed once more and the request is repeated.
+func authInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if _, ok := authMethods[method]; ok {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	auth := currentAuth()
+	if auth == nil {
+		// not authenticated - server decides
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	refreshed := false
+	if auth.Expired() && auth.RefreshToken != `` {
+		if err := refreshAuth(ctx, cc, auth); err != nil {
+			return err
+		}
+		refreshed = true
+	}
+
+	err := invoker(withToken(ctx, auth.JWT), method, req, reply, cc, opts...)
+	if status.Code(err) != codes.Unauthenticated || refreshed || auth.RefreshToken == `` {
+		return err
+	}
+
+	// token could expire on the way or be issued by another session - try once with a fresh one.
+	if rErr := refreshAuth(ctx, cc, auth); rErr != nil {
+		log.Println("token refresh failed:", rErr)
+		return err
+	}
+
+	return invoker(withToken(ctx, auth.JWT), method, req, reply, cc, opts...)
+}
+
+// authStreamInterceptor adds the bearer token of the current local user to the streaming call. Lapsed access token
+// is refreshed before the call. Streams can't be repeated, so a rejected call fails.
+func authStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	auth := currentAuth()
+	if auth == nil {
+		// not authenticated - server decides
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+
+	if auth.Expired() && auth.RefreshToken != `` {
+		if err := refreshAuth(ctx, cc, auth); err != nil {
+			return nil, err
+		}
+	}
+
+	return streamer(withToken(ctx, auth.JWT), desc, cc, method, opts...)
+}
+
+// refreshAuth exchanges the refresh token for a new token pair and saves it to the passed auth data
+// and to the users file.
+func refreshAuth(ctx context.Context, cc *grpc.ClientConn, auth *clstor.UserAuth) error {
+	resp, err := pb.NewKeeperClient(cc).RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: auth.RefreshToken})
+	if err != nil {
+		return err
+	}
+
+	auth.JWT = resp.GetJwt()
+	auth.RefreshToken = resp.GetRefreshToken()
+	auth.ExpiresAt = resp.GetExpiresAt()
+
+	u, err := user.Current()
+	if err != nil {
+		return err
+	}
+	if err = clstor.SaveAuth(u.Username, auth); err != nil {
+		// the request is made with the new token anyway, the file is rewritten after the command.
+		log.Println("token save failed:", err)
+	}
+	return nil
+}
+
+// currentAuth returns the session tokens of the current OS user. Returns nil, if user is not authenticated.
+func currentAuth() *clstor.UserAuth {
+	u, err := user.Current()
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+
+	return clstor.Users[u.Username]
+}
+
+// withToken adds the bearer token to the outgoing request metadata.
+func withToken(ctx context.Context, jwt string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+jwt)
+}
@@ -4,15 +4,30 @@ import (
 	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/cfg"
 	pb "github.com/EestiChameleon/gophkeeper/proto"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
 )
 
 var clientConn *grpc.ClientConn
 
-// DialUp initiates a connection between the client and the server. Address taken from cfg.GrpcServerPath.
+// DialUp initiates a connection between the client and the server. Address taken from cfg.Current.ServerAddress.
+// Connection is secured with TLS, see tlsConfig. Requests are authenticated with the current local user tokens,
+// see authInterceptor and authStreamInterceptor. The active connection is reused: the commands, run in one
+// process, share it.
 func DialUp() (pb.KeeperClient, error) {
+	if clientConn != nil {
+		return pb.NewKeeperClient(clientConn), nil
+	}
+
+	tlsConf, err := tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	// устанавливаем соединение с сервером
-	conn, err := grpc.Dial(cfg.GrpcServerPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial(cfg.Current.ServerAddress,
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConf)),
+		grpc.WithUnaryInterceptor(authInterceptor),
+		grpc.WithStreamInterceptor(authStreamInterceptor))
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +41,9 @@ func DialUp() (pb.KeeperClient, error) {
 
 // ConnDown closes the server connection.
 func ConnDown() error {
-	return clientConn.Close()
+	err := clientConn.Close()
+	clientConn = nil
+	return err
 }
 
 // ActiveConnection verifies if there is an active connection. Returns true in case of any active connection found.
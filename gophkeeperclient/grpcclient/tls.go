@@ -0,0 +1,73 @@
+package grpcclient
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/cfg"
+	"os"
+	"strings"
+)
+
+var (
+	ErrFingerprintMismatch = errors.New("server certificate fingerprint mismatch")
+)
+
+// tlsConfig builds the client TLS configuration from cfg:
+// - server certificate is verified against cfg.Current.TLSCAFile (system roots, if empty);
+// - if cfg.Current.TLSServerFingerprint is set, the server certificate must match it. Without the CA file pinning
+// replaces the chain verification, so a self-signed server certificate could be used;
+// - cfg.Current.TLSClientCertFile and cfg.Current.TLSClientKeyFile are presented to servers, that require mutual TLS.
+func tlsConfig() (*tls.Config, error) {
+	return newTLSConfig(cfg.Current.TLSCAFile, cfg.Current.TLSServerFingerprint, cfg.Current.TLSClientCertFile, cfg.Current.TLSClientKeyFile)
+}
+
+// newTLSConfig builds the client TLS configuration. See tlsConfig.
+func newTLSConfig(caFile, fingerprint, certFile, keyFile string) (*tls.Config, error) {
+	conf := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != `` {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		conf.RootCAs = pool
+	}
+
+	if fingerprint != `` {
+		pin, err := hex.DecodeString(strings.ReplaceAll(strings.ToLower(fingerprint), ":", ``))
+		if err != nil || len(pin) != sha256.Size {
+			return nil, fmt.Errorf("invalid server fingerprint %q", fingerprint)
+		}
+		// chain is verified by the VerifyConnection, only if the CA is set.
+		conf.InsecureSkipVerify = caFile == ``
+		conf.VerifyConnection = func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return ErrFingerprintMismatch
+			}
+			sum := sha256.Sum256(cs.PeerCertificates[0].Raw)
+			if subtle.ConstantTimeCompare(sum[:], pin) != 1 {
+				return ErrFingerprintMismatch
+			}
+			return nil
+		}
+	}
+
+	if certFile != `` || keyFile != `` {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+
+	return conf, nil
+}
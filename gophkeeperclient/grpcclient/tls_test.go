@@ -0,0 +1,103 @@
+package grpcclient
+
+import (
+	"github.com/EestiChameleon/gophkeeper/server/tlsconf"
+	"github.com/EestiChameleon/gophkeeper/server/tlsconf/tlstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestNewTLSConfig verifies, that:
+// 1) server is verified by the CA
+// 2) pinned fingerprint is accepted with and without the CA, in any case and with colons
+// 3) wrong fingerprint is rejected
+// 4) client certificate is presented to mutual TLS server
+func TestNewTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, tlsconf.GenerateDevCerts(dir, []string{"localhost"}))
+	otherDir := t.TempDir()
+	require.NoError(t, tlsconf.GenerateDevCerts(otherDir, []string{"localhost"}))
+
+	ca := filepath.Join(dir, tlsconf.CAFile)
+	fp, err := tlsconf.Fingerprint(filepath.Join(dir, tlsconf.ServerCertFile))
+	require.NoError(t, err)
+	otherFP, err := tlsconf.Fingerprint(filepath.Join(otherDir, tlsconf.ServerCertFile))
+	require.NoError(t, err)
+
+	var colonFP []string
+	for i := 0; i < len(fp); i += 2 {
+		colonFP = append(colonFP, strings.ToUpper(fp[i:i+2]))
+	}
+
+	tests := []struct {
+		name        string
+		caFile      string
+		fingerprint string
+		certFile    string
+		keyFile     string
+		mutual      bool
+		wantErr     bool
+	}{
+		{
+			name:   "Test #1: server verified by the CA",
+			caFile: ca,
+		},
+		{
+			name:    "Test #2: server signed by an unknown CA",
+			caFile:  filepath.Join(otherDir, tlsconf.CAFile),
+			wantErr: true,
+		},
+		{
+			name:        "Test #3: pinned fingerprint without the CA",
+			fingerprint: fp,
+		},
+		{
+			name:        "Test #4: pinned fingerprint with colons and the CA",
+			caFile:      ca,
+			fingerprint: strings.Join(colonFP, ":"),
+		},
+		{
+			name:        "Test #5: wrong fingerprint",
+			fingerprint: otherFP,
+			wantErr:     true,
+		},
+		{
+			name:     "Test #6: mutual TLS with client certificate",
+			caFile:   ca,
+			certFile: filepath.Join(dir, tlsconf.ClientCertFile),
+			keyFile:  filepath.Join(dir, tlsconf.ClientKeyFile),
+			mutual:   true,
+		},
+		{
+			name:    "Test #7: mutual TLS without client certificate",
+			caFile:  ca,
+			mutual:  true,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		clientCA := ``
+		if tt.mutual {
+			clientCA = ca
+		}
+		srvConf, err := tlsconf.ServerConfig(filepath.Join(dir, tlsconf.ServerCertFile), filepath.Join(dir, tlsconf.ServerKeyFile), clientCA)
+		require.NoError(t, err, tt.name)
+
+		clConf, err := newTLSConfig(tt.caFile, tt.fingerprint, tt.certFile, tt.keyFile)
+		require.NoError(t, err, tt.name)
+		clConf.ServerName = "localhost"
+
+		err = tlstest.Handshake(srvConf, clConf)
+		if tt.wantErr {
+			assert.Error(t, err, tt.name)
+		} else {
+			assert.NoError(t, err, tt.name)
+		}
+	}
+
+	_, err = newTLSConfig(``, "abc", ``, ``)
+	assert.Error(t, err)
+}
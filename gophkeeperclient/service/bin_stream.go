@@ -0,0 +1,190 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"hash"
+	"io"
+)
+
+// uploadChunkSize is the body chunk size of UploadBin.
+const uploadChunkSize = 256 * 1024
+
+var (
+	ErrChecksumMismatch = errors.New("downloaded body checksum mismatch")
+	ErrUnexpectedPart   = errors.New("unexpected download stream part")
+)
+
+// UploadBin saves the binary data on the server as the streamed item: the body is read from src, sealed by chunks
+// and streamed. bin keeps the item id, title, version and comment, its Body is ignored. The body is bound
+// to the item id. Returns the sealed body size.
+// The file is never loaded to memory as a whole. The body is sealed with random nonces, so the server keeps every
+// upload as a new blob, even if the file is the same. Renaming the streamed item (see RenameItem) keeps the body of
+// the latest version and doesn't upload it again.
+func UploadBin(ctx context.Context, c pb.KeeperClient, bin *models.Bin, src io.Reader, key models.Sealer) (int64, error) {
+	meta := *bin
+	meta.Body, meta.Streamed, meta.Size, meta.StreamTitle = nil, true, 0, ``
+	header, err := models.ModelsToProtoBin(&meta, key)
+	if err != nil {
+		return 0, err
+	}
+
+	// failed upload is cancelled: the server saves nothing without the checksum.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.UploadBin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err = stream.Send(&pb.UploadBinRequest{Part: &pb.UploadBinRequest_Header{Header: header}}); err != nil {
+		return 0, err
+	}
+
+	w := &uploadWriter{stream: stream, hash: sha256.New()}
+	if _, err = models.SealStream(key, "bin", bin.ItemID, w, src); err != nil {
+		return 0, err
+	}
+	if err = w.flush(); err != nil {
+		return 0, err
+	}
+	if err = stream.Send(&pb.UploadBinRequest{Part: &pb.UploadBinRequest_Sha256{Sha256: w.hash.Sum(nil)}}); err != nil {
+		return 0, err
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, err
+	}
+	return int64(resp.GetSize()), nil
+}
+
+// DownloadBin requests the binary data by id or title from the server and writes the opened body to dst.
+// The streamed body is opened by chunks, as received. Returns the item without the body.
+func DownloadBin(ctx context.Context, c pb.KeeperClient, id, title string, dst io.Writer, key models.Sealer) (*models.Bin, error) {
+	stream, err := c.DownloadBin(ctx, &pb.DownloadBinRequest{Id: id, Title: title})
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if first.GetHeader() == nil {
+		return nil, ErrUnexpectedPart
+	}
+	bin, err := models.ProtoToModelsBin(first.GetHeader(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	// item, saved as one message, has the body in the payload.
+	if !bin.Streamed {
+		_, err = dst.Write(bin.Body)
+		bin.Body = nil
+		return bin, err
+	}
+
+	r := &downloadReader{stream: stream, hash: sha256.New()}
+	if _, err = models.OpenStream(key, "bin", bin.ItemID, bin.StreamTitle, dst, r); err != nil {
+		return nil, err
+	}
+	// the checksum is read after the last chunk of the sealed stream.
+	if r.sum == nil {
+		if _, err = r.Read(make([]byte, 1)); err != io.EOF {
+			return nil, ErrUnexpectedPart
+		}
+	}
+	if !bytes.Equal(r.sum, r.hash.Sum(nil)) {
+		return nil, ErrChecksumMismatch
+	}
+
+	return bin, nil
+}
+
+// uploadWriter sends the written data to the UploadBin stream by chunks of uploadChunkSize.
+type uploadWriter struct {
+	stream pb.Keeper_UploadBinClient
+	hash   hash.Hash
+	buf    []byte
+}
+
+// Write implements io.Writer.
+func (w *uploadWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		if w.buf == nil {
+			w.buf = make([]byte, 0, uploadChunkSize)
+		}
+		free := cap(w.buf) - len(w.buf)
+		if free > len(p) {
+			free = len(p)
+		}
+		w.buf = append(w.buf, p[:free]...)
+		p = p[free:]
+
+		if len(w.buf) == cap(w.buf) {
+			if err := w.flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// flush sends the buffered data. Sent buffer is not reused.
+func (w *uploadWriter) flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	w.hash.Write(w.buf)
+	err := w.stream.Send(&pb.UploadBinRequest{Part: &pb.UploadBinRequest_Chunk{Chunk: w.buf}})
+	w.buf = nil
+	return err
+}
+
+// downloadReader reads the body chunks of the DownloadBin stream. The checksum, that ends the stream, is kept in sum.
+type downloadReader struct {
+	stream pb.Keeper_DownloadBinClient
+	hash   hash.Hash
+	chunk  []byte
+	sum    []byte
+}
+
+// Read implements io.Reader.
+func (r *downloadReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if r.sum != nil {
+			return 0, io.EOF
+		}
+
+		part, err := r.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			// stream ended without the checksum
+			return 0, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		switch part.GetPart().(type) {
+		case *pb.DownloadBinResponse_Chunk:
+			r.chunk = part.GetChunk()
+			r.hash.Write(r.chunk)
+		case *pb.DownloadBinResponse_Sha256:
+			r.sum = part.GetSha256()
+		default:
+			return 0, ErrUnexpectedPart
+		}
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
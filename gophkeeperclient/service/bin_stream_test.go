@@ -0,0 +1,155 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"testing"
+)
+
+// binServer keeps one uploaded binary data item in memory. Other methods are not implemented.
+type binServer struct {
+	pb.UnimplementedKeeperServer
+	header *pb.Bin
+	body   []byte
+}
+
+func (s *binServer) UploadBin(stream pb.Keeper_UploadBinServer) error {
+	h := sha256.New()
+	for {
+		part, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		switch part.GetPart().(type) {
+		case *pb.UploadBinRequest_Header:
+			s.header, s.body = part.GetHeader(), nil
+		case *pb.UploadBinRequest_Chunk:
+			s.body = append(s.body, part.GetChunk()...)
+			h.Write(part.GetChunk())
+		case *pb.UploadBinRequest_Sha256:
+			if !bytes.Equal(h.Sum(nil), part.GetSha256()) {
+				return io.ErrUnexpectedEOF
+			}
+			s.header.Streamed, s.header.Size = true, uint64(len(s.body))
+			return stream.SendAndClose(&pb.UploadBinResponse{Status: "success", Size: s.header.Size})
+		}
+	}
+}
+
+func (s *binServer) DownloadBin(in *pb.DownloadBinRequest, stream pb.Keeper_DownloadBinServer) error {
+	if err := stream.Send(&pb.DownloadBinResponse{Part: &pb.DownloadBinResponse_Header{Header: s.header}}); err != nil {
+		return err
+	}
+	for rest := s.body; len(rest) > 0; {
+		n := 1000
+		if n > len(rest) {
+			n = len(rest)
+		}
+		if err := stream.Send(&pb.DownloadBinResponse{Part: &pb.DownloadBinResponse_Chunk{Chunk: rest[:n]}}); err != nil {
+			return err
+		}
+		rest = rest[n:]
+	}
+	sum := sha256.Sum256(s.body)
+	return stream.Send(&pb.DownloadBinResponse{Part: &pb.DownloadBinResponse_Sha256{Sha256: sum[:]}})
+}
+
+// TestUploadDownloadBin verifies, that:
+// 1) body is streamed to the server sealed and is downloaded and opened as it was
+// 2) downloaded item keeps the metadata and is marked streamed
+// 3) tampered body is rejected
+func TestUploadDownloadBin(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	srv := new(binServer)
+	pb.RegisterKeeperServer(s, srv)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	c := pb.NewKeeperClient(conn)
+
+	body := bytes.Repeat([]byte("file content "), 100000)
+	size, err := UploadBin(context.Background(), c, &models.Bin{ItemID: testID1, Title: "b1", Comment: "c", Version: 2}, bytes.NewReader(body), testKey)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(srv.body)), size)
+	// server receives the sealed body only
+	assert.False(t, bytes.Contains(srv.body, []byte("file content")))
+
+	out := new(bytes.Buffer)
+	bin, err := DownloadBin(context.Background(), c, testID1, "", out, testKey)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(body, out.Bytes()))
+	assert.Equal(t, "c", bin.Comment)
+	assert.Equal(t, uint32(2), bin.Version)
+	assert.True(t, bin.Streamed)
+	assert.Equal(t, testID1, bin.ItemID)
+
+	// tampered body is not accepted
+	srv.body[len(srv.body)/2] ^= 1
+	_, err = DownloadBin(context.Background(), c, testID1, "", new(bytes.Buffer), testKey)
+	assert.Error(t, err)
+}
+
+// TestSealStream verifies, that:
+// 1) body of any size, including empty and of whole chunks, is sealed and opened back
+// 2) truncated stream and the stream of another item are rejected
+func TestSealStream(t *testing.T) {
+	tests := []struct {
+		name   string
+		number uint8
+		size   int
+	}{
+		{name: "Test #1: empty body", number: 1, size: 0},
+		{name: "Test #2: body shorter than a chunk", number: 2, size: 100},
+		{name: "Test #3: body of exactly one chunk", number: 3, size: models.StreamChunkSize},
+		{name: "Test #4: body of several chunks", number: 4, size: 2*models.StreamChunkSize + 7},
+		{name: "Test #5: truncated stream", number: 5, size: 2*models.StreamChunkSize + 7},
+		{name: "Test #6: stream of another item", number: 6, size: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain := bytes.Repeat([]byte{7}, tt.size)
+			sealed := new(bytes.Buffer)
+			n, err := models.SealStream(testKey, "bin", testID1, sealed, bytes.NewReader(plain))
+			require.NoError(t, err)
+			assert.Equal(t, int64(tt.size), n)
+			if tt.size > 0 {
+				assert.NotContains(t, sealed.String(), string(plain))
+			}
+
+			opened := new(bytes.Buffer)
+			switch tt.number {
+			case 1, 2, 3, 4:
+				n, err = models.OpenStream(testKey, "bin", testID1, "", opened, sealed)
+				require.NoError(t, err)
+				assert.Equal(t, int64(tt.size), n)
+				assert.True(t, bytes.Equal(plain, opened.Bytes()))
+			case 5:
+				// the last chunk is cut off
+				first := binary.BigEndian.Uint32(sealed.Bytes())
+				cut := sealed.Bytes()[:4+int(first)]
+				_, err = models.OpenStream(testKey, "bin", testID1, "", opened, bytes.NewReader(cut))
+				assert.ErrorIs(t, err, models.ErrStreamTruncated)
+			case 6:
+				_, err = models.OpenStream(testKey, "bin", testID2, "", opened, sealed)
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"database/sql"
 	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
 	"github.com/EestiChameleon/gophkeeper/models"
 	"github.com/stretchr/testify/assert"
@@ -11,6 +12,7 @@ var (
 	halfEmptyDataOne = models.Vault{
 		Pair: map[string]*models.Pair{
 			"ptitle1": {
+				ItemID:  "ptitle1",
 				Title:   "ptitle1",
 				Login:   "log1",
 				Pass:    "pass1",
@@ -20,6 +22,7 @@ var (
 		},
 		Text: map[string]*models.Text{
 			"ttitle1": {
+				ItemID:  "ttitle1",
 				Title:   "ttitle1",
 				Body:    "text1",
 				Comment: "comm1",
@@ -33,6 +36,7 @@ var (
 	halfEmptyDataTwo = models.Vault{
 		Pair: map[string]*models.Pair{
 			"ptitle1": {
+				ItemID:  "ptitle1",
 				Title:   "ptitle1",
 				Login:   "log2",
 				Pass:    "pass2",
@@ -42,6 +46,7 @@ var (
 		},
 		Text: map[string]*models.Text{
 			"ttitle1": {
+				ItemID:  "ttitle1",
 				Title:   "ttitle1",
 				Body:    "text2",
 				Comment: "comm2",
@@ -55,6 +60,7 @@ var (
 	fullDataOne = models.Vault{
 		Pair: map[string]*models.Pair{
 			"ptitle1": {
+				ItemID:  "ptitle1",
 				Title:   "ptitle1",
 				Login:   "log1",
 				Pass:    "pass1",
@@ -64,6 +70,7 @@ var (
 		},
 		Text: map[string]*models.Text{
 			"ttitle1": {
+				ItemID:  "ttitle1",
 				Title:   "ttitle1",
 				Body:    "text1",
 				Comment: "comm1",
@@ -72,6 +79,7 @@ var (
 		},
 		Bin: map[string]*models.Bin{
 			"btitle1": {
+				ItemID:  "btitle1",
 				Title:   "btitle1",
 				Body:    []byte("binary data 1"),
 				Comment: "comm1",
@@ -80,6 +88,7 @@ var (
 		},
 		Card: map[string]*models.Card{
 			"ctitle1": {
+				ItemID:         "ctitle1",
 				Title:          "ctitle1",
 				Number:         "1111 1111 1111 1111",
 				ExpirationDate: "1111/11",
@@ -92,6 +101,7 @@ var (
 	fullDataTwo = models.Vault{
 		Pair: map[string]*models.Pair{
 			"ptitle1": {
+				ItemID:  "ptitle1",
 				Title:   "ptitle1",
 				Login:   "log2",
 				Pass:    "pass2",
@@ -101,6 +111,7 @@ var (
 		},
 		Text: map[string]*models.Text{
 			"ttitle1": {
+				ItemID:  "ttitle1",
 				Title:   "ttitle1",
 				Body:    "text2",
 				Comment: "comm2",
@@ -109,6 +120,7 @@ var (
 		},
 		Bin: map[string]*models.Bin{
 			"btitle2": {
+				ItemID:  "btitle2",
 				Title:   "btitle2",
 				Body:    []byte("binary data 2"),
 				Comment: "comm2",
@@ -117,6 +129,7 @@ var (
 		},
 		Card: map[string]*models.Card{
 			"ctitle2": {
+				ItemID:         "ctitle2",
 				Title:          "ctitle2",
 				Number:         "2222 2222 2222 2222",
 				ExpirationDate: "2222/22",
@@ -125,6 +138,28 @@ var (
 			},
 		},
 	}
+
+	// tombstoneData is the server data after "ptitle1" deleted at version 2 and "ttitle1" deleted at version 2.
+	tombstoneData = models.Vault{
+		Pair: map[string]*models.Pair{
+			"ptitle1": {
+				ItemID:    "ptitle1",
+				Title:     "ptitle1",
+				Version:   2,
+				DeletedAt: sql.NullTime{Valid: true},
+			},
+		},
+		Text: map[string]*models.Text{
+			"ttitle1": {
+				ItemID:    "ttitle1",
+				Title:     "ttitle1",
+				Version:   2,
+				DeletedAt: sql.NullTime{Valid: true},
+			},
+		},
+		Bin:  nil,
+		Card: nil,
+	}
 )
 
 func TestCombineVault(t *testing.T) {
@@ -170,6 +205,7 @@ func TestCombineVault(t *testing.T) {
 				dataFinal: &models.Vault{
 					Pair: map[string]*models.Pair{
 						"ptitle1": {
+							ItemID:  "ptitle1",
 							Title:   "ptitle1",
 							Login:   "log2",
 							Pass:    "pass2",
@@ -179,6 +215,7 @@ func TestCombineVault(t *testing.T) {
 					},
 					Text: map[string]*models.Text{
 						"ttitle1": {
+							ItemID:  "ttitle1",
 							Title:   "ttitle1",
 							Body:    "text1",
 							Comment: "comm1",
@@ -198,6 +235,7 @@ func TestCombineVault(t *testing.T) {
 				dataFinal: &models.Vault{
 					Pair: map[string]*models.Pair{
 						"ptitle1": {
+							ItemID:  "ptitle1",
 							Title:   "ptitle1",
 							Login:   "log2",
 							Pass:    "pass2",
@@ -207,6 +245,7 @@ func TestCombineVault(t *testing.T) {
 					},
 					Text: map[string]*models.Text{
 						"ttitle1": {
+							ItemID:  "ttitle1",
 							Title:   "ttitle1",
 							Body:    "text1",
 							Comment: "comm1",
@@ -215,6 +254,7 @@ func TestCombineVault(t *testing.T) {
 					},
 					Bin: map[string]*models.Bin{
 						"btitle2": {
+							ItemID:  "btitle2",
 							Title:   "btitle2",
 							Body:    []byte("binary data 2"),
 							Comment: "comm2",
@@ -223,6 +263,7 @@ func TestCombineVault(t *testing.T) {
 					},
 					Card: map[string]*models.Card{
 						"ctitle2": {
+							ItemID:         "ctitle2",
 							Title:          "ctitle2",
 							Number:         "2222 2222 2222 2222",
 							ExpirationDate: "2222/22",
@@ -241,6 +282,7 @@ func TestCombineVault(t *testing.T) {
 				dataFinal: &models.Vault{
 					Pair: map[string]*models.Pair{
 						"ptitle1": {
+							ItemID:  "ptitle1",
 							Title:   "ptitle1",
 							Login:   "log2",
 							Pass:    "pass2",
@@ -250,6 +292,7 @@ func TestCombineVault(t *testing.T) {
 					},
 					Text: map[string]*models.Text{
 						"ttitle1": {
+							ItemID:  "ttitle1",
 							Title:   "ttitle1",
 							Body:    "text1",
 							Comment: "comm1",
@@ -258,12 +301,14 @@ func TestCombineVault(t *testing.T) {
 					},
 					Bin: map[string]*models.Bin{
 						"btitle1": {
+							ItemID:  "btitle1",
 							Title:   "btitle1",
 							Body:    []byte("binary data 1"),
 							Comment: "comm1",
 							Version: 4,
 						},
 						"btitle2": {
+							ItemID:  "btitle2",
 							Title:   "btitle2",
 							Body:    []byte("binary data 2"),
 							Comment: "comm2",
@@ -272,6 +317,7 @@ func TestCombineVault(t *testing.T) {
 					},
 					Card: map[string]*models.Card{
 						"ctitle1": {
+							ItemID:         "ctitle1",
 							Title:          "ctitle1",
 							Number:         "1111 1111 1111 1111",
 							ExpirationDate: "1111/11",
@@ -279,6 +325,7 @@ func TestCombineVault(t *testing.T) {
 							Version:        2,
 						},
 						"ctitle2": {
+							ItemID:         "ctitle2",
 							Title:          "ctitle2",
 							Number:         "2222 2222 2222 2222",
 							ExpirationDate: "2222/22",
@@ -289,6 +336,62 @@ func TestCombineVault(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "Test #7: newer tombstone removes the item, older tombstone is ignored",
+			dataOne: &halfEmptyDataOne,
+			dataTwo: &tombstoneData,
+			want: want{
+				dataFinal: &models.Vault{
+					Pair: map[string]*models.Pair{
+						"ptitle1": {
+							ItemID:    "ptitle1",
+							Title:     "ptitle1",
+							Version:   2,
+							DeletedAt: sql.NullTime{Valid: true},
+						},
+					},
+					Text: map[string]*models.Text{
+						"ttitle1": {
+							ItemID:  "ttitle1",
+							Title:   "ttitle1",
+							Body:    "text1",
+							Comment: "comm1",
+							Version: 3,
+						},
+					},
+					Bin:  nil,
+					Card: nil,
+				},
+			},
+		},
+		{
+			name:    "Test #8: local tombstone is not resurrected by the older server version",
+			dataOne: &tombstoneData,
+			dataTwo: &halfEmptyDataOne,
+			want: want{
+				dataFinal: &models.Vault{
+					Pair: map[string]*models.Pair{
+						"ptitle1": {
+							ItemID:    "ptitle1",
+							Title:     "ptitle1",
+							Version:   2,
+							DeletedAt: sql.NullTime{Valid: true},
+						},
+					},
+					Text: map[string]*models.Text{
+						"ttitle1": {
+							ItemID:  "ttitle1",
+							Title:   "ttitle1",
+							Body:    "text1",
+							Comment: "comm1",
+							Version: 3,
+						},
+					},
+					Bin:  nil,
+					Card: nil,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
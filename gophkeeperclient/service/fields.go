@@ -0,0 +1,147 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// custom field types.
+const (
+	FieldText   = "text"
+	FieldHidden = "hidden" // secret value: PIN, security question answer.
+	FieldURL    = "url"
+	FieldDate   = "date"
+
+	// FieldDateLayout is the date field value format.
+	FieldDateLayout = "2006-01-02"
+)
+
+var (
+	ErrInvalidField     = errors.New("custom field must be passed as name=value or name:type=value")
+	ErrUnknownFieldType = errors.New("custom field type must be text, hidden, url or date")
+	ErrInvalidURL       = errors.New("invalid URL")
+	ErrInvalidDate      = errors.New("invalid date, YYYY-MM-DD expected")
+)
+
+// ParseField parses the custom field, passed as name=value or name:type=value. The type is text by default.
+// URL and date values are checked, URL without the scheme gets https.
+func ParseField(s string) (models.Field, error) {
+	name, value, ok := strings.Cut(s, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == `` {
+		return models.Field{}, ErrInvalidField
+	}
+	f := models.Field{Name: name, Type: FieldText, Value: value}
+	if n, t, typed := strings.Cut(name, ":"); typed {
+		f.Name, f.Type = strings.TrimSpace(n), strings.ToLower(strings.TrimSpace(t))
+		if f.Name == `` {
+			return models.Field{}, ErrInvalidField
+		}
+	}
+
+	var err error
+	switch f.Type {
+	case FieldText, FieldHidden:
+	case FieldURL:
+		f.Value, err = NormalizeURL(f.Value)
+	case FieldDate:
+		if _, err = time.Parse(FieldDateLayout, f.Value); err != nil {
+			err = ErrInvalidDate
+		}
+	default:
+		err = ErrUnknownFieldType
+	}
+	if err != nil {
+		return models.Field{}, fmt.Errorf("field %q: %w", f.Name, err)
+	}
+	return f, nil
+}
+
+// OmittedHidden returns the name of the hidden field, passed as name:hidden without the value: the value is read
+// apart, like the other secrets, not to be kept in the shell history. Ok is false for the other fields.
+func OmittedHidden(s string) (name string, ok bool) {
+	if strings.Contains(s, "=") {
+		return ``, false
+	}
+	name, t, typed := strings.Cut(s, ":")
+	name = strings.TrimSpace(name)
+	if !typed || name == `` || !strings.EqualFold(strings.TrimSpace(t), FieldHidden) {
+		return ``, false
+	}
+	return name, true
+}
+
+// ParseFields parses the custom fields, see ParseField. Nil, if there are none.
+func ParseFields(in []string) ([]models.Field, error) {
+	var fields []models.Field
+	for _, s := range in {
+		f, err := ParseField(s)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// NormalizeURL checks the URL. URL without the scheme, like example.com/login, gets https.
+func NormalizeURL(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host == `` {
+		return ``, fmt.Errorf("%w: %s", ErrInvalidURL, s)
+	}
+	return u.String(), nil
+}
+
+// NormalizeURLs checks the URLs, see NormalizeURL. Nil, if there are none.
+func NormalizeURLs(in []string) ([]string, error) {
+	var urls []string
+	for _, s := range in {
+		u, err := NormalizeURL(s)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, u)
+	}
+	return urls, nil
+}
+
+// NormalizeTags returns the tags in lower case, sorted and without the duplicates and empty ones.
+// Nil, if there are none.
+func NormalizeTags(in []string) []string {
+	seen := make(map[string]struct{})
+	var tags []string
+	for _, t := range in {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if _, ok := seen[t]; ok || t == `` {
+			continue
+		}
+		seen[t] = struct{}{}
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// HasTag reports, if the item has the tag. Tags are compared case-insensitively, empty tag matches any item.
+func HasTag(h models.ItemHead, tag string) bool {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == `` {
+		return true
+	}
+	for _, t := range h.Tags {
+		if strings.ToLower(t) == tag {
+			return true
+		}
+	}
+	return false
+}
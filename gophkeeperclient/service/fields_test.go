@@ -0,0 +1,64 @@
+package service
+
+import (
+	"github.com/EestiChameleon/gophkeeper/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestParseField verifies, that:
+// 1) field of every type is parsed, the type is text by default and the value keeps =
+// 2) url gets the https scheme, if it has none
+// 3) field without the value or the name, of unknown type and with invalid date or url is rejected
+func TestParseField(t *testing.T) {
+	tests := []struct {
+		name   string
+		number uint8
+		in     string
+		want   models.Field
+		err    error
+	}{
+		{name: "Test #1: text by default, value keeps =", number: 1, in: "question=a=b",
+			want: models.Field{Name: "question", Type: "text", Value: "a=b"}},
+		{name: "Test #2: hidden", number: 1, in: "pin:Hidden=1234", want: models.Field{Name: "pin", Type: "hidden", Value: "1234"}},
+		{name: "Test #3: url without the scheme", number: 1, in: "site:url=example.com/login",
+			want: models.Field{Name: "site", Type: "url", Value: "https://example.com/login"}},
+		{name: "Test #4: date", number: 1, in: "issued:date=2022-02-28",
+			want: models.Field{Name: "issued", Type: "date", Value: "2022-02-28"}},
+		{name: "Test #5: no value", number: 2, in: "pin", err: ErrInvalidField},
+		{name: "Test #6: no name", number: 2, in: ":hidden=1234", err: ErrInvalidField},
+		{name: "Test #7: unknown type", number: 2, in: "pin:secret=1234", err: ErrUnknownFieldType},
+		{name: "Test #8: invalid date", number: 2, in: "issued:date=28.02.2022", err: ErrInvalidDate},
+		{name: "Test #9: invalid url", number: 2, in: "site:url=https://", err: ErrInvalidURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseField(tt.in)
+			switch tt.number {
+			case 1:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			case 2:
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+// TestOmittedHidden verifies, that only the hidden field without the value is reported, the name is trimmed.
+func TestOmittedHidden(t *testing.T) {
+	name, ok := OmittedHidden(" pin : Hidden")
+	assert.True(t, ok)
+	assert.Equal(t, "pin", name)
+	for _, in := range []string{"pin:hidden=1234", "pin:hidden=", "pin", "pin:text", ":hidden"} {
+		_, ok = OmittedHidden(in)
+		assert.False(t, ok, in)
+	}
+}
+
+// TestNormalizeTags verifies, that tags are trimmed, lower cased, sorted and deduplicated, empty ones are dropped.
+func TestNormalizeTags(t *testing.T) {
+	assert.Equal(t, []string{"bank", "work"}, NormalizeTags([]string{" Work", "bank", "", "work"}))
+	assert.Nil(t, NormalizeTags(nil))
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+)
+
+const (
+	charsLower   = "abcdefghijklmnopqrstuvwxyz"
+	charsUpper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	charsDigits  = "0123456789"
+	charsSymbols = "!#$%&()*+,-./:;<=>?@[]^_{|}~"
+	// characters, that are easily confused with each other in some fonts.
+	charsAmbiguous = "Il1|O0o()[]{}.,;:"
+
+	// pronounceable passwords alternate the consonants and the vowels.
+	charsConsonants = "bcdfghjkmnprstvwxz"
+	charsVowels     = "aeiuy"
+)
+
+var (
+	ErrInvalidLength = errors.New("password length must be from 4 to 1024")
+	ErrNoCharClasses = errors.New("at least one character class must be used")
+	ErrInvalidWords  = errors.New("passphrase must have from 3 to 64 words")
+
+	// wordlist is the BIP-0039 English wordlist: 2048 common words, recognized by the first four letters.
+	//go:embed wordlist.txt
+	wordlistData string
+	wordlist     = strings.Fields(wordlistData)
+)
+
+// PasswordOptions are the generated password parameters.
+type PasswordOptions struct {
+	Length      int
+	Lower       bool
+	Upper       bool
+	Digits      bool
+	Symbols     bool
+	NoAmbiguous bool // the characters, confused in some fonts, like l, 1 and I, are not used.
+	// Pronounceable password alternates the lower case consonants and vowels. Character classes are not used.
+	Pronounceable bool
+}
+
+// PassphraseOptions are the generated passphrase parameters.
+type PassphraseOptions struct {
+	Words      int
+	Separator  string
+	Capitalize bool // the first letter of each word is in upper case.
+}
+
+// Generated is the generated password or passphrase.
+type Generated struct {
+	Secret  string
+	Entropy float64 // estimated entropy, bits: the secret is one of 2^Entropy equally likely ones.
+}
+
+// Strength describes the entropy of the generated secret in words.
+func (g Generated) Strength() string {
+	switch {
+	case g.Entropy < 40:
+		return "weak"
+	case g.Entropy < 60:
+		return "fair"
+	case g.Entropy < 80:
+		return "strong"
+	}
+	return "very strong"
+}
+
+// GeneratePassword generates the random password. Each of the used character classes is in the password.
+func GeneratePassword(o PasswordOptions) (Generated, error) {
+	if o.Length < 4 || o.Length > 1024 {
+		return Generated{}, ErrInvalidLength
+	}
+	if o.Pronounceable {
+		return generatePronounceable(o.Length)
+	}
+
+	var classes []string
+	for _, c := range []struct {
+		used  bool
+		chars string
+	}{{o.Lower, charsLower}, {o.Upper, charsUpper}, {o.Digits, charsDigits}, {o.Symbols, charsSymbols}} {
+		if !c.used {
+			continue
+		}
+		chars := c.chars
+		if o.NoAmbiguous {
+			chars = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(charsAmbiguous, r) {
+					return -1
+				}
+				return r
+			}, chars)
+		}
+		classes = append(classes, chars)
+	}
+	if len(classes) == 0 {
+		return Generated{}, ErrNoCharClasses
+	}
+	all := strings.Join(classes, ``)
+
+	// one character of each class, the rest - of any, then shuffled.
+	password := make([]byte, 0, o.Length)
+	for i := 0; i < o.Length; i++ {
+		chars := all
+		if i < len(classes) {
+			chars = classes[i]
+		}
+		n, err := randIndex(len(chars))
+		if err != nil {
+			return Generated{}, err
+		}
+		password = append(password, chars[n])
+	}
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randIndex(i + 1)
+		if err != nil {
+			return Generated{}, err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+
+	// the class requirement slightly reduces the entropy, the estimate ignores it.
+	return Generated{Secret: string(password), Entropy: float64(o.Length) * math.Log2(float64(len(all)))}, nil
+}
+
+// generatePronounceable generates the password of the lower case letters, alternating the consonants and vowels.
+func generatePronounceable(length int) (Generated, error) {
+	var (
+		b       strings.Builder
+		entropy float64
+	)
+	for i := 0; i < length; i++ {
+		chars := charsConsonants
+		if i%2 == 1 {
+			chars = charsVowels
+		}
+		n, err := randIndex(len(chars))
+		if err != nil {
+			return Generated{}, err
+		}
+		b.WriteByte(chars[n])
+		entropy += math.Log2(float64(len(chars)))
+	}
+	return Generated{Secret: b.String(), Entropy: entropy}, nil
+}
+
+// GeneratePassphrase generates the passphrase of the random words of the embedded wordlist, like diceware does.
+func GeneratePassphrase(o PassphraseOptions) (Generated, error) {
+	if o.Words < 3 || o.Words > 64 {
+		return Generated{}, ErrInvalidWords
+	}
+
+	words := make([]string, 0, o.Words)
+	for i := 0; i < o.Words; i++ {
+		n, err := randIndex(len(wordlist))
+		if err != nil {
+			return Generated{}, err
+		}
+		word := wordlist[n]
+		if o.Capitalize {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		words = append(words, word)
+	}
+	return Generated{
+		Secret:  strings.Join(words, o.Separator),
+		Entropy: float64(o.Words) * math.Log2(float64(len(wordlist))),
+	}, nil
+}
+
+// randIndex returns the uniformly distributed random number from 0 to n-1, read from the cryptographic RNG.
+func randIndex(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(i.Int64()), nil
+}
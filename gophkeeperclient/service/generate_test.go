@@ -0,0 +1,84 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+// TestGeneratePassword verifies, that:
+// 1) password has the length, every chosen class and the expected entropy
+// 2) ambiguous characters are skipped, pronounceable password alternates consonants and vowels
+// 3) too short password and no classes are rejected
+func TestGeneratePassword(t *testing.T) {
+	tests := []struct {
+		name    string
+		number  uint8
+		opts    PasswordOptions
+		classes []string
+		entropy float64
+		err     error
+	}{
+		{name: "Test #1: all classes", number: 1,
+			opts:    PasswordOptions{Length: 20, Lower: true, Upper: true, Digits: true, Symbols: true},
+			classes: []string{charsLower, charsUpper, charsDigits, charsSymbols}, entropy: 129.8},
+		{name: "Test #2: digits only", number: 1, opts: PasswordOptions{Length: 6, Digits: true},
+			classes: []string{charsDigits}, entropy: 19.9},
+		{name: "Test #3: no ambiguous characters", number: 2,
+			opts: PasswordOptions{Length: 64, Lower: true, Upper: true, Digits: true, Symbols: true, NoAmbiguous: true}},
+		{name: "Test #4: pronounceable", number: 3, opts: PasswordOptions{Length: 10, Pronounceable: true}, entropy: 32.5},
+		{name: "Test #5: too short", number: 4, opts: PasswordOptions{Length: 3, Lower: true}, err: ErrInvalidLength},
+		{name: "Test #6: no classes", number: 4, opts: PasswordOptions{Length: 12}, err: ErrNoCharClasses},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := GeneratePassword(tt.opts)
+			switch tt.number {
+			case 1:
+				require.NoError(t, err)
+				assert.Len(t, g.Secret, tt.opts.Length)
+				for _, class := range tt.classes {
+					assert.True(t, strings.ContainsAny(g.Secret, class), class)
+				}
+				assert.InDelta(t, tt.entropy, g.Entropy, 0.1)
+			case 2:
+				require.NoError(t, err)
+				assert.False(t, strings.ContainsAny(g.Secret, charsAmbiguous), g.Secret)
+			case 3:
+				require.NoError(t, err)
+				for i, r := range g.Secret {
+					chars := charsConsonants
+					if i%2 == 1 {
+						chars = charsVowels
+					}
+					assert.True(t, strings.ContainsRune(chars, r), g.Secret)
+				}
+				assert.InDelta(t, tt.entropy, g.Entropy, 0.1)
+			case 4:
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+// TestGeneratePassphrase verifies, that:
+// 1) passphrase is made of the capitalized wordlist words, joined by the separator, with 11 bits of entropy per word
+// 2) too few words are rejected
+func TestGeneratePassphrase(t *testing.T) {
+	require.Len(t, wordlist, 2048)
+
+	g, err := GeneratePassphrase(PassphraseOptions{Words: 6, Separator: "-", Capitalize: true})
+	require.NoError(t, err)
+	words := strings.Split(g.Secret, "-")
+	require.Len(t, words, 6)
+	for _, w := range words {
+		assert.Contains(t, wordlist, strings.ToLower(w))
+		assert.Equal(t, strings.ToUpper(w[:1]), w[:1])
+	}
+	assert.Equal(t, float64(66), g.Entropy)
+	assert.Equal(t, "strong", g.Strength())
+
+	_, err = GeneratePassphrase(PassphraseOptions{Words: 2})
+	assert.ErrorIs(t, err, ErrInvalidWords)
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+)
+
+var (
+	ErrVersionNotFound = errors.New("version not found")
+)
+
+// RestoreItem saves the old version of the item on the server as the new latest version. in keeps the item type,
+// id or title and the version: 0 restores the latest version, that is not a tombstone. The payload is bound to
+// the item version, so the payload of the old version is opened and sealed again for the new latest version.
+// Returns the new latest version.
+func RestoreItem(ctx context.Context, c pb.KeeperClient, in *pb.RestoreItemRequest, key models.Sealer) (uint32, error) {
+	list, err := c.ListVersions(ctx, &pb.ListVersionsRequest{Type: in.GetType(), Id: in.GetId(), Title: in.GetTitle()})
+	if err != nil {
+		return 0, err
+	}
+	versions := list.GetVersions()
+	version := in.GetVersion()
+	for _, v := range versions {
+		if version == 0 && !v.GetDeleted() {
+			version = v.GetVersion()
+		}
+	}
+	if len(versions) == 0 || version == 0 {
+		return 0, ErrVersionNotFound
+	}
+
+	resp, err := c.GetVersion(ctx, &pb.GetVersionRequest{Type: in.GetType(), Id: in.GetId(), Title: in.GetTitle(),
+		Version: version})
+	if err != nil {
+		return 0, err
+	}
+	old := versionItem(resp)
+	fields, err := openFields(key, in.GetType(), old.GetId(), old.GetTitle(), old.GetVersion(), old.GetPayload())
+	if err != nil {
+		return 0, err
+	}
+	next := versions[0].GetVersion() + 1
+	payload, err := models.SealPayloadFields(key, in.GetType(), old.GetId(), next, fields)
+	if err != nil {
+		return 0, err
+	}
+
+	restored, err := c.RestoreItem(ctx, &pb.RestoreItemRequest{Type: in.GetType(), Id: old.GetId(), Version: version,
+		Payload: payload, NextVersion: next})
+	if err != nil {
+		return 0, err
+	}
+	return restored.GetVersion(), nil
+}
+
+// versionItem wraps the item version to the Item envelope.
+func versionItem(resp *pb.GetVersionResponse) *pb.Item {
+	switch item := resp.GetItem().(type) {
+	case *pb.GetVersionResponse_Pair:
+		return models.PairToItem(item.Pair)
+	case *pb.GetVersionResponse_Text:
+		return models.TextToItem(item.Text)
+	case *pb.GetVersionResponse_BinData:
+		return models.BinToItem(item.BinData)
+	case *pb.GetVersionResponse_Card:
+		return models.CardToItem(item.Card)
+	}
+	return resp.GetEnvelope()
+}
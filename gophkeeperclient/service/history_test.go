@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"testing"
+)
+
+// historyClient imitates the server ListVersions, GetVersion and RestoreItem handlers. Other methods are not
+// implemented.
+type historyClient struct {
+	pb.KeeperClient
+	versions []*pb.ItemVersion
+	text     *pb.Text
+	restore  *pb.RestoreItemRequest
+}
+
+func (c *historyClient) ListVersions(ctx context.Context, in *pb.ListVersionsRequest, opts ...grpc.CallOption) (*pb.ListVersionsResponse, error) {
+	return &pb.ListVersionsResponse{Versions: c.versions}, nil
+}
+
+func (c *historyClient) GetVersion(ctx context.Context, in *pb.GetVersionRequest, opts ...grpc.CallOption) (*pb.GetVersionResponse, error) {
+	text := &pb.Text{Id: c.text.GetId(), Title: c.text.GetTitle(), Payload: c.text.GetPayload(), Version: in.GetVersion()}
+	return &pb.GetVersionResponse{Item: &pb.GetVersionResponse_Text{Text: text}}, nil
+}
+
+func (c *historyClient) RestoreItem(ctx context.Context, in *pb.RestoreItemRequest, opts ...grpc.CallOption) (*pb.RestoreItemResponse, error) {
+	c.restore = in
+	return &pb.RestoreItemResponse{Status: "success", Version: in.GetNextVersion()}, nil
+}
+
+// TestRestoreItem verifies, that:
+// 1) version 0 restores the latest version, that is not a tombstone
+// 2) restored payload is sealed again for the new latest version
+// 3) item without the versions to restore and the payload of another version are rejected
+func TestRestoreItem(t *testing.T) {
+	versions := []*pb.ItemVersion{{Version: 3, Deleted: true}, {Version: 2}, {Version: 1}}
+
+	tests := []struct {
+		name     string
+		number   uint8
+		versions []*pb.ItemVersion
+		version  uint32
+		sealedAt uint32
+		wantErr  error
+	}{
+		{name: "Test #1: latest not deleted version", number: 1, versions: versions, sealedAt: 2},
+		{name: "Test #2: chosen version", number: 2, versions: versions, version: 1, sealedAt: 1},
+		{name: "Test #3: tombstones only", number: 3, versions: versions[:1], wantErr: ErrVersionNotFound},
+		{name: "Test #4: payload of another version", number: 4, versions: versions, version: 1, sealedAt: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &historyClient{versions: tt.versions,
+				text: &pb.Text{Id: testID1, Title: "t1", Payload: sealText(t, testID1, tt.sealedAt, "old body", "c")}}
+
+			version, err := RestoreItem(context.Background(), c, &pb.RestoreItemRequest{Type: "text", Title: "t1",
+				Version: tt.version}, testKey)
+			switch tt.number {
+			case 1, 2:
+				require.NoError(t, err)
+				assert.Equal(t, uint32(4), version)
+				require.NotNil(t, c.restore)
+				assert.Equal(t, tt.sealedAt, c.restore.GetVersion())
+				assert.Equal(t, testID1, c.restore.GetId())
+
+				text, err := models.ProtoToModelsText(&pb.Text{Id: testID1, Title: "t1", Version: 4,
+					Payload: c.restore.GetPayload()}, testKey)
+				require.NoError(t, err)
+				assert.Equal(t, "old body", text.Body)
+				assert.Equal(t, "c", text.Comment)
+			case 3:
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, c.restore)
+			case 4:
+				assert.Error(t, err)
+				assert.Nil(t, c.restore)
+			}
+		})
+	}
+}
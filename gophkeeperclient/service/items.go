@@ -0,0 +1,145 @@
+package service
+
+import (
+	"errors"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"sort"
+	"time"
+)
+
+var (
+	ErrInvalidItemID  = errors.New("invalid item id")
+	ErrItemNotFound   = errors.New("item not found")
+	ErrAmbiguousTitle = errors.New("several items have the title, please use the item id")
+)
+
+// itemHeads returns the heads of the vault items of the type.
+func itemHeads(v *models.Vault, dataType string) []models.ItemHead {
+	var heads []models.ItemHead
+	for _, it := range v.Items(dataType) {
+		heads = append(heads, it.Head())
+	}
+	return heads
+}
+
+// FindItem returns the id of the vault item, referred by id or, if it is empty, by title. Like the server does,
+// the item, that is not deleted, is preferred to the deleted ones. ErrAmbiguousTitle, if the title is shared
+// by several items. ErrItemNotFound, if there is no such item.
+func FindItem(v *models.Vault, dataType, id, title string) (string, error) {
+	heads := itemHeads(v, dataType)
+	if id != `` {
+		if !models.ValidItemID(id) {
+			return ``, ErrInvalidItemID
+		}
+		for _, h := range heads {
+			if h.ItemID == id {
+				return id, nil
+			}
+		}
+		return ``, ErrItemNotFound
+	}
+
+	var found []string
+	for _, deleted := range []bool{false, true} {
+		for _, h := range heads {
+			if h.Title == title && h.Deleted == deleted {
+				found = append(found, h.ItemID)
+			}
+		}
+		if len(found) > 0 {
+			break
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return ``, ErrItemNotFound
+	case 1:
+		return found[0], nil
+	}
+	return ``, ErrAmbiguousTitle
+}
+
+// SaveItemID returns the id of the saved item: the passed one, the id of the item found by title
+// or a new one for a new item.
+func SaveItemID(v *models.Vault, dataType, id, title string) (string, error) {
+	if id != `` {
+		if !models.ValidItemID(id) {
+			return ``, ErrInvalidItemID
+		}
+		return id, nil
+	}
+	found, err := FindItem(v, dataType, ``, title)
+	if errors.Is(err, ErrItemNotFound) {
+		return models.NewItemID()
+	}
+	return found, err
+}
+
+// RenameItem saves the next version of the item with the new title and queues the change. The item content
+// is not changed, the payload is sealed again.
+func RenameItem(v *models.Vault, outbox *clstor.Outbox, dataType, id, title string, key models.Sealer) error {
+	prev, err := getSealed(v, dataType, id, key)
+	if err != nil {
+		return err
+	}
+	if prev == nil || prev.Deleted {
+		return ErrItemNotFound
+	}
+
+	fields, err := openFields(key, dataType, id, prev.Title, prev.Version, prev.Payload)
+	if err != nil {
+		return err
+	}
+	return saveItem(v, outbox, dataType, id, title, fields, key, time.Now())
+}
+
+// SaveItem puts the item to the vault as the next version and queues the change. The item is sealed as is:
+// the streamed bin, saved with the body, is not streamed anymore.
+func SaveItem(v *models.Vault, outbox *clstor.Outbox, it models.Item, key models.Sealer) error {
+	head := it.Head()
+	return putVersion(v, outbox, head.Type, head.ItemID, func(version uint32) (*pb.Item, error) {
+		return models.SealItem(models.WithVersion(it, version), key)
+	}, false, key, time.Now())
+}
+
+// DeleteItem records the tombstone of the item and queues the deletion. ErrItemNotFound, if the vault has no such item
+// or it is already deleted.
+func DeleteItem(v *models.Vault, outbox *clstor.Outbox, dataType, id string, key models.Sealer) error {
+	it, ok := v.Item(dataType, id)
+	if !ok || it.Head().Deleted {
+		return ErrItemNotFound
+	}
+	return deleteItem(v, outbox, dataType, id, key, time.Now())
+}
+
+// ListItems returns the heads of the live vault items of the type, all types if it is empty, with the tag.
+// Items are sorted by type (in models.ItemTypes order) and title.
+func ListItems(v *models.Vault, dataType, tag string) ([]models.ItemHead, error) {
+	types := models.ItemTypes
+	if dataType != `` {
+		if !models.ValidItemType(dataType) {
+			return nil, models.ErrUnknownItemType
+		}
+		types = []string{dataType}
+	}
+
+	var heads []models.ItemHead
+	for _, t := range types {
+		typeHeads := itemHeads(v, t)
+		sort.Slice(typeHeads, func(i, j int) bool {
+			if typeHeads[i].Title != typeHeads[j].Title {
+				return typeHeads[i].Title < typeHeads[j].Title
+			}
+			return typeHeads[i].ItemID < typeHeads[j].ItemID
+		})
+		for _, h := range typeHeads {
+			if !h.Deleted && HasTag(h, tag) {
+				heads = append(heads, h)
+			}
+		}
+	}
+	return heads, nil
+}
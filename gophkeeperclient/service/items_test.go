@@ -0,0 +1,149 @@
+package service
+
+import (
+	"database/sql"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// itemsVault returns the vault with two live texts, sharing the title, a live and a deleted pair.
+func itemsVault() *models.Vault {
+	return &models.Vault{
+		Pair: map[string]*models.Pair{
+			testID1: {ItemID: testID1, Title: "p1", Version: 1},
+			testID2: {ItemID: testID2, Title: "p1", Version: 2, DeletedAt: sql.NullTime{Valid: true}},
+		},
+		Text: map[string]*models.Text{
+			testID3: {ItemID: testID3, Title: "t1", Version: 1},
+			testID4: {ItemID: testID4, Title: "t1", Version: 1},
+		},
+		Bin:  map[string]*models.Bin{},
+		Card: map[string]*models.Card{},
+	}
+}
+
+// TestFindItem verifies, that:
+// 1) item is found by id, invalid and unknown ids are rejected
+// 2) item is found by title, the live item is preferred to the deleted one
+// 3) ambiguous and unknown titles are rejected
+func TestFindItem(t *testing.T) {
+	tests := []struct {
+		name     string
+		number   uint8
+		dataType string
+		id       string
+		title    string
+	}{
+		{name: "Test #1: by id", number: 1, dataType: "text", id: testID4, title: "t1"},
+		{name: "Test #2: invalid id", number: 2, dataType: "text", id: "t1"},
+		{name: "Test #3: unknown id", number: 3, dataType: "pair", id: testID3},
+		{name: "Test #4: live item is preferred to the deleted one", number: 4, dataType: "pair", title: "p1"},
+		{name: "Test #5: ambiguous title", number: 5, dataType: "text", title: "t1"},
+		{name: "Test #6: unknown title", number: 6, dataType: "card", title: "c1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := FindItem(itemsVault(), tt.dataType, tt.id, tt.title)
+			switch tt.number {
+			case 1:
+				assert.NoError(t, err)
+				assert.Equal(t, testID4, id)
+			case 2:
+				assert.ErrorIs(t, err, ErrInvalidItemID)
+			case 3, 6:
+				assert.ErrorIs(t, err, ErrItemNotFound)
+			case 4:
+				assert.NoError(t, err)
+				assert.Equal(t, testID1, id)
+			case 5:
+				assert.ErrorIs(t, err, ErrAmbiguousTitle)
+			}
+		})
+	}
+}
+
+// TestRenameItem verifies, that:
+// 1) renamed item keeps the content, the new version is sealed and queued
+// 2) deleted and unknown items are not renamed
+func TestRenameItem(t *testing.T) {
+	tests := []struct {
+		name   string
+		number uint8
+		id     string
+	}{
+		{name: "Test #1: renamed, new version is queued", number: 1, id: testID1},
+		{name: "Test #2: deleted item", number: 2, id: testID2},
+		{name: "Test #3: unknown item", number: 3, id: testID3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := itemsVault()
+			outbox := new(clstor.Outbox)
+			v.Pair[testID1].Login = "l1"
+			v.Pair[testID1].Tags = []string{"work"}
+
+			err := RenameItem(v, outbox, "pair", tt.id, "p2", testKey)
+			switch tt.number {
+			case 1:
+				require.NoError(t, err)
+				assert.Equal(t, "p2", v.Pair[testID1].Title)
+				assert.Equal(t, "l1", v.Pair[testID1].Login)
+				assert.Equal(t, uint32(2), v.Pair[testID1].Version)
+				require.Len(t, outbox.Ops, 1)
+				assert.Equal(t, testID1, outbox.Ops[0].ItemID)
+				assert.Equal(t, "p2", outbox.Ops[0].Title)
+				fields, err := models.OpenPayloadFields(testKey, "pair", testID1, "p2", 2, outbox.Ops[0].Payload)
+				require.NoError(t, err)
+				assert.JSONEq(t, `"l1"`, string(fields["login"]))
+				assert.JSONEq(t, `["work"]`, string(fields["tags"]))
+			case 2, 3:
+				assert.ErrorIs(t, err, ErrItemNotFound)
+				assert.Empty(t, outbox.Ops)
+			}
+		})
+	}
+}
+
+// TestListItems verifies, that:
+// 1) items of all types or of one type are listed, tombstones are skipped
+// 2) items are filtered by tag, the case is ignored
+// 3) unknown type is rejected
+func TestListItems(t *testing.T) {
+	tests := []struct {
+		name     string
+		number   uint8
+		dataType string
+		tag      string
+		want     []string
+	}{
+		{name: "Test #1: all types, tombstones are skipped", number: 1, want: []string{testID1, testID3, testID4}},
+		{name: "Test #2: one type", number: 1, dataType: "text", want: []string{testID3, testID4}},
+		{name: "Test #3: tag, the case is ignored", number: 1, tag: "Work", want: []string{testID1, testID4}},
+		{name: "Test #4: unknown tag", number: 1, tag: "home"},
+		{name: "Test #5: unknown type", number: 2, dataType: "note"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := itemsVault()
+			v.Pair[testID1].Tags = []string{"work"}
+			v.Pair[testID2].Tags = []string{"work"}
+			v.Text[testID4].Tags = []string{"private", "work"}
+
+			heads, err := ListItems(v, tt.dataType, tt.tag)
+			switch tt.number {
+			case 1:
+				require.NoError(t, err)
+				var ids []string
+				for _, h := range heads {
+					ids = append(ids, h.ItemID)
+				}
+				assert.Equal(t, tt.want, ids)
+			case 2:
+				assert.ErrorIs(t, err, models.ErrUnknownItemType)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"io"
+)
+
+// SealLegacyItems seals the plaintext items, the server kept from the time before the items were sealed on the client
+// side, and queues them as the next version. The server erases the plaintext, once the sealed version is saved.
+// The local version is sealed, if it is not older. The items with the queued changes are left: the changes are sealed
+// already. Returns the number of the queued items.
+func SealLegacyItems(ctx context.Context, c pb.KeeperClient, v *models.Vault, outbox *clstor.Outbox, key models.Sealer) (int, error) {
+	stream, err := c.LegacyItems(ctx, &pb.LegacyItemsRequest{})
+	if err != nil {
+		return 0, err
+	}
+
+	var sealed int
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return sealed, nil
+		}
+		if err != nil {
+			return sealed, err
+		}
+
+		legacy := resp.GetItem()
+		if queued(outbox, legacy.GetType(), legacy.GetId()) {
+			continue
+		}
+		it, err := models.LegacyToItem(legacy)
+		if err != nil {
+			return sealed, err
+		}
+		if local, ok := v.Item(legacy.GetType(), legacy.GetId()); ok && local.Head().Version >= legacy.GetVersion() {
+			if local.Head().Deleted {
+				continue
+			}
+			it = local
+		}
+
+		v.PutItem(it)
+		if err = SaveItem(v, outbox, it, key); err != nil {
+			return sealed, err
+		}
+		sealed++
+	}
+}
+
+// queued reports, if the outbox has the change of the item.
+func queued(outbox *clstor.Outbox, dataType, id string) bool {
+	for _, op := range outbox.Ops {
+		if op.Type == dataType && op.ItemID == id {
+			return true
+		}
+	}
+	return false
+}
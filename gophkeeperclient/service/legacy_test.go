@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestSealLegacyItems verifies, that:
+// 1) plaintext server items, missing locally or newer, are sealed and queued as the next version
+// 2) local version, that is not older, is sealed instead; the deleted item is not restored
+// 3) items with the queued changes are left
+func TestSealLegacyItems(t *testing.T) {
+	legacy := []*pb.LegacyItem{
+		{Type: "pair", Id: testID1, Title: "p1", Version: 3, Login: "l1", Pass: "remote"},
+		{Type: "text", Id: testID2, Title: "t1", Version: 2, Body: "remote"},
+		{Type: "card", Id: testID3, Title: "c1", Version: 1, Number: "4111"},
+	}
+
+	tests := []struct {
+		name   string
+		number uint8
+	}{
+		{name: "Test #1: missing and older local items are sealed from the server data", number: 1},
+		{name: "Test #2: the local version is sealed, if it is not older", number: 2},
+		{name: "Test #3: items with the queued changes are left", number: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := clstor.MakeVault()
+			outbox := new(clstor.Outbox)
+			c := &syncClient{legacy: legacy}
+
+			switch tt.number {
+			case 1:
+				v.PutItem(&models.Text{ItemID: testID2, Title: "t1", Body: "local", Version: 1})
+				n, err := SealLegacyItems(context.Background(), c, v, outbox, testKey)
+				require.NoError(t, err)
+				assert.Equal(t, 3, n)
+				require.Len(t, outbox.Ops, 3)
+				assert.Equal(t, uint32(4), outbox.Ops[0].Version)
+				assert.Equal(t, uint32(2), outbox.Ops[1].BaseVersion)
+				assert.Equal(t, "remote", v.Text[testID2].Body)
+				assert.Equal(t, uint32(3), v.Text[testID2].Version)
+				// sent sealed.
+				p, err := models.OpenItem(&pb.Item{Type: "pair", Id: testID1, Title: "p1", Version: 4,
+					Payload: outbox.Ops[0].Payload}, testKey)
+				require.NoError(t, err)
+				assert.Equal(t, "remote", p.(*models.Pair).Pass)
+			case 2:
+				v.PutItem(&models.Pair{ItemID: testID1, Title: "p1", Login: "l1", Pass: "local", Version: 3})
+				v.PutItem(&models.Text{ItemID: testID2, Title: "t1", Version: 5, DeletedAt: sql.NullTime{Valid: true}})
+				n, err := SealLegacyItems(context.Background(), c, v, outbox, testKey)
+				require.NoError(t, err)
+				// the deleted text is not restored.
+				assert.Equal(t, 2, n)
+				assert.Equal(t, "local", v.Pair[testID1].Pass)
+				assert.Equal(t, uint32(4), v.Pair[testID1].Version)
+				assert.True(t, v.Text[testID2].DeletedAt.Valid)
+			case 3:
+				outbox.Add(&clstor.Operation{Type: "pair", ItemID: testID1, Title: "p1", Version: 4})
+				n, err := SealLegacyItems(context.Background(), c, v, outbox, testKey)
+				require.NoError(t, err)
+				assert.Equal(t, 2, n)
+				assert.Len(t, outbox.Ops, 3)
+				_, ok := v.Pair[testID1]
+				assert.False(t, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"sort"
+	"time"
+)
+
+const (
+	KeepLocal  = "local"
+	KeepRemote = "remote"
+	KeepBoth   = "both"
+
+	// titleField is the name of the item title in the conflicting fields list. Payload fields never have it.
+	titleField = "title"
+)
+
+var (
+	ErrUnknownKeep = errors.New("keep must be local, remote or both")
+)
+
+// MergeResult describes, how a local change, rejected by the server as conflicting, was handled.
+type MergeResult struct {
+	Type     string
+	ItemID   string
+	Title    string
+	Merged   bool             // changes didn't overlap, the merged version is queued.
+	Conflict *models.Conflict // changes overlap, the local content is kept as the conflict copy.
+}
+
+// getSealed returns the sealed form of the vault item. Nil, if not found.
+func getSealed(v *models.Vault, dataType, id string, key models.Sealer) (*pb.Item, error) {
+	if !models.ValidItemType(dataType) {
+		return nil, fmt.Errorf("unknown item type %q", dataType)
+	}
+	it, ok := v.Item(dataType, id)
+	if !ok {
+		return nil, nil
+	}
+	return models.SealItem(it, key)
+}
+
+// putSealed opens the sealed item and puts it to the vault.
+func putSealed(v *models.Vault, it *pb.Item, key models.Sealer) error {
+	item, err := models.OpenItem(it, key)
+	if err != nil {
+		return err
+	}
+	v.PutItem(item)
+	return nil
+}
+
+// openFields opens the sealed payload fields of the item version. Empty payload gives no fields. title is the item
+// title of the payload version: the payloads, sealed before the item ids, are bound to it.
+func openFields(key models.Sealer, dataType, id, title string, version uint32, sealed []byte) (map[string]json.RawMessage,
+	error) {
+	if len(sealed) == 0 {
+		return map[string]json.RawMessage{}, nil
+	}
+	return models.OpenPayloadFields(key, dataType, id, title, version, sealed)
+}
+
+// MergeFields makes the three-way merge of the item payload fields. A field, changed only on one side, takes
+// that change. Returns the merged fields and the fields changed on both sides differently - they take the remote
+// value. The merge is clean, if there are none.
+func MergeFields(base, local, remote map[string]json.RawMessage) (map[string]json.RawMessage, []string) {
+	names := make(map[string]struct{})
+	for _, m := range []map[string]json.RawMessage{base, local, remote} {
+		for k := range m {
+			names[k] = struct{}{}
+		}
+	}
+
+	merged := make(map[string]json.RawMessage)
+	var conflicts []string
+	for name := range names {
+		b, l, r := base[name], local[name], remote[name]
+		var value json.RawMessage
+		switch {
+		case bytes.Equal(l, r), bytes.Equal(r, b):
+			value = l
+		case bytes.Equal(l, b):
+			value = r
+		default:
+			value = r
+			conflicts = append(conflicts, name)
+		}
+		if value != nil {
+			merged[name] = value
+		}
+	}
+	sort.Strings(conflicts)
+
+	return merged, conflicts
+}
+
+// MergeTitle makes the three-way merge of the item title, like MergeFields. conflict reports the title,
+// changed on both sides differently - it takes the remote value.
+func MergeTitle(base, local, remote string) (title string, conflict bool) {
+	switch {
+	case local == remote, remote == base:
+		return local, false
+	case local == base:
+		return remote, false
+	}
+	return remote, true
+}
+
+// saveItem puts the fields to the vault as the next version of the item and queues the change.
+// The next version of the streamed bin keeps its body.
+func saveItem(v *models.Vault, outbox *clstor.Outbox, dataType, id, title string, fields map[string]json.RawMessage,
+	key models.Sealer, now time.Time) error {
+	return putVersion(v, outbox, dataType, id, func(version uint32) (*pb.Item, error) {
+		payload, err := models.SealPayloadFields(key, dataType, id, version, fields)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.Item{Type: dataType, Id: id, Title: title, Payload: payload}, nil
+	}, true, key, now)
+}
+
+// putVersion puts the item, sealed by seal for the next version, to the vault and queues the change. The replaced
+// version is the base of the change: concurrent changes are merged against it. With keepBody the next version
+// of the streamed bin keeps its body.
+func putVersion(v *models.Vault, outbox *clstor.Outbox, dataType, id string, seal func(version uint32) (*pb.Item, error),
+	keepBody bool, key models.Sealer, now time.Time) error {
+	prev, err := getSealed(v, dataType, id, key)
+	if err != nil {
+		return err
+	}
+	// tombstone too: new data must be newer, than the deletion.
+	version := uint32(1)
+	if prev != nil {
+		version = prev.Version + 1
+	}
+	next, err := seal(version)
+	if err != nil {
+		return err
+	}
+
+	op := &clstor.Operation{Type: next.Type, ItemID: next.Id, Title: next.Title, Version: version, CreatedAt: now}
+	if prev != nil {
+		op.BaseVersion = prev.Version
+		op.BaseTitle = prev.Title
+		op.Base = prev.Payload
+		if keepBody && prev.Streamed && !prev.Deleted {
+			next.Streamed, next.Size = true, prev.Size
+		}
+	}
+
+	op.Payload, op.Streamed = next.Payload, next.Streamed
+	next.Version = op.Version
+	if err = putSealed(v, next, key); err != nil {
+		return err
+	}
+	outbox.Add(op)
+
+	return nil
+}
+
+// deleteItem records the tombstone of the item and queues the deletion. Nothing is done, if the item is not found
+// or already deleted.
+func deleteItem(v *models.Vault, outbox *clstor.Outbox, dataType, id string, key models.Sealer, now time.Time) error {
+	prev, err := getSealed(v, dataType, id, key)
+	if err != nil || prev == nil || prev.Deleted {
+		return err
+	}
+
+	op := &clstor.Operation{Type: dataType, ItemID: id, Title: prev.Title, Version: prev.Version + 1, Deleted: true,
+		CreatedAt: now, BaseVersion: prev.Version, BaseTitle: prev.Title, Base: prev.Payload}
+	tombstone := &pb.Item{Type: dataType, Id: id, Title: prev.Title, Version: op.Version, Deleted: true}
+	if err = putSealed(v, tombstone, key); err != nil {
+		return err
+	}
+	outbox.Add(op)
+
+	return nil
+}
+
+// copyTitle returns a free title for the conflict copy of the item.
+func copyTitle(v *models.Vault, dataType, title string, now time.Time) string {
+	name := fmt.Sprintf("%s (conflict %s)", title, now.Format("2006-01-02 15:04"))
+	for i := 2; ; i++ {
+		if !hasTitle(v, dataType, name) {
+			return name
+		}
+		name = fmt.Sprintf("%s (conflict %s #%d)", title, now.Format("2006-01-02 15:04"), i)
+	}
+}
+
+// hasTitle reports, if the vault has the item with the title (tombstones included).
+func hasTitle(v *models.Vault, dataType, title string) bool {
+	for _, h := range itemHeads(v, dataType) {
+		if h.Title == title {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeConflict handles the local change, rejected by the server as conflicting. first and last are the first
+// and the last rejected operations of the item: first holds the base, last - the local state.
+// remote is the server version of the item, nil if unknown.
+// Non overlapping changes (the title included) are merged and queued as the next version. Otherwise the item takes
+// the remote version, the local content is saved as the conflict copy and the conflict is recorded.
+func mergeConflict(v *models.Vault, outbox *clstor.Outbox, first, last *clstor.Operation, remote *pb.Item,
+	key models.Sealer, now time.Time) (*MergeResult, error) {
+	res := &MergeResult{Type: last.Type, ItemID: last.ItemID, Title: last.Title}
+	conflict := &models.Conflict{
+		Type:         last.Type,
+		ItemID:       last.ItemID,
+		Title:        last.Title,
+		LocalDeleted: last.Deleted,
+		BaseVersion:  first.BaseVersion,
+		DetectedAt:   now,
+	}
+
+	if remote == nil {
+		// remote version is unknown: the full synchronization will bring it.
+		v.RemoveItem(last.Type, last.ItemID)
+		v.Cursor = 0
+	} else {
+		conflict.RemoteVersion = remote.Version
+		if remote.Title != last.Title {
+			conflict.Title, conflict.LocalTitle = remote.Title, last.Title
+		}
+		if err := putSealed(v, remote, key); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case last.Deleted && (remote == nil || remote.Deleted):
+		// deleted on both sides
+		return res, nil
+	case last.Deleted:
+		// deleted locally, changed remotely: nothing to copy.
+		v.Conflicts = append(v.Conflicts, conflict)
+		res.Conflict = conflict
+		return res, nil
+	}
+
+	local, err := openFields(key, last.Type, last.ItemID, last.Title, last.Version, last.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if remote != nil && !remote.Deleted {
+		// operations, queued before the item ids, have no base title: the title was not changed then.
+		baseTitle := first.BaseTitle
+		if baseTitle == `` && len(first.Base) > 0 {
+			baseTitle = first.Title
+		}
+		base, err := openFields(key, first.Type, first.ItemID, baseTitle, first.BaseVersion, first.Base)
+		if err != nil {
+			return nil, err
+		}
+		theirs, err := openFields(key, last.Type, last.ItemID, remote.Title, remote.Version, remote.Payload)
+		if err != nil {
+			return nil, err
+		}
+
+		merged, fields := MergeFields(base, local, theirs)
+		title, titleConflict := MergeTitle(baseTitle, last.Title, remote.Title)
+		if titleConflict {
+			fields = append(fields, titleField)
+			sort.Strings(fields)
+		}
+		if len(fields) == 0 {
+			res.Merged, res.Title = true, title
+			return res, saveItem(v, outbox, last.Type, last.ItemID, title, merged, key, now)
+		}
+		conflict.Fields = fields
+	}
+
+	// local content is kept as a new item
+	if conflict.CopyID, err = models.NewItemID(); err != nil {
+		return nil, err
+	}
+	conflict.CopyTitle = copyTitle(v, last.Type, last.Title, now)
+	if err = saveItem(v, outbox, last.Type, conflict.CopyID, conflict.CopyTitle, local, key, now); err != nil {
+		return nil, err
+	}
+	v.Conflicts = append(v.Conflicts, conflict)
+	res.Conflict = conflict
+
+	return res, nil
+}
+
+// ResolveConflict resolves the recorded conflict and queues the resulting changes:
+//   - KeepLocal: the local content (or deletion) and title replace the remote version, the conflict copy is deleted;
+//   - KeepRemote: the remote version stays, the conflict copy is deleted;
+//   - KeepBoth: the remote version and the conflict copy stay as separate items.
+func ResolveConflict(v *models.Vault, outbox *clstor.Outbox, c *models.Conflict, keep string, key models.Sealer) error {
+	now := time.Now()
+
+	switch keep {
+	case KeepLocal:
+		if c.LocalDeleted {
+			if err := deleteItem(v, outbox, c.Type, c.ItemID, key, now); err != nil {
+				return err
+			}
+			break
+		}
+		cp, err := getSealed(v, c.Type, c.CopyID, key)
+		if err != nil {
+			return err
+		}
+		if cp != nil && !cp.Deleted {
+			local, err := openFields(key, c.Type, c.CopyID, cp.Title, cp.Version, cp.Payload)
+			if err != nil {
+				return err
+			}
+			title := c.Title
+			if c.LocalTitle != `` {
+				title = c.LocalTitle
+			}
+			if err = saveItem(v, outbox, c.Type, c.ItemID, title, local, key, now); err != nil {
+				return err
+			}
+		}
+		if err = deleteItem(v, outbox, c.Type, c.CopyID, key, now); err != nil {
+			return err
+		}
+	case KeepRemote:
+		if c.CopyID != `` {
+			if err := deleteItem(v, outbox, c.Type, c.CopyID, key, now); err != nil {
+				return err
+			}
+		}
+	case KeepBoth:
+	default:
+		return ErrUnknownKeep
+	}
+
+	for i, vc := range v.Conflicts {
+		if vc == c {
+			v.Conflicts = append(v.Conflicts[:i], v.Conflicts[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
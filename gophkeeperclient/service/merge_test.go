@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// sealText returns the sealed text payload of the item version.
+func sealText(t *testing.T, id string, version uint32, body, comment string) []byte {
+	st, err := models.ModelsToProtoText(&models.Text{ItemID: id, Body: body, Comment: comment, Version: version}, testKey)
+	require.NoError(t, err)
+	return st.GetPayload()
+}
+
+// sealLegacyText returns the text payload, sealed before the item ids: bound to the title.
+func sealLegacyText(t *testing.T, title, body, comment string) []byte {
+	plain, err := json.Marshal(map[string]string{"body": body, "comment": comment})
+	require.NoError(t, err)
+	sealed, err := testKey.Seal(plain, []byte("text:"+title))
+	require.NoError(t, err)
+	return sealed
+}
+
+// TestMergeFields verifies, that:
+// 1) fields, changed on one side, and the same changes on both sides are merged
+// 2) field, changed on both sides differently, takes the remote value and is reported as the conflict
+func TestMergeFields(t *testing.T) {
+	raw := func(s string) json.RawMessage { return json.RawMessage(`"` + s + `"`) }
+	base := map[string]json.RawMessage{"login": raw("l"), "pass": raw("p"), "comment": raw("c")}
+
+	tests := []struct {
+		name      string
+		local     map[string]json.RawMessage
+		remote    map[string]json.RawMessage
+		merged    map[string]json.RawMessage
+		conflicts []string
+	}{
+		{
+			name:   "Test #1: different fields changed",
+			local:  map[string]json.RawMessage{"login": raw("l2"), "pass": raw("p"), "comment": raw("c")},
+			remote: map[string]json.RawMessage{"login": raw("l"), "pass": raw("p2"), "comment": raw("c")},
+			merged: map[string]json.RawMessage{"login": raw("l2"), "pass": raw("p2"), "comment": raw("c")},
+		},
+		{
+			name:   "Test #2: same change on both sides",
+			local:  map[string]json.RawMessage{"login": raw("l2"), "pass": raw("p"), "comment": raw("c")},
+			remote: map[string]json.RawMessage{"login": raw("l2"), "pass": raw("p"), "comment": raw("c")},
+			merged: map[string]json.RawMessage{"login": raw("l2"), "pass": raw("p"), "comment": raw("c")},
+		},
+		{
+			name:      "Test #3: same field changed differently",
+			local:     map[string]json.RawMessage{"login": raw("l2"), "pass": raw("p3"), "comment": raw("c")},
+			remote:    map[string]json.RawMessage{"login": raw("l3"), "pass": raw("p3"), "comment": raw("c2")},
+			merged:    map[string]json.RawMessage{"login": raw("l3"), "pass": raw("p3"), "comment": raw("c2")},
+			conflicts: []string{"login"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, conflicts := MergeFields(base, tt.local, tt.remote)
+			assert.Equal(t, tt.merged, merged)
+			assert.Equal(t, tt.conflicts, conflicts)
+		})
+	}
+}
+
+// TestSyncVaultConflicts verifies, that:
+// 1) changes of different fields and titles are merged and queued on top of the remote version
+// 2) same field or title, changed differently, keeps the remote version and the local one as the conflict copy
+// 3) deletion, conflicting with the change, keeps the changed content
+// 4) operation, queued before the item ids, is merged and sealed with the item id and version
+func TestSyncVaultConflicts(t *testing.T) {
+	tests := []struct {
+		name   string
+		number uint8
+		op     *clstor.Operation
+		remote *pb.Text
+	}{
+		{
+			name:   "Test #1: different fields are merged",
+			number: 1,
+			op: &clstor.Operation{Type: "text", ItemID: testID2, Title: "t1", Version: 3,
+				Payload: sealText(t, testID2, 3, "local", "c"), BaseVersion: 2, BaseTitle: "t1",
+				Base: sealText(t, testID2, 2, "base", "c")},
+			remote: &pb.Text{Id: testID2, Title: "t1", Version: 3, Payload: sealText(t, testID2, 3, "base", "remote")},
+		},
+		{
+			name:   "Test #2: same field changed - conflict copy",
+			number: 2,
+			op: &clstor.Operation{Type: "text", ItemID: testID2, Title: "t1", Version: 3,
+				Payload: sealText(t, testID2, 3, "local", "c"), BaseVersion: 2, BaseTitle: "t1",
+				Base: sealText(t, testID2, 2, "base", "c")},
+			remote: &pb.Text{Id: testID2, Title: "t1", Version: 3, Payload: sealText(t, testID2, 3, "remote", "c")},
+		},
+		{
+			name:   "Test #3: deleted locally, changed remotely",
+			number: 3,
+			op: &clstor.Operation{Type: "text", ItemID: testID2, Title: "t1", Version: 3, Deleted: true,
+				BaseVersion: 2, BaseTitle: "t1", Base: sealText(t, testID2, 2, "base", "c")},
+			remote: &pb.Text{Id: testID2, Title: "t1", Version: 3, Payload: sealText(t, testID2, 3, "remote", "c")},
+		},
+		{
+			name:   "Test #4: changed locally, deleted remotely",
+			number: 4,
+			op: &clstor.Operation{Type: "text", ItemID: testID2, Title: "t1", Version: 3,
+				Payload: sealText(t, testID2, 3, "local", "c"), BaseVersion: 2, BaseTitle: "t1",
+				Base: sealText(t, testID2, 2, "base", "c")},
+			remote: &pb.Text{Id: testID2, Title: "t1", Version: 3, Deleted: true},
+		},
+		{
+			name:   "Test #5: renamed remotely, changed locally - merged",
+			number: 5,
+			op: &clstor.Operation{Type: "text", ItemID: testID2, Title: "t1", Version: 3,
+				Payload: sealText(t, testID2, 3, "local", "c"), BaseVersion: 2, BaseTitle: "t1",
+				Base: sealText(t, testID2, 2, "base", "c")},
+			remote: &pb.Text{Id: testID2, Title: "t2", Version: 3, Payload: sealText(t, testID2, 3, "base", "c")},
+		},
+		{
+			name:   "Test #6: renamed differently - conflict copy",
+			number: 6,
+			op: &clstor.Operation{Type: "text", ItemID: testID2, Title: "t3", Version: 3,
+				Payload: sealText(t, testID2, 3, "base", "c"), BaseVersion: 2, BaseTitle: "t1",
+				Base: sealText(t, testID2, 2, "base", "c")},
+			remote: &pb.Text{Id: testID2, Title: "t2", Version: 3, Payload: sealText(t, testID2, 3, "base", "c")},
+		},
+		{
+			name:   "Test #7: operation, queued before the item ids",
+			number: 7,
+			op: &clstor.Operation{Type: "text", ItemID: models.LegacyItemID("text", "t1"), Title: "t1", Version: 3,
+				Payload: sealLegacyText(t, "t1", "local", "c"), BaseVersion: 2, Base: sealLegacyText(t, "t1", "base", "c")},
+			remote: &pb.Text{Id: models.LegacyItemID("text", "t1"), Title: "t1", Version: 3,
+				Payload: sealLegacyText(t, "t1", "base", "remote")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := tt.op.ItemID
+			local := &models.Vault{
+				Pair:   map[string]*models.Pair{},
+				Text:   map[string]*models.Text{id: {ItemID: id, Title: tt.op.Title, Body: "local", Comment: "c", Version: 3}},
+				Bin:    map[string]*models.Bin{},
+				Card:   map[string]*models.Card{},
+				Cursor: 4,
+			}
+			outbox := &clstor.Outbox{Ops: []*clstor.Operation{tt.op}}
+			client := &syncClient{response: &pb.SyncVaultResponse{
+				Status: "success",
+				Cursor: 9,
+				Texts:  []*pb.Text{tt.remote},
+				Results: []*pb.SyncItemResult{{Type: "text", Id: id, Title: tt.op.Title, Version: 3,
+					Status: pb.SyncItemStatus_SYNC_CONFLICT}},
+			}}
+
+			vault, report, err := SyncVault(context.Background(), client, local, outbox, testKey)
+			require.NoError(t, err)
+			require.Len(t, report.Merges, 1)
+			m := report.Merges[0]
+			switch tt.number {
+			case 1:
+				assert.True(t, m.Merged)
+				assert.Empty(t, vault.Conflicts)
+				assert.Equal(t, "local", vault.Text[id].Body)
+				assert.Equal(t, "remote", vault.Text[id].Comment)
+				// merged version is queued on top of the remote one
+				require.Len(t, outbox.Ops, 1)
+				assert.Equal(t, uint32(4), outbox.Ops[0].Version)
+				assert.Equal(t, uint32(3), outbox.Ops[0].BaseVersion)
+			case 2:
+				require.NotNil(t, m.Conflict)
+				assert.Equal(t, []string{"body"}, m.Conflict.Fields)
+				assert.Equal(t, "remote", vault.Text[id].Body)
+				copied := vault.Text[m.Conflict.CopyID]
+				require.NotNil(t, copied)
+				assert.Equal(t, "local", copied.Body)
+				require.Len(t, outbox.Ops, 1)
+				assert.Equal(t, m.Conflict.CopyTitle, outbox.Ops[0].Title)
+				assert.Equal(t, m.Conflict.CopyID, outbox.Ops[0].ItemID)
+				assert.NotEqual(t, id, m.Conflict.CopyID)
+				assert.Equal(t, []*models.Conflict{m.Conflict}, vault.Conflicts)
+			case 3:
+				require.NotNil(t, m.Conflict)
+				assert.True(t, m.Conflict.LocalDeleted)
+				assert.Empty(t, m.Conflict.CopyTitle)
+				assert.Equal(t, "remote", vault.Text[id].Body)
+				assert.Empty(t, outbox.Ops)
+			case 4:
+				require.NotNil(t, m.Conflict)
+				assert.True(t, vault.Text[id].DeletedAt.Valid)
+				assert.Equal(t, "local", vault.Text[m.Conflict.CopyID].Body)
+				require.Len(t, outbox.Ops, 1)
+			case 5:
+				assert.True(t, m.Merged)
+				assert.Equal(t, "t2", m.Title)
+				assert.Equal(t, "t2", vault.Text[id].Title)
+				assert.Equal(t, "local", vault.Text[id].Body)
+				require.Len(t, outbox.Ops, 1)
+				assert.Equal(t, "t2", outbox.Ops[0].Title)
+				assert.Equal(t, "t2", outbox.Ops[0].BaseTitle)
+			case 6:
+				require.NotNil(t, m.Conflict)
+				assert.Equal(t, []string{"title"}, m.Conflict.Fields)
+				assert.Equal(t, "t2", m.Conflict.Title)
+				assert.Equal(t, "t3", m.Conflict.LocalTitle)
+				assert.Equal(t, "t2", vault.Text[id].Title)
+			case 7:
+				assert.True(t, m.Merged)
+				assert.Equal(t, "local", vault.Text[id].Body)
+				assert.Equal(t, "remote", vault.Text[id].Comment)
+				// merged version is sealed with the item id and version
+				require.Len(t, outbox.Ops, 1)
+				_, err = models.OpenPayloadFields(testKey, "text", id, "renamed", outbox.Ops[0].Version, outbox.Ops[0].Payload)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestResolveConflict verifies, that:
+// 1) keep local saves the conflict copy content as the next version of the item and deletes the copy
+// 2) keep remote deletes the copy, keep both leaves it
+// 3) unknown choice is rejected and the conflict is kept
+func TestResolveConflict(t *testing.T) {
+	tests := []struct {
+		name   string
+		number uint8
+		keep   string
+	}{
+		{
+			name:   "Test #1: keep local",
+			number: 1,
+			keep:   KeepLocal,
+		},
+		{
+			name:   "Test #2: keep remote",
+			number: 2,
+			keep:   KeepRemote,
+		},
+		{
+			name:   "Test #3: keep both",
+			number: 3,
+			keep:   KeepBoth,
+		},
+		{
+			name:   "Test #4: unknown choice",
+			number: 4,
+			keep:   "mine",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &models.Conflict{Type: "text", ItemID: testID1, Title: "t1", LocalTitle: "t0", CopyID: testID2,
+				CopyTitle: "t1 (conflict)", RemoteVersion: 3, Fields: []string{"body", "title"}}
+			v := &models.Vault{
+				Pair: map[string]*models.Pair{},
+				Text: map[string]*models.Text{
+					testID1: {ItemID: testID1, Title: "t1", Body: "remote", Version: 3},
+					testID2: {ItemID: testID2, Title: "t1 (conflict)", Body: "local", Version: 1},
+				},
+				Bin:       map[string]*models.Bin{},
+				Card:      map[string]*models.Card{},
+				Conflicts: []*models.Conflict{c},
+			}
+			outbox := new(clstor.Outbox)
+
+			err := ResolveConflict(v, outbox, c, tt.keep, testKey)
+			switch tt.number {
+			case 1:
+				require.NoError(t, err)
+				assert.Equal(t, "local", v.Text[testID1].Body)
+				assert.Equal(t, uint32(4), v.Text[testID1].Version)
+				assert.Equal(t, "t0", v.Text[testID1].Title)
+				assert.True(t, v.Text[testID2].DeletedAt.Valid)
+				assert.Len(t, outbox.Ops, 2)
+				assert.Empty(t, v.Conflicts)
+			case 2:
+				require.NoError(t, err)
+				assert.Equal(t, "remote", v.Text[testID1].Body)
+				assert.True(t, v.Text[testID2].DeletedAt.Valid)
+				assert.Len(t, outbox.Ops, 1)
+				assert.Empty(t, v.Conflicts)
+			case 3:
+				require.NoError(t, err)
+				assert.False(t, v.Text[testID2].DeletedAt.Valid)
+				assert.Empty(t, outbox.Ops)
+				assert.Empty(t, v.Conflicts)
+			case 4:
+				assert.ErrorIs(t, err, ErrUnknownKeep)
+				assert.Len(t, v.Conflicts, 1)
+			}
+		})
+	}
+}
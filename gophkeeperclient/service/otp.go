@@ -0,0 +1,182 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	OTPKindTOTP = "totp"
+	OTPKindHOTP = "hotp"
+
+	// defaults of the otpauth URI parameters.
+	otpDefaultAlgorithm = "SHA1"
+	otpDefaultDigits    = 6
+	otpDefaultPeriod    = 30
+)
+
+var (
+	ErrInvalidOTPURI       = errors.New("invalid otpauth URI")
+	ErrInvalidOTPSecret    = errors.New("invalid OTP secret, base32 expected")
+	ErrUnknownOTPKind      = errors.New("OTP kind must be totp or hotp")
+	ErrUnknownOTPAlgorithm = errors.New("OTP algorithm must be SHA1, SHA256 or SHA512")
+	ErrInvalidOTPDigits    = errors.New("OTP digits must be from 6 to 10")
+	ErrInvalidOTPPeriod    = errors.New("OTP period must be positive")
+)
+
+// NormalizeOTP sets the defaults of the empty OTP parameters, like the otpauth URI does, and checks them.
+// The secret is kept in the canonical form: upper case base32 without spaces and padding.
+func NormalizeOTP(o *models.OTP) error {
+	if o.Kind == `` {
+		o.Kind = OTPKindTOTP
+	}
+	o.Kind = strings.ToLower(o.Kind)
+	if o.Algorithm == `` {
+		o.Algorithm = otpDefaultAlgorithm
+	}
+	o.Algorithm = strings.ToUpper(o.Algorithm)
+	if o.Digits == 0 {
+		o.Digits = otpDefaultDigits
+	}
+	if o.Period == 0 && o.Kind == OTPKindTOTP {
+		o.Period = otpDefaultPeriod
+	}
+	o.Secret = strings.TrimRight(strings.ToUpper(strings.ReplaceAll(o.Secret, " ", ``)), "=")
+
+	switch {
+	case o.Kind != OTPKindTOTP && o.Kind != OTPKindHOTP:
+		return ErrUnknownOTPKind
+	case otpHash(o.Algorithm) == nil:
+		return ErrUnknownOTPAlgorithm
+	case o.Digits < 6 || o.Digits > 10:
+		return ErrInvalidOTPDigits
+	case o.Kind == OTPKindTOTP && o.Period <= 0:
+		return ErrInvalidOTPPeriod
+	}
+	if _, err := otpKey(o.Secret); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ParseOTPURI parses the otpauth URI, as exported by the authenticator apps and shown by the QR codes:
+// otpauth://totp/Issuer:account?secret=BASE32&issuer=Issuer&algorithm=SHA1&digits=6&period=30.
+// The label is the title of the returned OTP.
+func ParseOTPURI(uri string) (*models.OTP, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "otpauth" {
+		return nil, ErrInvalidOTPURI
+	}
+	q := u.Query()
+
+	o := &models.OTP{
+		Kind:      strings.ToLower(u.Host),
+		Secret:    q.Get("secret"),
+		Issuer:    q.Get("issuer"),
+		Algorithm: q.Get("algorithm"),
+		Title:     strings.TrimPrefix(u.Path, "/"),
+	}
+	if o.Secret == `` || o.Title == `` {
+		return nil, ErrInvalidOTPURI
+	}
+	// label is "issuer:account" or "account"
+	o.Account = o.Title
+	if issuer, account, ok := strings.Cut(o.Title, ":"); ok {
+		o.Account = strings.TrimSpace(account)
+		if o.Issuer == `` {
+			o.Issuer = issuer
+		}
+	}
+
+	for name, dest := range map[string]*int{"digits": &o.Digits, "period": &o.Period} {
+		if v := q.Get(name); v != `` {
+			if *dest, err = strconv.Atoi(v); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidOTPURI, name)
+			}
+		}
+	}
+	if v := q.Get("counter"); v != `` {
+		if o.Counter, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: counter", ErrInvalidOTPURI)
+		}
+	}
+
+	if err = NormalizeOTP(o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// OTPCode returns the current code of the OTP and the time it remains valid. TOTP code depends on the time,
+// HOTP code - on the counter: it is valid until used, remaining is 0.
+func OTPCode(o *models.OTP, now time.Time) (code string, remaining time.Duration, err error) {
+	n := *o
+	if err = NormalizeOTP(&n); err != nil {
+		return ``, 0, err
+	}
+
+	counter := n.Counter
+	if n.Kind == OTPKindTOTP {
+		period := int64(n.Period)
+		counter = uint64(now.Unix() / period)
+		remaining = time.Duration(period-now.Unix()%period) * time.Second
+	}
+
+	key, err := otpKey(n.Secret)
+	if err != nil {
+		return ``, 0, err
+	}
+	return otpValue(otpHash(n.Algorithm), key, counter, n.Digits), remaining, nil
+}
+
+// otpValue computes the HOTP value (RFC 4226) of the counter. TOTP (RFC 6238) passes the time step as the counter.
+func otpValue(h func() hash.Hash, key []byte, counter uint64, digits int) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+	mac := hmac.New(h, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := uint64(binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff)
+	mod := uint64(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
+
+// otpHash returns the hash function of the OTP algorithm. Nil, if the algorithm is unknown.
+func otpHash(algorithm string) func() hash.Hash {
+	switch algorithm {
+	case "SHA1":
+		return sha1.New
+	case "SHA256":
+		return sha256.New
+	case "SHA512":
+		return sha512.New
+	}
+	return nil
+}
+
+// otpKey decodes the canonical base32 secret.
+func otpKey(secret string) ([]byte, error) {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidOTPSecret
+	}
+	return key, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"github.com/EestiChameleon/gophkeeper/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// RFC 6238 test seeds: ASCII "1234567890" repeated to the hash size, base32 encoded.
+const (
+	testSecretSHA1   = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	testSecretSHA256 = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZA"
+	testSecretSHA512 = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNA"
+)
+
+// TestOTPCode verifies, that:
+// 1) TOTP codes match the RFC 6238 test vectors of every algorithm, the remaining time is returned
+// 2) HOTP code matches the RFC 4226 test vector, the secret case and spaces are ignored
+// 3) invalid secret, unknown algorithm and kind are rejected
+func TestOTPCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		number    uint8
+		otp       models.OTP
+		now       time.Time
+		code      string
+		remaining time.Duration
+		err       error
+	}{
+		{name: "Test #1: RFC 6238 SHA1", number: 1, now: time.Unix(59, 0), code: "94287082", remaining: time.Second,
+			otp: models.OTP{Secret: testSecretSHA1, Digits: 8}},
+		{name: "Test #2: RFC 6238 SHA256", number: 1, now: time.Unix(1111111109, 0), code: "68084774", remaining: 1 * time.Second,
+			otp: models.OTP{Secret: testSecretSHA256, Algorithm: "sha256", Digits: 8}},
+		{name: "Test #3: RFC 6238 SHA512", number: 1, now: time.Unix(20000000000, 0), code: "47863826", remaining: 10 * time.Second,
+			otp: models.OTP{Secret: testSecretSHA512, Algorithm: "SHA512", Digits: 8}},
+		{name: "Test #4: RFC 4226 HOTP, lower case secret with spaces", number: 1, code: "969429",
+			otp: models.OTP{Kind: "hotp", Secret: "gezd gnbv gy3t qojq gezd gnbv gy3t qojq", Counter: 3}},
+		{name: "Test #5: invalid secret", number: 2, otp: models.OTP{Secret: "not base32!"}, err: ErrInvalidOTPSecret},
+		{name: "Test #6: unknown algorithm", number: 2, otp: models.OTP{Secret: testSecretSHA1, Algorithm: "MD5"},
+			err: ErrUnknownOTPAlgorithm},
+		{name: "Test #7: unknown kind", number: 2, otp: models.OTP{Secret: testSecretSHA1, Kind: "motp"}, err: ErrUnknownOTPKind},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, remaining, err := OTPCode(&tt.otp, tt.now)
+			switch tt.number {
+			case 1:
+				require.NoError(t, err)
+				assert.Equal(t, tt.code, code)
+				assert.Equal(t, tt.remaining, remaining)
+			case 2:
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+// TestParseOTPURI verifies, that:
+// 1) missing parameters take the defaults, the issuer parameter is preferred to the label one
+// 2) URI of another scheme, without the secret or with invalid digits is rejected
+func TestParseOTPURI(t *testing.T) {
+	tests := []struct {
+		name   string
+		number uint8
+		uri    string
+		want   *models.OTP
+		err    error
+	}{
+		{name: "Test #1: defaults", number: 1, uri: "otpauth://totp/Example:alice@example.com?secret=" + testSecretSHA1,
+			want: &models.OTP{Title: "Example:alice@example.com", Kind: "totp", Secret: testSecretSHA1, Issuer: "Example",
+				Account: "alice@example.com", Algorithm: "SHA1", Digits: 6, Period: 30}},
+		{name: "Test #2: all parameters, issuer parameter is preferred", number: 1,
+			uri: "otpauth://hotp/ACME%20Co:bob?secret=" + testSecretSHA256 + "&issuer=ACME&algorithm=SHA256&digits=8&counter=5",
+			want: &models.OTP{Title: "ACME Co:bob", Kind: "hotp", Secret: testSecretSHA256, Issuer: "ACME", Account: "bob",
+				Algorithm: "SHA256", Digits: 8, Counter: 5}},
+		{name: "Test #3: another scheme", number: 2, uri: "https://totp/a?secret=" + testSecretSHA1, err: ErrInvalidOTPURI},
+		{name: "Test #4: no secret", number: 2, uri: "otpauth://totp/a", err: ErrInvalidOTPURI},
+		{name: "Test #5: invalid digits", number: 2, uri: "otpauth://totp/a?digits=x&secret=" + testSecretSHA1,
+			err: ErrInvalidOTPURI},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOTPURI(tt.uri)
+			switch tt.number {
+			case 1:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			case 2:
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
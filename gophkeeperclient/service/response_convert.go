@@ -1,68 +1,42 @@
 package service
 
 import (
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
 	"github.com/EestiChameleon/gophkeeper/models"
 	pb "github.com/EestiChameleon/gophkeeper/proto"
 )
 
-// VaultSyncConvert convert gRPC response proto data (slices) to local data format (map).
-func VaultSyncConvert(in *pb.SyncVaultResponse) *models.Vault {
-	return &models.Vault{
-		Pair: responsePairArrayToMap(in.Pairs),
-		Text: responseTextArrayToMap(in.Texts),
-		Bin:  responseBinArrayToMap(in.BinData),
-		Card: responseCardArrayToMap(in.Cards),
+// VaultSyncConvert convert gRPC response proto data (slices) to local data format (map by the item id).
+// Item payloads are opened with the user vault key.
+func VaultSyncConvert(in *pb.SyncVaultResponse, key models.Sealer) (*models.Vault, error) {
+	var items []*pb.Item
+	for _, p := range in.GetPairs() {
+		items = append(items, models.PairToItem(p))
 	}
-}
-
-// responsePairArrayToMap converts pair slice to pair map.
-func responsePairArrayToMap(pairs []*pb.Pair) map[string]*models.Pair {
-	result := make(map[string]*models.Pair)
-	if len(pairs) < 1 {
-		return result
-	}
-	for _, v := range pairs {
-		result[v.Title] = models.ProtoToModelsPair(v)
-	}
-
-	return result
-}
-
-// responseTextArrayToMap converts text slice to pair map.
-func responseTextArrayToMap(texts []*pb.Text) map[string]*models.Text {
-	result := make(map[string]*models.Text)
-	if len(texts) < 1 {
-		return result
-	}
-	for _, v := range texts {
-		result[v.Title] = models.ProtoToModelsText(v)
+	for _, t := range in.GetTexts() {
+		items = append(items, models.TextToItem(t))
 	}
-
-	return result
-}
-
-// responseBinArrayToMap converts bin slice to bin map.
-func responseBinArrayToMap(bins []*pb.Bin) map[string]*models.Bin {
-	result := make(map[string]*models.Bin)
-	if len(bins) < 1 {
-		return result
+	for _, b := range in.GetBinData() {
+		items = append(items, models.BinToItem(b))
 	}
-	for _, v := range bins {
-		result[v.Title] = models.ProtoToModelsBin(v)
+	for _, c := range in.GetCards() {
+		items = append(items, models.CardToItem(c))
 	}
+	items = append(items, in.GetItems()...)
 
-	return result
+	return responseItemsToVault(items, key)
 }
 
-// responseCardArrayToMap converts card slice to card map.
-func responseCardArrayToMap(cards []*pb.Card) map[string]*models.Card {
-	result := make(map[string]*models.Card)
-	if len(cards) < 1 {
-		return result
-	}
-	for _, v := range cards {
-		result[v.Title] = models.ProtoToModelsCard(v)
+// responseItemsToVault opens the items and puts them to a new vault by the item id.
+func responseItemsToVault(items []*pb.Item, key models.Sealer) (*models.Vault, error) {
+	v := clstor.MakeVault()
+	for _, in := range items {
+		it, err := models.OpenItem(in, key)
+		if err != nil {
+			return nil, err
+		}
+		v.PutItem(it)
 	}
 
-	return result
+	return v, nil
 }
@@ -1,16 +1,104 @@
 package service
 
 import (
+	"database/sql"
 	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
 	"github.com/EestiChameleon/gophkeeper/models"
 	pb "github.com/EestiChameleon/gophkeeper/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
+var (
+	testKey  = VaultKey([]byte("0123456789abcdef0123456789abcdef"))
+	otherKey = VaultKey([]byte("fedcba9876543210fedcba9876543210"))
+
+	testID1 = "00000000-0000-4000-8000-000000000001"
+	testID2 = "00000000-0000-4000-8000-000000000002"
+	testID3 = "00000000-0000-4000-8000-000000000003"
+	testID4 = "00000000-0000-4000-8000-000000000004"
+
+	fullData = &models.Vault{
+		Pair: map[string]*models.Pair{
+			testID1: {
+				ItemID:  testID1,
+				Title:   "p1",
+				Login:   "l1",
+				Pass:    "p1",
+				Comment: "c1",
+				Version: 1,
+			},
+		},
+		Text: map[string]*models.Text{
+			testID2: {
+				ItemID:  testID2,
+				Title:   "t1",
+				Body:    "b1",
+				Comment: "c1",
+				Version: 2,
+			},
+		},
+		Bin: map[string]*models.Bin{
+			testID3: {
+				ItemID:  testID3,
+				Title:   "b1",
+				Body:    []byte(`byte1`),
+				Comment: "c1",
+				Version: 3,
+			},
+		},
+		Card: map[string]*models.Card{
+			testID4: {
+				ItemID:         testID4,
+				Title:          "c1",
+				Number:         "1111 1111 1111 1111",
+				ExpirationDate: "2022/22",
+				Comment:        "c1",
+				Version:        4,
+			},
+		},
+	}
+)
+
+// sealVault converts local vault to the sync response, sealing all the payloads with the key.
+func sealVault(t *testing.T, key VaultKey, v *models.Vault) *pb.SyncVaultResponse {
+	out := new(pb.SyncVaultResponse)
+	for _, p := range v.Pair {
+		sp, err := models.ModelsToProtoPair(p, key)
+		require.NoError(t, err)
+		out.Pairs = append(out.Pairs, sp)
+	}
+	for _, tx := range v.Text {
+		st, err := models.ModelsToProtoText(tx, key)
+		require.NoError(t, err)
+		out.Texts = append(out.Texts, st)
+	}
+	for _, b := range v.Bin {
+		sb, err := models.ModelsToProtoBin(b, key)
+		require.NoError(t, err)
+		out.BinData = append(out.BinData, sb)
+	}
+	for _, c := range v.Card {
+		sc, err := models.ModelsToProtoCard(c, key)
+		require.NoError(t, err)
+		out.Cards = append(out.Cards, sc)
+	}
+
+	return out
+}
+
+// sealedPair seals the pair payload with the additional data as is.
+func sealedPair(t *testing.T, ad string) []byte {
+	sealed, err := testKey.Seal([]byte(`{"login":"l1","pass":"p1","comment":""}`), []byte(ad))
+	require.NoError(t, err)
+	return sealed
+}
+
 func TestVaultSyncConvert(t *testing.T) {
 	type want struct {
 		dataFinal *models.Vault
+		err       bool
 	}
 
 	tests := []struct {
@@ -32,87 +120,96 @@ func TestVaultSyncConvert(t *testing.T) {
 			},
 		},
 		{
-			name: "Test #2: full incoming data",
+			name:         "Test #2: full incoming data",
+			incomingData: sealVault(t, testKey, fullData),
+			want: want{
+				dataFinal: fullData,
+			},
+		},
+		{
+			name:         "Test #3: data sealed with another key",
+			incomingData: sealVault(t, otherKey, fullData),
+			want: want{
+				err: true,
+			},
+		},
+		{
+			name: "Test #4: payload moved to another item",
+			incomingData: func() *pb.SyncVaultResponse {
+				r := sealVault(t, testKey, fullData)
+				r.Pairs[0].Id = testID2
+				return r
+			}(),
+			want: want{
+				err: true,
+			},
+		},
+		{
+			name: "Test #5: tombstone without payload",
 			incomingData: &pb.SyncVaultResponse{
-				Pairs: []*pb.Pair{
-					{
-						Title:   "p1",
-						Login:   "l1",
-						Pass:    "p1",
-						Comment: "c1",
-						Version: 1,
-					},
-				},
-				Texts: []*pb.Text{
-					{
-						Title:   "t1",
-						Body:    "b1",
-						Comment: "c1",
-						Version: 2,
-					},
-				},
-				BinData: []*pb.Bin{
-					{
-						Title:   "b1",
-						Body:    []byte(`byte1`),
-						Comment: "c1",
-						Version: 3,
+				Pairs:  []*pb.Pair{{Id: testID1, Title: "p1", Version: 2, Deleted: true}},
+				Status: "success",
+			},
+			want: want{
+				dataFinal: &models.Vault{
+					Pair: map[string]*models.Pair{
+						testID1: {
+							ItemID:    testID1,
+							Title:     "p1",
+							Version:   2,
+							DeletedAt: sql.NullTime{Valid: true},
+						},
 					},
 				},
-				Cards: []*pb.Card{
-					{
-						Title:   "c1",
-						Number:  "1111 1111 1111 1111",
-						Expdate: "2022/22",
-						Comment: "c1",
-						Version: 4,
+			},
+		},
+		{
+			name: "Test #6: renamed item",
+			incomingData: &pb.SyncVaultResponse{
+				Pairs: []*pb.Pair{{Id: testID1, Title: "p2", Version: 2, Payload: sealedPair(t, "pair/"+testID1)}},
+			},
+			want: want{
+				dataFinal: &models.Vault{
+					Pair: map[string]*models.Pair{
+						testID1: {ItemID: testID1, Title: "p2", Login: "l1", Pass: "p1", Version: 2},
 					},
 				},
-				Status: "",
+			},
+		},
+		{
+			name: "Test #7: item, saved before the item ids",
+			incomingData: &pb.SyncVaultResponse{
+				Pairs: []*pb.Pair{{Id: models.LegacyItemID("pair", "p1"), Title: "p1", Version: 1,
+					Payload: sealedPair(t, "pair:p1")}},
 			},
 			want: want{
 				dataFinal: &models.Vault{
 					Pair: map[string]*models.Pair{
-						"p1": {
-							Title:   "p1",
-							Login:   "l1",
-							Pass:    "p1",
-							Comment: "c1",
-							Version: 1,
-						},
-					},
-					Text: map[string]*models.Text{
-						"t1": {
-							Title:   "t1",
-							Body:    "b1",
-							Comment: "c1",
-							Version: 2,
-						},
-					},
-					Bin: map[string]*models.Bin{
-						"b1": {
-							Title:   "b1",
-							Body:    []byte(`byte1`),
-							Comment: "c1",
-							Version: 3,
-						},
-					},
-					Card: map[string]*models.Card{
-						"c1": {
-							Title:          "c1",
-							Number:         "1111 1111 1111 1111",
-							ExpirationDate: "2022/22",
-							Comment:        "c1",
-							Version:        4,
-						},
+						models.LegacyItemID("pair", "p1"): {ItemID: models.LegacyItemID("pair", "p1"), Title: "p1",
+							Login: "l1", Pass: "p1", Version: 1},
 					},
 				},
 			},
 		},
+		{
+			name: "Test #8: title bound payload of the item with a random id",
+			incomingData: &pb.SyncVaultResponse{
+				Pairs: []*pb.Pair{{Id: testID1, Title: "p1", Version: 1, Payload: sealedPair(t, "pair:p1")}},
+			},
+			want: want{
+				err: true,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := VaultSyncConvert(tt.incomingData)
+			result, err := VaultSyncConvert(tt.incomingData, testKey)
+			if tt.want.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.want.dataFinal.Pair), len(result.Pair))
 			for k, v := range result.Pair {
 				assert.Equal(t, tt.want.dataFinal.Pair[k], v)
 			}
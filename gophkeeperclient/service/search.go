@@ -0,0 +1,135 @@
+package service
+
+import (
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"sort"
+)
+
+// SearchHit is the item, found by SearchItems.
+type SearchHit struct {
+	models.ItemHead
+	// the best matching field: title, login, url, comment, issuer, account or the custom field name.
+	Field string `json:"field"`
+	// the item is found on the server only, or the server has a newer version: the vault is not synchronized.
+	Remote bool `json:"remote,omitempty"`
+	score  int
+}
+
+// searchField is the item field, the query is matched with. Only the short fields are matched fuzzy:
+// any long text has the query letters somewhere.
+type searchField struct {
+	name  string
+	text  string
+	fuzzy bool
+}
+
+// searchFields returns the fields of the item to search in. Secrets (passwords, card numbers, hidden custom
+// field values) are not searched.
+func searchFields(it models.Item) []searchField {
+	fields := []searchField{{name: "title", text: it.Head().Title, fuzzy: true}}
+	var (
+		comment string
+		custom  []models.Field
+	)
+	switch item := it.(type) {
+	case *models.Pair:
+		fields = append(fields, searchField{name: "login", text: item.Login, fuzzy: true})
+		for _, u := range item.URLs {
+			fields = append(fields, searchField{name: "url", text: u})
+		}
+		comment, custom = item.Comment, item.Fields
+	case *models.Text:
+		comment, custom = item.Comment, item.Fields
+	case *models.Bin:
+		comment, custom = item.Comment, item.Fields
+	case *models.Card:
+		comment, custom = item.Comment, item.Fields
+	case *models.OTP:
+		fields = append(fields, searchField{name: "issuer", text: item.Issuer, fuzzy: true},
+			searchField{name: "account", text: item.Account, fuzzy: true})
+		comment, custom = item.Comment, item.Fields
+	}
+	fields = append(fields, searchField{name: "comment", text: comment})
+	for _, f := range custom {
+		fields = append(fields, searchField{name: f.Name, text: f.Name, fuzzy: true})
+		if f.Type != FieldHidden {
+			fields = append(fields, searchField{name: f.Name, text: f.Value})
+		}
+	}
+	return fields
+}
+
+// matchItem matches the item fields with the query, see models.Match. Returns the best matching field
+// and its score. Title wins the ties.
+func matchItem(it models.Item, query string, fuzzy bool) (field string, score int, ok bool) {
+	for _, f := range searchFields(it) {
+		if f.text == `` {
+			continue
+		}
+		if s, matched := models.Match(query, f.text, fuzzy && f.fuzzy); matched && s > score {
+			field, score, ok = f.name, s, true
+		}
+	}
+	return field, score, ok
+}
+
+// SearchItems returns the live vault items of the type, all types if it is empty, with the tag, that match
+// the query: titles, logins, URLs, comments and custom fields are searched, the case is ignored. Fuzzy search
+// finds the titles, logins and custom field names, that have the query letters with others in between.
+// The best matches go first.
+func SearchItems(v *models.Vault, query, dataType, tag string, fuzzy bool) ([]SearchHit, error) {
+	heads, err := ListItems(v, dataType, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	var hits []SearchHit
+	for _, h := range heads {
+		it, _ := v.Item(h.Type, h.ItemID)
+		if field, score, ok := matchItem(it, query, fuzzy); ok {
+			hits = append(hits, SearchHit{ItemHead: h, Field: field, score: score})
+		}
+	}
+	sortHits(hits)
+	return hits, nil
+}
+
+// AddRemoteHits adds the items, found on the server by SearchItems RPC, to the local search hits. The item
+// is added, if the vault has no such item or has an older version of it: the local hit is replaced. The items
+// are opened with the key and matched like SearchItems does. The vault is not changed.
+func AddRemoteHits(v *models.Vault, hits []SearchHit, remote []*pb.Item, query, tag string, fuzzy bool,
+	key models.Sealer) ([]SearchHit, error) {
+	for _, in := range remote {
+		if local, ok := v.Item(in.GetType(), in.GetId()); ok && local.Head().Version >= in.GetVersion() {
+			continue
+		}
+		it, err := models.OpenItem(in, key)
+		if err != nil {
+			return nil, err
+		}
+		h := it.Head()
+		field, score, ok := matchItem(it, query, fuzzy)
+		if h.Deleted || !HasTag(h, tag) || !ok {
+			continue
+		}
+
+		hit := SearchHit{ItemHead: h, Field: field, Remote: true, score: score}
+		replaced := false
+		for i := range hits {
+			if hits[i].Type == h.Type && hits[i].ItemID == h.ItemID {
+				hits[i], replaced = hit, true
+			}
+		}
+		if !replaced {
+			hits = append(hits, hit)
+		}
+	}
+	sortHits(hits)
+	return hits, nil
+}
+
+// sortHits sorts the hits by score, the best first. The order of the equal ones is kept.
+func sortHits(hits []SearchHit) {
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+}
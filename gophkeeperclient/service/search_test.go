@@ -0,0 +1,106 @@
+package service
+
+import (
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// searchVault returns itemsVault with the searched data: the pair has the title, login and custom fields,
+// the first text - the comment, the second one - the tag.
+func searchVault() *models.Vault {
+	v := itemsVault()
+	v.Pair[testID1].Title = "AWS root"
+	v.Pair[testID1].Login = "admin@example.com"
+	v.Pair[testID1].Fields = []models.Field{
+		{Name: "pin", Type: FieldHidden, Value: "4321"},
+		{Name: "question", Type: FieldText, Value: "First pet"},
+	}
+	v.Text[testID3].Comment = "aws keys rotation"
+	v.Text[testID4].Tags = []string{"work"}
+	return v
+}
+
+// TestSearchItems verifies, that:
+// 1) title, login, comment and custom field values are searched, the matched field is reported
+// 2) hidden values are not searched
+// 3) fuzzy match is made only with fuzzy, items are filtered by tag
+// 4) unknown type is rejected
+func TestSearchItems(t *testing.T) {
+	tests := []struct {
+		name     string
+		number   uint8
+		query    string
+		dataType string
+		tag      string
+		fuzzy    bool
+		want     []string
+		fields   []string
+	}{
+		{name: "Test #1: title and comment", number: 1, query: "AWS", want: []string{testID1, testID3},
+			fields: []string{"title", "comment"}},
+		{name: "Test #2: custom field value", number: 1, query: "pet", want: []string{testID1}, fields: []string{"question"}},
+		{name: "Test #3: hidden value is not searched", number: 1, query: "4321"},
+		{name: "Test #4: login", number: 1, query: "admin", want: []string{testID1}, fields: []string{"login"}},
+		{name: "Test #5: fuzzy title", number: 1, query: "awr", fuzzy: true, want: []string{testID1},
+			fields: []string{"title"}},
+		{name: "Test #6: no fuzzy match without fuzzy", number: 1, query: "awr"},
+		{name: "Test #7: tag", number: 1, tag: "work", want: []string{testID4}, fields: []string{"title"}},
+		{name: "Test #8: unknown type", number: 2, dataType: "note"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := SearchItems(searchVault(), tt.query, tt.dataType, tt.tag, tt.fuzzy)
+			switch tt.number {
+			case 1:
+				require.NoError(t, err)
+				var ids, fields []string
+				for _, h := range hits {
+					ids, fields = append(ids, h.ItemID), append(fields, h.Field)
+				}
+				assert.Equal(t, tt.want, ids)
+				assert.Equal(t, tt.fields, fields)
+			case 2:
+				assert.ErrorIs(t, err, models.ErrUnknownItemType)
+			}
+		})
+	}
+}
+
+// TestAddRemoteHits verifies, that:
+// 1) remote items of the local version are skipped, newer versions replace the local hits
+// 2) remote items, not synced yet, are added without being put to the vault
+func TestAddRemoteHits(t *testing.T) {
+	v := searchVault()
+	hits, err := SearchItems(v, "aws", "", "", false)
+	require.NoError(t, err)
+
+	newID, err := models.NewItemID()
+	require.NoError(t, err)
+	var remote []*pb.Item
+	for _, it := range []models.Item{
+		&models.Pair{ItemID: testID1, Title: "AWS root", Version: 1},           // local version - skipped
+		&models.Text{ItemID: testID3, Title: "aws notes", Version: 2},          // newer version - replaces the local one
+		&models.Card{ItemID: newID, Title: "AWS billing", Version: 1},          // not synced yet - added
+		&models.Text{ItemID: testID4, Title: "t1", Comment: "aws", Version: 2}, // comments are matched too
+	} {
+		sealed, err := models.SealItem(it, testKey)
+		require.NoError(t, err)
+		remote = append(remote, sealed)
+	}
+
+	hits, err = AddRemoteHits(v, hits, remote, "aws", "", false, testKey)
+	require.NoError(t, err)
+	require.Len(t, hits, 4)
+	assert.Equal(t, testID1, hits[0].ItemID)
+	assert.False(t, hits[0].Remote)
+	assert.Equal(t, testID3, hits[1].ItemID)
+	assert.True(t, hits[1].Remote)
+	assert.Equal(t, "title", hits[1].Field)
+	assert.Equal(t, newID, hits[2].ItemID)
+	assert.Equal(t, testID4, hits[3].ItemID)
+	assert.Equal(t, "comment", hits[3].Field)
+	assert.Len(t, v.Card, 0)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var ErrSecretSources = errors.New("please pass only one of --from-stdin and --from-file")
+
+// Secret is the secret, omitted on the command line.
+type Secret struct {
+	Prompt  string
+	Confirm bool // new secret: it is prompted twice.
+	Value   *string
+}
+
+// SecretSource is where the omitted secrets are read from: stdin, the file or the no-echo prompt.
+type SecretSource struct {
+	FromStdin bool
+	FromFile  string
+	Stdin     io.Reader
+	// Prompt reads the secret without echo. The new secret is read twice.
+	Prompt func(prompt string, confirm bool) (string, error)
+}
+
+// ReadSecrets reads the secrets from stdin with FromStdin, from the file with FromFile, otherwise they are prompted.
+func ReadSecrets(src SecretSource, secrets []Secret) error {
+	if len(secrets) == 0 {
+		return nil
+	}
+
+	switch {
+	case src.FromStdin && src.FromFile != ``:
+		return ErrSecretSources
+	case src.FromStdin:
+		return readSecrets(src.Stdin, secrets)
+	case src.FromFile != ``:
+		f, err := os.Open(src.FromFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return readSecrets(f, secrets)
+	}
+
+	for _, s := range secrets {
+		value, err := src.Prompt(s.Prompt, s.Confirm)
+		if err != nil {
+			return err
+		}
+		*s.Value = value
+	}
+	return nil
+}
+
+// readSecrets reads the secrets from r. The only secret is the whole input, so it could be multiline.
+// Several secrets are read one per line. The trailing line break is not a part of the secret.
+func readSecrets(r io.Reader, secrets []Secret) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	input := strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+	if len(secrets) == 1 {
+		*secrets[0].Value = input
+		return nil
+	}
+
+	lines := strings.Split(input, "\n")
+	if len(lines) < len(secrets) {
+		return fmt.Errorf("%d secrets expected, one per line, %d found", len(secrets), len(lines))
+	}
+	for i, s := range secrets {
+		*s.Value = strings.TrimSuffix(lines[i], "\r")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestReadSecrets verifies, that:
+// 1) the only secret is the whole stdin or file, several secrets are read one per line, trailing line breaks are trimmed
+// 2) secrets are prompted in order, only if neither stdin nor file is chosen
+// 3) too few lines, stdin and file together, unknown file and prompt errors are rejected
+func TestReadSecrets(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secrets")
+	require.NoError(t, os.WriteFile(file, []byte("fromFile1\r\nfromFile2\r\n"), 0600))
+	errPrompt := errors.New("no terminal")
+
+	tests := []struct {
+		name    string
+		number  uint8
+		src     SecretSource
+		secrets int
+		want    []string
+		prompts []string
+		err     error
+	}{
+		{name: "Test #1: the only secret is the whole input, the trailing line break is trimmed", number: 1,
+			src: SecretSource{FromStdin: true, Stdin: strings.NewReader("line1\nline2\n")}, secrets: 1,
+			want: []string{"line1\nline2"}},
+		{name: "Test #2: the trailing CRLF is trimmed", number: 1,
+			src: SecretSource{FromStdin: true, Stdin: strings.NewReader("p4ss\r\n")}, secrets: 1, want: []string{"p4ss"}},
+		{name: "Test #3: several secrets - one per line", number: 1,
+			src: SecretSource{FromStdin: true, Stdin: strings.NewReader("one\r\ntwo\nthree")}, secrets: 3,
+			want: []string{"one", "two", "three"}},
+		{name: "Test #4: too few lines", number: 2,
+			src: SecretSource{FromStdin: true, Stdin: strings.NewReader("one\n")}, secrets: 2},
+		{name: "Test #5: stdin is read instead of the prompt", number: 1,
+			src: SecretSource{FromStdin: true, Stdin: strings.NewReader("fromStdin")}, secrets: 1,
+			want: []string{"fromStdin"}},
+		{name: "Test #6: file is read instead of the prompt", number: 1,
+			src: SecretSource{FromFile: file}, secrets: 2, want: []string{"fromFile1", "fromFile2"}},
+		{name: "Test #7: stdin and file together", number: 2,
+			src: SecretSource{FromStdin: true, FromFile: file, Stdin: strings.NewReader("fromStdin")}, secrets: 1,
+			err: ErrSecretSources},
+		{name: "Test #8: unknown file", number: 2,
+			src: SecretSource{FromFile: filepath.Join(t.TempDir(), "none")}, secrets: 1, err: os.ErrNotExist},
+		{name: "Test #9: prompted without stdin and file, in order", number: 1, secrets: 2,
+			want: []string{"Secret 1", "Secret 2"}, prompts: []string{"Secret 1", "Secret 2"}},
+		{name: "Test #10: prompt error", number: 2, src: SecretSource{Prompt: func(string, bool) (string, error) {
+			return ``, errPrompt
+		}}, secrets: 1, err: errPrompt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var prompts []string
+			if tt.src.Prompt == nil {
+				tt.src.Prompt = func(prompt string, confirm bool) (string, error) {
+					prompts = append(prompts, prompt)
+					return prompt, nil
+				}
+			}
+			values := make([]string, tt.secrets)
+			secrets := make([]Secret, tt.secrets)
+			for i := range secrets {
+				secrets[i] = Secret{Prompt: "Secret " + string(rune('1'+i)), Value: &values[i]}
+			}
+
+			err := ReadSecrets(tt.src, secrets)
+			switch tt.number {
+			case 1:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, values)
+				assert.Equal(t, tt.prompts, prompts)
+			case 2:
+				assert.Error(t, err)
+				if tt.err != nil {
+					assert.ErrorIs(t, err, tt.err)
+				}
+				assert.Empty(t, prompts)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"time"
+)
+
+var (
+	ErrReplayFailed = errors.New("some queued changes were not saved on the server")
+)
+
+// OutboxSyncRequest builds the sync request with the vault cursor and the queued operations.
+// Server returns the results grouped by the item type (pairs, texts, bins, cards, items) in the request order,
+// the returned operations are ordered the same way, so results[i] belongs to ops[i].
+func OutboxSyncRequest(cursor int64, queue []*clstor.Operation) (*pb.SyncVaultRequest, []*clstor.Operation) {
+	req := &pb.SyncVaultRequest{Cursor: cursor}
+	groups := make(map[string][]*clstor.Operation)
+
+	for _, op := range queue {
+		it := &pb.Item{Type: op.Type, Id: op.ItemID, Title: op.Title, Version: op.Version, Payload: op.Payload,
+			Deleted: op.Deleted, Streamed: op.Streamed}
+		switch op.Type {
+		case "pair":
+			req.Pairs = append(req.Pairs, models.ItemToPair(it))
+		case "text":
+			req.Texts = append(req.Texts, models.ItemToText(it))
+		case "bin":
+			req.BinData = append(req.BinData, models.ItemToBin(it))
+		case "card":
+			req.Cards = append(req.Cards, models.ItemToCard(it))
+		default:
+			// types without the typed message
+			req.Items = append(req.Items, it)
+		}
+		groups[op.Type] = append(groups[op.Type], op)
+	}
+
+	var ops []*clstor.Operation
+	for _, dataType := range models.ItemTypes {
+		ops = append(ops, groups[dataType]...)
+	}
+	return req, ops
+}
+
+// SyncReport is the result of SyncVault.
+type SyncReport struct {
+	Results []*pb.SyncItemResult // results of the replayed operations.
+	Merges  []*MergeResult       // conflicting local changes handling.
+	Sealed  int                  // plaintext items, sealed and sent as the next version, see SealLegacyItems.
+	SealErr error                // the plaintext items are sealed with the next sync, if it fails.
+}
+
+// SyncVault replays the user outbox and pulls the changes made after the local vault cursor in one request.
+// Accepted, conflicted and rejected operations leave the outbox, failed ones stay in it (in order) for the next replay.
+// If the request fails, the outbox is postponed with backoff. Conflicting local changes are merged with the remote
+// ones, see mergeConflict. Until the plaintext items, kept by the server, are sealed, they are sealed and sent first.
+// Returns the combined vault with the new cursor and the report.
+func SyncVault(ctx context.Context, c pb.KeeperClient, local *models.Vault, outbox *clstor.Outbox, key models.Sealer) (*models.Vault, *SyncReport, error) {
+	if local == nil {
+		local = clstor.MakeVault()
+	}
+
+	var (
+		sealed  int
+		sealErr error
+	)
+	if !local.LegacySealed {
+		sealed, sealErr = SealLegacyItems(ctx, c, local, outbox, key)
+		local.LegacySealed = sealErr == nil
+	}
+
+	req, ops := OutboxSyncRequest(local.Cursor, outbox.Ops)
+	response, err := c.SyncVault(ctx, req)
+	if err != nil {
+		outbox.Failed(err, time.Now())
+		return nil, nil, err
+	}
+
+	// operations without the acknowledgement are kept. Conflicting ones are grouped by the item:
+	// the first one has the base of the local changes, the last one - the local state.
+	report := &SyncReport{Results: response.GetResults(), Sealed: sealed, SealErr: sealErr}
+	keep := make(map[*clstor.Operation]bool)
+	type chain struct{ first, last *clstor.Operation }
+	var conflicts []*chain
+	chains := make(map[string]*chain)
+	for i, op := range ops {
+		if i >= len(report.Results) || report.Results[i].GetStatus() == pb.SyncItemStatus_SYNC_FAILED {
+			keep[op] = true
+			continue
+		}
+		if report.Results[i].GetStatus() != pb.SyncItemStatus_SYNC_CONFLICT {
+			continue
+		}
+		id := op.Type + "/" + op.ItemID
+		if ch, ok := chains[id]; ok {
+			ch.last = op
+			continue
+		}
+		chains[id] = &chain{first: op, last: op}
+		conflicts = append(conflicts, chains[id])
+	}
+	var left []*clstor.Operation
+	for _, op := range outbox.Ops {
+		if keep[op] {
+			left = append(left, op)
+		}
+	}
+	var replayErr error
+	if len(left) > 0 {
+		replayErr = ErrReplayFailed
+	}
+	outbox.Replayed(left, replayErr, time.Now())
+	ApplySyncResults(local, report.Results)
+
+	serverVault, err := VaultSyncConvert(response, key)
+	if err != nil {
+		return nil, report, err
+	}
+
+	combined := CombineVault(local, serverVault)
+	combined.Cursor = response.GetCursor()
+
+	for _, ch := range conflicts {
+		// remote version was changed after the local one was made, so it is in the pulled changes.
+		// It could be missing only if it was pulled earlier, then the full synchronization brings it again.
+		remote, err := getSealed(serverVault, ch.last.Type, ch.last.ItemID, key)
+		if err != nil {
+			return nil, report, err
+		}
+
+		merge, err := mergeConflict(combined, outbox, ch.first, ch.last, remote, key, time.Now())
+		if err != nil {
+			return nil, report, err
+		}
+		report.Merges = append(report.Merges, merge)
+	}
+
+	return combined, report, nil
+}
+
+// ApplySyncResults updates the local tombstones, accepted by the server: the server tombstone version is the actual one.
+func ApplySyncResults(v *models.Vault, results []*pb.SyncItemResult) {
+	for _, r := range results {
+		if r.GetStatus() != pb.SyncItemStatus_SYNC_ACCEPTED {
+			continue
+		}
+
+		it, ok := v.Item(r.GetType(), r.GetId())
+		if !ok || !it.Head().Deleted {
+			continue
+		}
+		if tombstone, err := models.NewTombstone(r.GetType(), r.GetId(), it.Head().Title, r.GetVersion()); err == nil {
+			v.PutItem(tombstone)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"io"
+	"testing"
+)
+
+// syncClient imitates the server SyncVault and LegacyItems handlers. Other methods are not implemented.
+type syncClient struct {
+	pb.KeeperClient
+	request  *pb.SyncVaultRequest
+	response *pb.SyncVaultResponse
+	err      error
+	legacy   []*pb.LegacyItem
+}
+
+func (c *syncClient) SyncVault(ctx context.Context, in *pb.SyncVaultRequest, opts ...grpc.CallOption) (*pb.SyncVaultResponse, error) {
+	c.request = in
+	return c.response, c.err
+}
+
+func (c *syncClient) LegacyItems(ctx context.Context, in *pb.LegacyItemsRequest, opts ...grpc.CallOption) (pb.Keeper_LegacyItemsClient, error) {
+	return &legacyStream{items: c.legacy}, nil
+}
+
+// legacyStream sends the plaintext items one by one.
+type legacyStream struct {
+	grpc.ClientStream
+	items []*pb.LegacyItem
+}
+
+func (s *legacyStream) Recv() (*pb.LegacyItemsResponse, error) {
+	if len(s.items) == 0 {
+		return nil, io.EOF
+	}
+	it := s.items[0]
+	s.items = s.items[1:]
+	return &pb.LegacyItemsResponse{Item: it}, nil
+}
+
+// TestOutboxSyncRequest verifies, that:
+// 1) queued operations are sent by type in the queue order, types without the typed message - in the envelope
+// 2) operations are returned in the order of the server results
+func TestOutboxSyncRequest(t *testing.T) {
+	queue := []*clstor.Operation{
+		{Type: "text", ItemID: testID1, Title: "t1", Version: 1, Payload: []byte("sealed")},
+		{Type: "pair", ItemID: testID2, Title: "p1", Version: 2, Payload: []byte("sealed")},
+		{Type: "text", ItemID: testID1, Title: "t1", Version: 2, Payload: []byte("sealed")},
+		{Type: "otp", ItemID: testID4, Title: "o1", Version: 1, Payload: []byte("sealed")},
+		{Type: "card", ItemID: testID3, Title: "c1", Version: 4, Deleted: true},
+	}
+
+	req, ops := OutboxSyncRequest(5, queue)
+	assert.Equal(t, int64(5), req.GetCursor())
+	require.Len(t, req.GetPairs(), 1)
+	require.Len(t, req.GetTexts(), 2)
+	require.Len(t, req.GetCards(), 1)
+	assert.Empty(t, req.GetBinData())
+	// operations of one type keep the queue order
+	assert.Equal(t, uint32(1), req.GetTexts()[0].GetVersion())
+	assert.Equal(t, uint32(2), req.GetTexts()[1].GetVersion())
+	assert.True(t, req.GetCards()[0].GetDeleted())
+	assert.Equal(t, testID1, req.GetTexts()[0].GetId())
+	// types without the typed message are sent in the envelope
+	require.Len(t, req.GetItems(), 1)
+	assert.Equal(t, "otp", req.GetItems()[0].GetType())
+	assert.Equal(t, testID4, req.GetItems()[0].GetId())
+	// operations are ordered as the server results
+	assert.Equal(t, []*clstor.Operation{queue[1], queue[0], queue[2], queue[4], queue[3]}, ops)
+}
+
+// TestSyncVault verifies, that:
+// 1) unavailable server keeps the queue and postpones the next attempt
+// 2) accepted operations are dropped from the queue, failed ones are kept and conflicts are merged
+// 3) server data and the cursor are taken, accepted tombstone takes the server version
+func TestSyncVault(t *testing.T) {
+	saved := &clstor.Operation{Type: "pair", ItemID: testID1, Title: "p1", Version: 2, Payload: []byte("sealed")}
+	outdated := &clstor.Operation{Type: "text", ItemID: testID2, Title: "t1", Version: 1,
+		Payload: sealText(t, testID2, 1, "local", "")}
+	failed := &clstor.Operation{Type: "text", ItemID: testID3, Title: "t2", Version: 1, Payload: []byte("sealed")}
+	deleted := &clstor.Operation{Type: "card", ItemID: testID4, Title: "c1", Version: 2, Deleted: true}
+
+	tests := []struct {
+		name   string
+		number uint8
+		client *syncClient
+	}{
+		{
+			name:   "Test #1: server unavailable",
+			number: 1,
+			client: &syncClient{err: errors.New("unavailable")},
+		},
+		{
+			name:   "Test #2: replayed operations are acknowledged, failed are kept",
+			number: 2,
+			client: &syncClient{response: &pb.SyncVaultResponse{
+				Status: "success",
+				Cursor: 9,
+				Texts:  []*pb.Text{{Id: testID2, Title: "t1", Version: 3, Payload: sealText(t, testID2, 3, "remote", "")}},
+				Results: []*pb.SyncItemResult{
+					{Type: "pair", Id: testID1, Title: "p1", Version: 2, Status: pb.SyncItemStatus_SYNC_ACCEPTED},
+					{Type: "text", Id: testID2, Title: "t1", Version: 3, Status: pb.SyncItemStatus_SYNC_CONFLICT},
+					{Type: "text", Id: testID3, Title: "t2", Version: 1, Status: pb.SyncItemStatus_SYNC_FAILED},
+					{Type: "card", Id: testID4, Title: "c1", Version: 5, Status: pb.SyncItemStatus_SYNC_ACCEPTED},
+				},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local := &models.Vault{
+				Pair:   map[string]*models.Pair{testID1: {ItemID: testID1, Title: "p1", Login: "l1", Version: 2}},
+				Text:   map[string]*models.Text{},
+				Bin:    map[string]*models.Bin{},
+				Card:   map[string]*models.Card{testID4: {ItemID: testID4, Title: "c1", Version: 2, DeletedAt: sql.NullTime{Valid: true}}},
+				Cursor: 4,
+			}
+			outbox := &clstor.Outbox{Ops: []*clstor.Operation{saved, outdated, failed, deleted}}
+
+			vault, report, err := SyncVault(context.Background(), tt.client, local, outbox, testKey)
+			assert.Equal(t, int64(4), tt.client.request.GetCursor())
+			switch tt.number {
+			case 1:
+				assert.Error(t, err)
+				assert.Len(t, outbox.Ops, 4)
+				assert.Equal(t, 1, outbox.Attempts)
+				assert.False(t, outbox.NextAttempt.IsZero())
+			case 2:
+				require.NoError(t, err)
+				assert.Len(t, report.Results, 4)
+				// failed operation is kept, the conflict copy of the outdated one is queued after it
+				require.Len(t, outbox.Ops, 2)
+				assert.Equal(t, failed, outbox.Ops[0])
+				require.Len(t, report.Merges, 1)
+				require.NotNil(t, report.Merges[0].Conflict)
+				assert.Equal(t, report.Merges[0].Conflict.CopyTitle, outbox.Ops[1].Title)
+				assert.Equal(t, "remote", vault.Text[testID2].Body)
+				assert.Equal(t, 1, outbox.Attempts)
+				assert.Equal(t, int64(9), vault.Cursor)
+				// no plaintext items on the server.
+				assert.True(t, vault.LegacySealed)
+				// accepted tombstone takes the server version
+				assert.Equal(t, uint32(5), vault.Card[testID4].Version)
+				assert.Equal(t, "l1", vault.Pair[testID1].Login)
+			}
+		})
+	}
+}
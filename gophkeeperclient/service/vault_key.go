@@ -0,0 +1,142 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"strings"
+)
+
+var (
+	ErrWrongMasterPassword = errors.New("wrong master password")
+	ErrMalformedKDF        = errors.New("malformed vault kdf parameters")
+	ErrMalformedCiphertext = errors.New("malformed ciphertext")
+)
+
+const (
+	kdfTime      = 3
+	kdfMemoryKiB = 64 * 1024
+	kdfThreads   = 4
+	kdfSaltLen   = 16
+
+	// limits of the KDF parameters, received from the server: the key derivation must not exhaust the device.
+	kdfMaxTime      = 16
+	kdfMaxMemoryKiB = 1024 * 1024
+	kdfMaxThreads   = 64
+
+	// kdfCheckText is sealed with the derived key and stored on the server together with the KDF parameters.
+	// Opening it proves the master password is correct, before any vault item is touched.
+	kdfCheckText = "gophkeeper vault key check"
+	kdfCheckAD   = "check"
+)
+
+// VaultKey is the symmetric key derived from the master password. It implements models.Sealer
+// with XChaCha20-Poly1305. Sealed format: <24 bytes random nonce><ciphertext with tag>.
+type VaultKey []byte
+
+// Seal encrypts and authenticates plaintext.
+func (k VaultKey) Seal(plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(k)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Open decrypts ciphertext and verifies it together with additionalData.
+func (k VaultKey) Open(ciphertext, additionalData []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(k)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrMalformedCiphertext
+	}
+
+	return aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], additionalData)
+}
+
+// NewVaultKDF generates new KDF parameters with a random salt, derives the vault key from the master password
+// and returns both. The KDF is saved on the server, so any device can derive the same key.
+func NewVaultKDF(master string) (*pb.VaultKDF, VaultKey, error) {
+	salt := make([]byte, kdfSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, err
+	}
+
+	params := fmt.Sprintf("argon2id$v=%d$m=%d,t=%d,p=%d$%s",
+		argon2.Version, kdfMemoryKiB, kdfTime, kdfThreads, base64.RawStdEncoding.EncodeToString(salt))
+
+	key, err := deriveKey(master, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	check, err := key.Seal([]byte(kdfCheckText), []byte(kdfCheckAD))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &pb.VaultKDF{Params: params, Check: check}, key, nil
+}
+
+// DeriveVaultKey derives the vault key from the master password with the KDF received from the server.
+// Returns ErrWrongMasterPassword, if the key can't open the KDF check.
+func DeriveVaultKey(master string, kdf *pb.VaultKDF) (VaultKey, error) {
+	key, err := deriveKey(master, kdf.GetParams())
+	if err != nil {
+		return nil, err
+	}
+
+	check, err := key.Open(kdf.GetCheck(), []byte(kdfCheckAD))
+	if err != nil || string(check) != kdfCheckText {
+		return nil, ErrWrongMasterPassword
+	}
+
+	return key, nil
+}
+
+// deriveKey parses the KDF parameters and derives the key. The parameters out of the limits are rejected.
+// Format: argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt b64>.
+func deriveKey(master, params string) (VaultKey, error) {
+	parts := strings.Split(params, "$")
+	if len(parts) != 4 || parts[0] != "argon2id" {
+		return nil, ErrMalformedKDF
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[1], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrMalformedKDF
+	}
+
+	var (
+		memory, time uint32
+		threads      uint8
+	)
+	if _, err := fmt.Sscanf(parts[2], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return nil, ErrMalformedKDF
+	}
+	// argon2 needs 8 KiB of memory per thread at least.
+	if time == 0 || time > kdfMaxTime || threads == 0 || threads > kdfMaxThreads ||
+		memory < 8*uint32(threads) || memory > kdfMaxMemoryKiB {
+		return nil, ErrMalformedKDF
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(salt) == 0 {
+		return nil, ErrMalformedKDF
+	}
+
+	return argon2.IDKey([]byte(master), salt, time, memory, threads, chacha20poly1305.KeySize), nil
+}
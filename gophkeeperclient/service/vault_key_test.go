@@ -0,0 +1,97 @@
+package service
+
+import (
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestDeriveVaultKey verifies, that:
+// 1) the key derived on another device with the same master password is equal
+// 2) wrong master password is rejected
+// 3) malformed kdf parameters and the parameters out of the limits are rejected
+func TestDeriveVaultKey(t *testing.T) {
+	kdf, key, err := NewVaultKDF("master7")
+	require.NoError(t, err)
+
+	type want struct {
+		key VaultKey
+		err error
+	}
+
+	tests := []struct {
+		name   string
+		master string
+		kdf    *pb.VaultKDF
+		want   want
+	}{
+		{
+			name:   "Test #1: correct master password",
+			master: "master7",
+			kdf:    kdf,
+			want:   want{key: key},
+		},
+		{
+			name:   "Test #2: wrong master password",
+			master: "master1",
+			kdf:    kdf,
+			want:   want{err: ErrWrongMasterPassword},
+		},
+		{
+			name:   "Test #3: malformed kdf",
+			master: "master7",
+			kdf:    &pb.VaultKDF{Params: "argon2id$v=19$m=abc", Check: kdf.Check},
+			want:   want{err: ErrMalformedKDF},
+		},
+		{
+			name:   "Test #4: zero threads",
+			master: "master7",
+			kdf:    &pb.VaultKDF{Params: "argon2id$v=19$m=65536,t=3,p=0$c2FsdHNhbHRzYWx0c2FsdA", Check: kdf.Check},
+			want:   want{err: ErrMalformedKDF},
+		},
+		{
+			name:   "Test #5: zero time",
+			master: "master7",
+			kdf:    &pb.VaultKDF{Params: "argon2id$v=19$m=65536,t=0,p=4$c2FsdHNhbHRzYWx0c2FsdA", Check: kdf.Check},
+			want:   want{err: ErrMalformedKDF},
+		},
+		{
+			name:   "Test #6: memory over the limit",
+			master: "master7",
+			kdf:    &pb.VaultKDF{Params: "argon2id$v=19$m=4194304,t=3,p=4$c2FsdHNhbHRzYWx0c2FsdA", Check: kdf.Check},
+			want:   want{err: ErrMalformedKDF},
+		},
+		{
+			name:   "Test #7: time over the limit",
+			master: "master7",
+			kdf:    &pb.VaultKDF{Params: "argon2id$v=19$m=65536,t=1000,p=4$c2FsdHNhbHRzYWx0c2FsdA", Check: kdf.Check},
+			want:   want{err: ErrMalformedKDF},
+		},
+	}
+	for _, tt := range tests {
+		k, err := DeriveVaultKey(tt.master, tt.kdf)
+		assert.ErrorIs(t, err, tt.want.err, tt.name)
+		assert.Equal(t, tt.want.key, k, tt.name)
+	}
+}
+
+// TestVaultKeySeal verifies, that sealed data is opened only with the same key and additional data.
+func TestVaultKeySeal(t *testing.T) {
+	sealed, err := testKey.Seal([]byte("secret"), []byte("pair:p1"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), "secret")
+
+	plain, err := testKey.Open(sealed, []byte("pair:p1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", string(plain))
+
+	_, err = testKey.Open(sealed, []byte("pair:p2"))
+	assert.Error(t, err)
+
+	_, err = otherKey.Open(sealed, []byte("pair:p1"))
+	assert.Error(t, err)
+
+	_, err = testKey.Open([]byte("short"), nil)
+	assert.ErrorIs(t, err, ErrMalformedCiphertext)
+}
@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/cfg"
+	"golang.org/x/crypto/chacha20poly1305"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// fileMagic starts the encrypted storage file. Files without it are the plaintext JSON of the old versions:
+	// they are read as is and encrypted on the next write.
+	fileMagic = "GKLS1\n"
+	fileAD    = "gophkeeper local storage"
+	keyLen    = chacha20poly1305.KeySize
+)
+
+var (
+	ErrMalformedFile = errors.New("local storage file is malformed or encrypted with another key")
+	ErrMalformedKey  = errors.New("local storage key file is malformed")
+
+	// fileKey is the OS user secret, that encrypts the local storage files. Read by InitStorage.
+	fileKey []byte
+)
+
+// initFileKey reads the local storage key. A new random key is generated on the first run.
+// The key file is readable only by the OS user, so the storage files copied elsewhere can't be opened.
+func initFileKey() error {
+	key, err := os.ReadFile(cfg.Current.KeyFile)
+	if os.IsNotExist(err) {
+		key = make([]byte, keyLen)
+		if _, err = rand.Read(key); err != nil {
+			return err
+		}
+		if err = os.MkdirAll(filepath.Dir(cfg.Current.KeyFile), 0700); err != nil {
+			return err
+		}
+		if err = writeFileAtomic(cfg.Current.KeyFile, key); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if len(key) != keyLen {
+		return ErrMalformedKey
+	}
+
+	fileKey = key
+	return nil
+}
+
+// sealFile encrypts the file content with the local storage key.
+func sealFile(plain []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(fileKey)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, len(fileMagic)+aead.NonceSize(), len(fileMagic)+aead.NonceSize()+len(plain)+aead.Overhead())
+	copy(out, fileMagic)
+	nonce := out[len(fileMagic):]
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(out, nonce, plain, []byte(fileAD)), nil
+}
+
+// openFile decrypts the file content. Plaintext content of the old versions is returned as is.
+func openFile(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(fileMagic)) {
+		return data, nil
+	}
+	data = data[len(fileMagic):]
+
+	aead, err := chacha20poly1305.NewX(fileKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrMalformedFile
+	}
+
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(fileAD))
+	if err != nil {
+		return nil, ErrMalformedFile
+	}
+	return plain, nil
+}
+
+// readFile reads the storage file and parses it to dest. Missing or empty file leaves dest as is.
+func readFile(path string, dest interface{}) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	plain, err := openFile(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(plain, dest)
+}
+
+// writeFile encrypts the data and rewrites the storage file with it.
+func writeFile(path string, data interface{}) error {
+	plain, err := json.Marshal(data)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	sealed, err := sealFile(plain)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, sealed)
+}
+
+// writeFileAtomic replaces the file with the data: the data is written to a temporary file in the same directory,
+// synced and renamed over the target. The file is left either old or new, whenever the process stops.
+// Only the OS user can read the file.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	f, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	// after the successful rename the temporary file is gone - remove fails quietly.
+	defer os.Remove(f.Name())
+
+	if err = f.Chmod(0600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+
+	return syncDir(dir)
+}
+
+// syncDir flushes the directory entry, so the rename survives a crash.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	// some platforms (windows) don't support the directory sync. The rename itself is done.
+	if err = d.Sync(); err != nil && !errors.Is(err, os.ErrInvalid) {
+		log.Println(`[WARNING]: storage directory sync:`, err)
+	}
+	return nil
+}
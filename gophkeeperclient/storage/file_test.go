@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/cfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestStorageFile verifies, that:
+// 1) file is written encrypted, owner-only and without temporary files left, and is read back
+// 2) plaintext file of the old version is read
+// 3) file, sealed with another key, is rejected; missing file is read as empty
+func TestStorageFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		number uint8
+	}{
+		{name: "Test #1: file is encrypted and read back", number: 1},
+		{name: "Test #2: plaintext file of the old version is read", number: 2},
+		{name: "Test #3: file sealed with another key", number: 3},
+		{name: "Test #4: missing file", number: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileKey = make([]byte, keyLen)
+			path := filepath.Join(t.TempDir(), "users")
+			written := map[string]*UserAuth{"user": {JWT: "secret-jwt"}}
+			read := make(map[string]*UserAuth)
+
+			switch tt.number {
+			case 1:
+				require.NoError(t, writeFile(path, written))
+				data, err := os.ReadFile(path)
+				require.NoError(t, err)
+				assert.NotContains(t, string(data), "secret-jwt")
+				info, err := os.Stat(path)
+				require.NoError(t, err)
+				assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+				// no temporary files are left
+				entries, err := os.ReadDir(filepath.Dir(path))
+				require.NoError(t, err)
+				assert.Len(t, entries, 1)
+
+				require.NoError(t, readFile(path, &read))
+				assert.Equal(t, written, read)
+			case 2:
+				require.NoError(t, os.WriteFile(path, []byte(`{"user":{"jwt":"secret-jwt"}}`), 0600))
+				require.NoError(t, readFile(path, &read))
+				assert.Equal(t, written, read)
+			case 3:
+				require.NoError(t, writeFile(path, written))
+				fileKey = []byte("0123456789abcdef0123456789abcdef")
+				assert.ErrorIs(t, readFile(path, &read), ErrMalformedFile)
+			case 4:
+				require.NoError(t, readFile(path, &read))
+				assert.Empty(t, read)
+			}
+		})
+	}
+}
+
+// TestLockStorage verifies, that the storage lock is not taken by another process, until it is released.
+func TestLockStorage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vault.lock")
+	require.NoError(t, lockStorage(path))
+	defer Unlock()
+
+	// another process opens the lock file on its own
+	other, err := os.OpenFile(path, os.O_RDWR, 0600)
+	require.NoError(t, err)
+	defer other.Close()
+
+	ok, err := tryLockFile(other)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, Unlock())
+	ok, err = tryLockFile(other)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, unlockFile(other))
+}
+
+// TestSaveAuth verifies, that:
+// 1) users are saved as is, when the storage is locked
+// 2) without the lock, the tokens are saved to the actual file: the other users and the KDF parameters are kept
+func TestSaveAuth(t *testing.T) {
+	dir := t.TempDir()
+	cfg.Current = &cfg.Config{UsersFile: filepath.Join(dir, "users"), VaultFile: filepath.Join(dir, "vault")}
+	defer func() { cfg.Current = nil }()
+	fileKey = make([]byte, keyLen)
+
+	tests := []struct {
+		name   string
+		number uint8
+	}{
+		{name: "Test #1: the storage is locked - the users are saved", number: 1},
+		{name: "Test #2: the storage is released - the tokens are saved to the actual file", number: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Users = map[string]*UserAuth{"user": {JWT: "jwt2", RefreshToken: "refresh2", ExpiresAt: 2}}
+			require.NoError(t, writeFile(cfg.Current.UsersFile, map[string]*UserAuth{
+				"user":  {JWT: "jwt1", RefreshToken: "refresh1", ExpiresAt: 1, KDFParams: "kdf"},
+				"other": {JWT: "other"},
+			}))
+			read := make(map[string]*UserAuth)
+
+			switch tt.number {
+			case 1:
+				require.NoError(t, lockStorage(cfg.Current.VaultFile+".lock"))
+				defer Unlock()
+				require.NoError(t, SaveAuth("user", Users["user"]))
+				require.NoError(t, readFile(cfg.Current.UsersFile, &read))
+				assert.Equal(t, Users, read)
+			case 2:
+				require.NoError(t, SaveAuth("user", Users["user"]))
+				assert.Nil(t, lockFile)
+				require.NoError(t, readFile(cfg.Current.UsersFile, &read))
+				assert.Equal(t, map[string]*UserAuth{
+					"user":  {JWT: "jwt2", RefreshToken: "refresh2", ExpiresAt: 2, KDFParams: "kdf"},
+					"other": {JWT: "other"},
+				}, read)
+			}
+		})
+	}
+}
@@ -4,16 +4,43 @@ import (
 	"encoding/json"
 	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/cfg"
 	"github.com/EestiChameleon/gophkeeper/models"
-	"io/ioutil"
-	"log"
 	"os"
+	"path/filepath"
+	"time"
 )
 
 var (
-	Users = make(map[string]string) //UserLocalName: JWT from server. UserLocalName is obtained via os/user -> user.Current()
-	Local map[string]*models.Vault  // UserLocalName :vault
+	Users = make(map[string]*UserAuth) //UserLocalName: session tokens from server. UserLocalName is obtained via os/user -> user.Current()
+	Local map[string]*models.Vault     // UserLocalName :vault
 )
 
+// UserAuth keeps the server session tokens of the local user.
+type UserAuth struct {
+	JWT          string `json:"jwt"`           // access token.
+	RefreshToken string `json:"refresh_token"` // used to obtain a new access token, when it expires.
+	ExpiresAt    int64  `json:"expires_at"`    // access token expiration, unix seconds.
+	VaultKey     []byte `json:"vault_key"`     // key derived from the master password. Seals the items sent to the server.
+	// vault key derivation parameters and check value, as kept by the server. The locked vault is unlocked
+	// with the master password without the server. Empty for the sessions, started before they were kept.
+	KDFParams string `json:"kdf_params,omitempty"`
+	KDFCheck  []byte `json:"kdf_check,omitempty"`
+}
+
+// UnmarshalJSON parses user auth data. Supports the old users file format, where only JWT was saved.
+func (a *UserAuth) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &a.JWT)
+	}
+
+	type plain UserAuth
+	return json.Unmarshal(data, (*plain)(a))
+}
+
+// Expired reports if the access token lifetime has passed (with a small gap for the request time).
+func (a *UserAuth) Expired() bool {
+	return a.ExpiresAt != 0 && time.Now().Add(5*time.Second).Unix() >= a.ExpiresAt
+}
+
 // to think about?
 type LocalStorer interface {
 	Save(string, []byte)           // storageName string, dataJSON []byte => Save("pair", [01010101])
@@ -29,68 +56,101 @@ type ClientData struct {
 }
 
 // InitStorage function initializes the storage data (check files & parse to local memory).
+// The storage is locked until Unlock: the files are read here and rewritten by UpdateFiles.
 func InitStorage() (err error) {
-	if err = initUsers(); err != nil {
-		return err
+	// storage files could be configured to any location - create the missing directories.
+	for _, path := range []string{cfg.Current.UsersFile, cfg.Current.VaultFile, cfg.Current.OutboxFile} {
+		if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return err
+		}
 	}
 
-	if err = initLocal(); err != nil {
+	if err = lockStorage(cfg.Current.VaultFile + ".lock"); err != nil {
 		return err
 	}
 
-	return nil
-}
+	if err = initFileKey(); err != nil {
+		return err
+	}
 
-// initUsers reads or creates the local user auth info file. Then parse the content to local memory.
-func initUsers() error {
-	// create/open file
-	fu, err := os.OpenFile(cfg.UsersFileStoragePath, os.O_RDONLY|os.O_CREATE, 0777)
-	if err != nil {
-		log.Println(err)
+	if err = initUsers(); err != nil {
 		return err
 	}
-	defer fu.Close()
 
-	// read file
-	ubytes, err := os.ReadFile(cfg.UsersFileStoragePath)
-	if err != nil {
-		log.Println(err)
+	if err = initLocal(); err != nil {
 		return err
 	}
 
-	// parse file data
-	if len(ubytes) != 0 {
-		return json.Unmarshal(ubytes, &Users)
+	if err = initOutbox(); err != nil {
+		return err
 	}
 
 	return nil
 }
 
-// initLocal reads or creates the local users data storage file. Then parse the content to local memory.
+// initUsers reads the local user auth info file, if exists. Then parse the content to local memory.
+func initUsers() error {
+	Users = make(map[string]*UserAuth)
+	return readFile(cfg.Current.UsersFile, &Users)
+}
+
+// initLocal reads the local users data storage file, if exists. Then parse the content to local memory.
 func initLocal() error {
-	// second - open vault file
 	Local = make(map[string]*models.Vault)
-
-	// create/open file
-	fv, err := os.OpenFile(cfg.VaultFileStoragePath, os.O_RDONLY|os.O_CREATE, 0777)
-	if err != nil {
-		log.Println(err)
+	if err := readFile(cfg.Current.VaultFile, &Local); err != nil {
 		return err
 	}
-	defer fv.Close()
 
-	// read the whole file at once
-	vbytes, err := ioutil.ReadFile(cfg.VaultFileStoragePath)
-	if err != nil {
-		panic(err)
+	for _, v := range Local {
+		rekeyVault(v)
 	}
+	return nil
+}
 
-	// parse file data
-	if len(vbytes) != 0 {
-		return json.Unmarshal(vbytes, &Local)
+// rekeyVault moves the items, saved before the item ids, from the title keys to the ids derived from the title.
+// The server derives the same ids for such items, see models.LegacyItemID.
+func rekeyVault(v *models.Vault) {
+	for title, p := range v.Pair {
+		if p.ItemID == `` {
+			p.ItemID = models.LegacyItemID("pair", title)
+			delete(v.Pair, title)
+			v.Pair[p.ItemID] = p
+		}
+	}
+	for title, t := range v.Text {
+		if t.ItemID == `` {
+			t.ItemID = models.LegacyItemID("text", title)
+			delete(v.Text, title)
+			v.Text[t.ItemID] = t
+		}
+	}
+	for title, b := range v.Bin {
+		if b.ItemID == `` {
+			b.ItemID = models.LegacyItemID("bin", title)
+			if b.Streamed {
+				// streamed body is sealed with the title.
+				b.StreamTitle = title
+			}
+			delete(v.Bin, title)
+			v.Bin[b.ItemID] = b
+		}
+	}
+	for title, c := range v.Card {
+		if c.ItemID == `` {
+			c.ItemID = models.LegacyItemID("card", title)
+			delete(v.Card, title)
+			v.Card[c.ItemID] = c
+		}
 	}
 
-	return nil
+	for _, c := range v.Conflicts {
+		if c.ItemID == `` {
+			c.ItemID = models.LegacyItemID(c.Type, c.Title)
+			if c.CopyTitle != `` {
+				c.CopyID = models.LegacyItemID(c.Type, c.CopyTitle)
+			}
+		}
+	}
 }
 
 // MakeVault initializes a new instance of Vault.
@@ -100,36 +160,47 @@ func MakeVault() *models.Vault {
 		Text: make(map[string]*models.Text),
 		Bin:  make(map[string]*models.Bin),
 		Card: make(map[string]*models.Card),
+		OTP:  make(map[string]*models.OTP),
 	}
 }
 
-// UpdateFiles rewrites local files with actual data.
-func UpdateFiles() error {
-	// prepare users data
-	usersJSONByte, err := json.Marshal(Users)
-	if err != nil {
-		log.Println(err)
+// SaveAuth saves the session tokens of the user to the users file at once: the rotated refresh token is not
+// accepted by the server anymore, the new one must survive the command failure. The process, that has released
+// the storage (the agent), takes the lock for the write and updates the tokens in the actual file.
+func SaveAuth(userName string, auth *UserAuth) error {
+	if lockFile != nil {
+		return writeFile(cfg.Current.UsersFile, Users)
+	}
+
+	if err := lockStorage(cfg.Current.VaultFile + ".lock"); err != nil {
 		return err
 	}
-	if err = UpdateFile(cfg.UsersFileStoragePath, usersJSONByte); err != nil {
+	defer Unlock()
+	users := make(map[string]*UserAuth)
+	if err := readFile(cfg.Current.UsersFile, &users); err != nil {
 		return err
 	}
+	saved, ok := users[userName]
+	if !ok {
+		return nil
+	}
+	saved.JWT, saved.RefreshToken, saved.ExpiresAt = auth.JWT, auth.RefreshToken, auth.ExpiresAt
+	return writeFile(cfg.Current.UsersFile, users)
+}
 
-	// prepare vault data
-	vaultJSONByte, err := json.Marshal(Local)
-	if err != nil {
-		log.Println(err)
+// UpdateFiles rewrites local files with actual data. Files are encrypted with the local storage key
+// and replaced atomically.
+func UpdateFiles() error {
+	// users data
+	if err := writeFile(cfg.Current.UsersFile, Users); err != nil {
 		return err
 	}
 
-	if err = UpdateFile(cfg.VaultFileStoragePath, vaultJSONByte); err != nil {
+	// vault data
+	if err := writeFile(cfg.Current.VaultFile, Local); err != nil {
 		return err
 	}
 
-	return nil
-}
-
-// UpdateFile method rewrite the file with the passed data.
-func UpdateFile(path string, data []byte) error {
-	return ioutil.WriteFile(path, data, 0644)
+	// outbox data
+	return writeFile(cfg.Current.OutboxFile, Outboxes)
 }
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+const (
+	lockWait  = 10 * time.Second
+	lockRetry = 100 * time.Millisecond
+)
+
+var (
+	ErrStorageLocked = errors.New("local storage is used by another gophkeeperclient process")
+
+	// lockFile is the held storage lock. Nil, if not locked.
+	lockFile *os.File
+)
+
+// lockStorage takes the exclusive lock of the local storage. It is held until Unlock, so the concurrent
+// clients read and rewrite the storage files one after another. Waits for the other client up to lockWait.
+func lockStorage(path string) error {
+	if lockFile != nil {
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(lockWait)
+	for {
+		ok, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		if ok {
+			lockFile = f
+			return nil
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return ErrStorageLocked
+		}
+		time.Sleep(lockRetry)
+	}
+}
+
+// Unlock releases the local storage lock, taken by InitStorage.
+func Unlock() error {
+	if lockFile == nil {
+		return nil
+	}
+
+	err := unlockFile(lockFile)
+	if cerr := lockFile.Close(); err == nil {
+		err = cerr
+	}
+	lockFile = nil
+
+	return err
+}
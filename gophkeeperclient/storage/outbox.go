@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"github.com/EestiChameleon/gophkeeper/gophkeeperclient/cfg"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"time"
+)
+
+const (
+	outboxBackoffBase = 5 * time.Second
+	outboxBackoffMax  = 10 * time.Minute
+)
+
+// Outboxes keeps the local changes, not acknowledged by the server yet. UserLocalName: outbox.
+var Outboxes map[string]*Outbox
+
+// Operation is a queued local change. Payload is sealed with the user vault key, so the outbox file
+// keeps the item data as protected, as the server does.
+type Operation struct {
+	Type      string    `json:"type"` // pair, text, bin, card or otp.
+	ItemID    string    `json:"item_id"`
+	Title     string    `json:"title"`
+	Version   uint32    `json:"version"`
+	Deleted   bool      `json:"deleted"`            // delete operation, the item version is a tombstone.
+	Payload   []byte    `json:"payload"`            // sealed item payload. Empty for delete operations.
+	Streamed  bool      `json:"streamed,omitempty"` // bin only: the version keeps the streamed body of the previous one.
+	CreatedAt time.Time `json:"created_at"`
+
+	// the item version the change was made from, its title and sealed payload. Used to merge the concurrent changes.
+	BaseVersion uint32 `json:"base_version"`
+	BaseTitle   string `json:"base_title,omitempty"`
+	Base        []byte `json:"base"` // empty for a new item or a deleted base.
+}
+
+// Outbox is the ordered queue of the user local changes. Operations are replayed in order. After a failed replay
+// the automatic replays are postponed with exponential backoff, an explicit sync ignores it.
+type Outbox struct {
+	Ops         []*Operation `json:"ops"`
+	Attempts    int          `json:"attempts"`     // failed replays in a row.
+	NextAttempt time.Time    `json:"next_attempt"` // automatic replay is not started before this moment.
+	LastError   string       `json:"last_error"`
+}
+
+// UserOutbox returns the outbox of the local user. Created, if not found.
+func UserOutbox(userName string) *Outbox {
+	o, ok := Outboxes[userName]
+	if !ok {
+		o = new(Outbox)
+		Outboxes[userName] = o
+	}
+	return o
+}
+
+// Add appends the operation to the end of the queue.
+func (o *Outbox) Add(op *Operation) {
+	o.Ops = append(o.Ops, op)
+}
+
+// Due reports if there are queued operations and the backoff has passed.
+func (o *Outbox) Due(now time.Time) bool {
+	return len(o.Ops) > 0 && !now.Before(o.NextAttempt)
+}
+
+// Failed records the failed replay and postpones the next automatic one.
+func (o *Outbox) Failed(err error, now time.Time) {
+	o.Attempts++
+	o.LastError = err.Error()
+
+	backoff := outboxBackoffMax
+	if o.Attempts <= 10 {
+		backoff = outboxBackoffBase << (o.Attempts - 1)
+	}
+	if backoff > outboxBackoffMax {
+		backoff = outboxBackoffMax
+	}
+	o.NextAttempt = now.Add(backoff)
+}
+
+// Replayed replaces the queue with the operations, that are left after the replay. Backoff is reset,
+// unless some operations failed on the server side and have to be retried.
+func (o *Outbox) Replayed(left []*Operation, err error, now time.Time) {
+	o.Ops = left
+	if err != nil {
+		o.Failed(err, now)
+		return
+	}
+
+	o.Attempts = 0
+	o.NextAttempt = time.Time{}
+	o.LastError = ``
+}
+
+// initOutbox reads the local outbox file, if exists. Operations, queued before the item ids, get the ids
+// derived from the title, like the items of the local vault.
+func initOutbox() error {
+	Outboxes = make(map[string]*Outbox)
+	if err := readFile(cfg.Current.OutboxFile, &Outboxes); err != nil {
+		return err
+	}
+
+	for _, o := range Outboxes {
+		for _, op := range o.Ops {
+			if op.ItemID == `` {
+				op.ItemID = models.LegacyItemID(op.Type, op.Title)
+				if len(op.Base) > 0 {
+					op.BaseTitle = op.Title
+				}
+			}
+		}
+	}
+	return nil
+}
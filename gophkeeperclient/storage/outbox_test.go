@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// TestOutbox verifies, that:
+// 1) empty outbox is never due
+// 2) failed replays are postponed with the exponential backoff, limited by outboxBackoffMax
+// 3) successful replay drops the replayed operations and resets the backoff
+func TestOutbox(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	errTest := errors.New("unavailable")
+
+	tests := []struct {
+		name   string
+		number uint8
+	}{
+		{name: "Test #1: empty outbox is never due", number: 1},
+		{name: "Test #2: failed replays are postponed with exponential backoff", number: 2},
+		{name: "Test #3: backoff is limited", number: 3},
+		{name: "Test #4: successful replay resets the backoff", number: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := new(Outbox)
+			switch tt.number {
+			case 1:
+				assert.False(t, o.Due(now))
+			case 2:
+				o.Add(&Operation{Type: "pair", Title: "p1", Version: 1})
+				assert.True(t, o.Due(now))
+				o.Failed(errTest, now)
+				assert.Equal(t, now.Add(outboxBackoffBase), o.NextAttempt)
+				assert.False(t, o.Due(now))
+				o.Failed(errTest, now)
+				assert.Equal(t, now.Add(2*outboxBackoffBase), o.NextAttempt)
+				assert.Equal(t, 2, o.Attempts)
+				assert.Equal(t, errTest.Error(), o.LastError)
+				assert.True(t, o.Due(o.NextAttempt))
+			case 3:
+				o.Add(&Operation{Type: "pair", Title: "p1", Version: 1})
+				for i := 0; i < 100; i++ {
+					o.Failed(errTest, now)
+				}
+				assert.Equal(t, now.Add(outboxBackoffMax), o.NextAttempt)
+			case 4:
+				first := &Operation{Type: "pair", Title: "p1", Version: 1}
+				second := &Operation{Type: "text", Title: "t1", Version: 1}
+				o.Add(first)
+				o.Add(second)
+				o.Failed(errTest, now)
+				o.Replayed([]*Operation{second}, nil, now)
+				assert.Equal(t, []*Operation{second}, o.Ops)
+				assert.Equal(t, 0, o.Attempts)
+				assert.True(t, o.Due(now))
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"fmt"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"strings"
+	"time"
+)
+
+// mask replaces the secrets in the detail pane, until they are revealed.
+const mask = "••••••••"
+
+// secret returns the value, masked unless revealed.
+func secret(value string, reveal bool) string {
+	if reveal || value == `` {
+		return value
+	}
+	return mask
+}
+
+// renderDetail renders the item for the detail pane. Passwords, card numbers, OTP secrets and codes and hidden
+// custom fields are masked, unless revealed.
+func renderDetail(it models.Item, reveal bool, now time.Time) string {
+	h := it.Head()
+	var b strings.Builder
+	line := func(label, value string) {
+		if value != `` {
+			fmt.Fprintf(&b, "%-9s %s\n", label+":", value)
+		}
+	}
+
+	b.WriteString(styleTitle.Render(h.Title) + "\n\n")
+	line("Type", h.Type)
+	line("ID", h.ItemID)
+	line("Version", fmt.Sprint(h.Version))
+
+	var (
+		comment string
+		fields  []models.Field
+	)
+	switch item := it.(type) {
+	case *models.Pair:
+		line("Login", item.Login)
+		line("Password", secret(item.Pass, reveal))
+		for _, u := range item.URLs {
+			line("URL", u)
+		}
+		comment, fields = item.Comment, item.Fields
+	case *models.Text:
+		if strings.Contains(item.Body, "\n") {
+			b.WriteString("Body:\n" + item.Body + "\n")
+		} else {
+			line("Body", item.Body)
+		}
+		comment, fields = item.Comment, item.Fields
+	case *models.Bin:
+		size := int64(len(item.Body))
+		if item.Streamed {
+			size = item.Size
+		}
+		line("Size", fmt.Sprintf("%d bytes", size))
+		line("Data", "saved to the file with getBinary")
+		comment, fields = item.Comment, item.Fields
+	case *models.Card:
+		line("Number", secret(item.Number, reveal))
+		line("Expires", item.ExpirationDate)
+		comment, fields = item.Comment, item.Fields
+	case *models.OTP:
+		line("Issuer", item.Issuer)
+		line("Account", item.Account)
+		line("Secret", secret(item.Secret, reveal))
+		line("Code", otpCode(item, reveal, now))
+		comment, fields = item.Comment, item.Fields
+	}
+	line("Comment", comment)
+	for _, f := range fields {
+		value := f.Value
+		if f.Type == clserv.FieldHidden {
+			value = secret(value, reveal)
+		}
+		fmt.Fprintf(&b, "%s (%s): %s\n", f.Name, f.Type, value)
+	}
+	if len(h.Tags) > 0 {
+		line("Tags", strings.Join(h.Tags, ", "))
+	}
+	return b.String()
+}
+
+// otpCode returns the current TOTP code with the seconds it remains valid. HOTP code is not shown:
+// the counter must be advanced, when the code is used, getOTP does it.
+func otpCode(o *models.OTP, reveal bool, now time.Time) string {
+	if o.Kind == clserv.OTPKindHOTP {
+		return "HOTP, use getOTP to advance the counter"
+	}
+	code, remaining, err := clserv.OTPCode(o, now)
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("%s (%ds)", secret(code, reveal), int(remaining.Seconds()))
+}
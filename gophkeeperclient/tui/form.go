@@ -0,0 +1,325 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	errEmptyTitle  = errors.New("title is required")
+	errNewBin      = errors.New("binary data is added with saveBinary")
+	errStreamedBin = errors.New("streamed binary data is edited with saveBinary")
+)
+
+// form field labels. Custom fields are edited as the list: name=value or name:type=value, separated by "; ".
+const (
+	labelTitle   = "Title"
+	labelLogin   = "Login"
+	labelPass    = "Password"
+	labelURLs    = "URLs"
+	labelBody    = "Body"
+	labelNumber  = "Number"
+	labelExpires = "Expires"
+	labelSecret  = "Secret"
+	labelIssuer  = "Issuer"
+	labelAccount = "Account"
+	labelComment = "Comment"
+	labelFields  = "Fields"
+	labelTags    = "Tags"
+)
+
+// formField is the form input. Secret input is masked, until revealed.
+type formField struct {
+	label  string
+	input  textinput.Model
+	secret bool
+}
+
+// form edits the item of the type: the new one, if base is nil. Saved item is the next version of the base,
+// so the fields, the form doesn't show (OTP parameters, bin body), are kept.
+type form struct {
+	dataType string
+	base     models.Item
+	fields   []formField
+	focused  int
+	reveal   bool
+}
+
+// newForm returns the form, filled with the item fields.
+func newForm(dataType string, base models.Item) *form {
+	f := &form{dataType: dataType, base: base}
+	var (
+		title, comment string
+		fields         []models.Field
+		tags           []string
+	)
+	if base != nil {
+		h := base.Head()
+		title, tags = h.Title, h.Tags
+	}
+
+	f.add(labelTitle, title, false)
+	switch item := base.(type) {
+	case *models.Pair:
+		f.add(labelLogin, item.Login, false)
+		f.add(labelPass, item.Pass, true)
+		f.add(labelURLs, strings.Join(item.URLs, ", "), false)
+		comment, fields = item.Comment, item.Fields
+	case *models.Text:
+		// the input is a single line: the multiline body is kept as is, it is edited with saveText.
+		if !strings.Contains(item.Body, "\n") {
+			f.add(labelBody, item.Body, false)
+		}
+		comment, fields = item.Comment, item.Fields
+	case *models.Bin:
+		comment, fields = item.Comment, item.Fields
+	case *models.Card:
+		f.add(labelNumber, item.Number, true)
+		f.add(labelExpires, item.ExpirationDate, false)
+		comment, fields = item.Comment, item.Fields
+	case *models.OTP:
+		f.add(labelSecret, item.Secret, true)
+		f.add(labelIssuer, item.Issuer, false)
+		f.add(labelAccount, item.Account, false)
+		comment, fields = item.Comment, item.Fields
+	case nil:
+		// new item: the type fields are empty.
+		switch dataType {
+		case "pair":
+			f.add(labelLogin, ``, false)
+			f.add(labelPass, ``, true)
+			f.add(labelURLs, ``, false)
+		case "text":
+			f.add(labelBody, ``, false)
+		case "card":
+			f.add(labelNumber, ``, true)
+			f.add(labelExpires, ``, false)
+		case "otp":
+			f.add(labelSecret, ``, true)
+			f.add(labelIssuer, ``, false)
+			f.add(labelAccount, ``, false)
+		}
+	}
+	f.add(labelComment, comment, false)
+	f.add(labelFields, formatFields(fields), false)
+	f.add(labelTags, strings.Join(tags, ", "), false)
+
+	f.fields[0].input.Focus()
+	return f
+}
+
+func (f *form) add(label, value string, secret bool) {
+	in := textinput.New()
+	in.Prompt = ``
+	in.CharLimit = 0
+	in.PlaceholderStyle = styleMuted
+	in.SetValue(value)
+	// the cursor is shown by SetValue: it is hidden, until the input is focused.
+	in.Blur()
+	switch label {
+	case labelURLs, labelTags:
+		in.Placeholder = "comma separated"
+	case labelFields:
+		in.Placeholder = "name=value; name:hidden=value"
+	case labelExpires:
+		in.Placeholder = "MM/YY"
+	}
+	if secret {
+		in.EchoMode = textinput.EchoPassword
+	}
+	f.fields = append(f.fields, formField{label: label, input: in, secret: secret})
+}
+
+// value returns the trimmed value of the field with the label.
+func (f *form) value(label string) string {
+	v, _ := f.rawValue(label)
+	return strings.TrimSpace(v)
+}
+
+// focus moves the focus to the next (delta 1) or previous (delta -1) field.
+func (f *form) focus(delta int) {
+	f.fields[f.focused].input.Blur()
+	f.focused = (f.focused + delta + len(f.fields)) % len(f.fields)
+	f.fields[f.focused].input.Focus()
+}
+
+// toggleReveal shows or masks the secret inputs.
+func (f *form) toggleReveal() {
+	f.reveal = !f.reveal
+	for i := range f.fields {
+		if !f.fields[i].secret {
+			continue
+		}
+		f.fields[i].input.EchoMode = textinput.EchoPassword
+		if f.reveal {
+			f.fields[i].input.EchoMode = textinput.EchoNormal
+		}
+	}
+}
+
+// update passes the message to the focused input.
+func (f *form) update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	f.fields[f.focused].input, cmd = f.fields[f.focused].input.Update(msg)
+	return cmd
+}
+
+// item returns the edited item: the copy of the base with the form values. The new item gets the new id,
+// even if another item has the title: the form adds the item, it doesn't replace the one found by title.
+func (f *form) item() (models.Item, error) {
+	it, err := f.values()
+	if err != nil || f.base != nil {
+		return it, err
+	}
+
+	id, err := models.NewItemID()
+	if err != nil {
+		return nil, err
+	}
+	switch item := it.(type) {
+	case *models.Pair:
+		item.ItemID = id
+	case *models.Text:
+		item.ItemID = id
+	case *models.Card:
+		item.ItemID = id
+	case *models.OTP:
+		item.ItemID = id
+	}
+	return it, nil
+}
+
+// values returns the copy of the base with the form values.
+func (f *form) values() (models.Item, error) {
+	title := f.value(labelTitle)
+	if title == `` {
+		return nil, errEmptyTitle
+	}
+	fields, err := parseFields(f.value(labelFields))
+	if err != nil {
+		return nil, err
+	}
+	tags := clserv.NormalizeTags(splitList(f.value(labelTags), ","))
+	comment := f.value(labelComment)
+
+	switch f.dataType {
+	case "pair":
+		p := new(models.Pair)
+		if f.base != nil {
+			*p = *f.base.(*models.Pair)
+		}
+		urls, err := clserv.NormalizeURLs(splitList(f.value(labelURLs), ","))
+		if err != nil {
+			return nil, err
+		}
+		p.Title, p.Login, p.Pass, p.URLs = title, f.value(labelLogin), f.value(labelPass), urls
+		p.Comment, p.Fields, p.Tags = comment, fields, tags
+		return p, nil
+	case "text":
+		t := new(models.Text)
+		if f.base != nil {
+			*t = *f.base.(*models.Text)
+		}
+		// the body is kept as typed: leading and trailing spaces could be meaningful.
+		if body, ok := f.rawValue(labelBody); ok {
+			t.Body = body
+		}
+		t.Title, t.Comment, t.Fields, t.Tags = title, comment, fields, tags
+		return t, nil
+	case "bin":
+		if f.base == nil {
+			return nil, errNewBin
+		}
+		b := *f.base.(*models.Bin)
+		// sealed as is, the streamed bin would lose the body, kept on the server.
+		if b.Streamed {
+			return nil, errStreamedBin
+		}
+		b.Title, b.Comment, b.Fields, b.Tags = title, comment, fields, tags
+		return &b, nil
+	case "card":
+		c := new(models.Card)
+		if f.base != nil {
+			*c = *f.base.(*models.Card)
+		}
+		c.Title, c.Number, c.ExpirationDate = title, f.value(labelNumber), f.value(labelExpires)
+		c.Comment, c.Fields, c.Tags = comment, fields, tags
+		return c, nil
+	case "otp":
+		o := new(models.OTP)
+		if f.base != nil {
+			*o = *f.base.(*models.OTP)
+		}
+		o.Title, o.Secret, o.Issuer, o.Account = title, f.value(labelSecret), f.value(labelIssuer), f.value(labelAccount)
+		o.Comment, o.Fields, o.Tags = comment, fields, tags
+		if err := clserv.NormalizeOTP(o); err != nil {
+			return nil, err
+		}
+		return o, nil
+	}
+	return nil, models.ErrUnknownItemType
+}
+
+// rawValue returns the value of the field with the label, not trimmed. False, if the form has no such field.
+func (f *form) rawValue(label string) (string, bool) {
+	for _, ff := range f.fields {
+		if ff.label == label {
+			return ff.input.Value(), true
+		}
+	}
+	return ``, false
+}
+
+// view renders the form: a line for each field, the focused one is marked.
+func (f *form) view() string {
+	var b strings.Builder
+	title := "New " + f.dataType
+	if f.base != nil {
+		title = fmt.Sprintf("Edit %s %q", f.dataType, f.base.Head().Title)
+	}
+	b.WriteString(styleTitle.Render(title) + "\n\n")
+	for i, ff := range f.fields {
+		marker := "  "
+		if i == f.focused {
+			marker = "> "
+		}
+		fmt.Fprintf(&b, "%s%-9s %s\n", marker, ff.label+":", ff.input.View())
+	}
+	b.WriteString("\n" + styleHelp.Render("tab/↓ next • shift+tab/↑ prev • ctrl+r reveal • ctrl+s save • esc cancel"))
+	return b.String()
+}
+
+// formatFields returns the custom fields as the form value: name=value or name:type=value, separated by "; ".
+func formatFields(fields []models.Field) string {
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f.Type == clserv.FieldText {
+			parts = append(parts, f.Name+"="+f.Value)
+			continue
+		}
+		parts = append(parts, f.Name+":"+f.Type+"="+f.Value)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// parseFields parses the custom fields of the form value, see formatFields.
+func parseFields(s string) ([]models.Field, error) {
+	return clserv.ParseFields(splitList(s, ";"))
+}
+
+// splitList splits the list by the separator. The items are trimmed, the empty ones are skipped.
+func splitList(s, sep string) []string {
+	var out []string
+	for _, part := range strings.Split(s, sep) {
+		if part = strings.TrimSpace(part); part != `` {
+			out = append(out, part)
+		}
+	}
+	return out
+}
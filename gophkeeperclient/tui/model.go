@@ -0,0 +1,500 @@
+package tui
+
+import (
+	"fmt"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pane is the focused pane of the main screen.
+type pane int
+
+const (
+	paneSidebar pane = iota
+	paneList
+)
+
+// sidebarEntry filters the item list by the type or the tag. Both are empty for all the items.
+type sidebarEntry struct {
+	label    string
+	dataType string
+	tag      string
+}
+
+type (
+	// syncStartMsg starts the background sync.
+	syncStartMsg struct{}
+	// syncDoneMsg is sent, when the background sync ends.
+	syncDoneMsg struct {
+		report *clserv.SyncReport
+		err    error
+	}
+	// tickMsg renders the detail pane again: TOTP code changes in time.
+	tickMsg time.Time
+)
+
+// Model is the full-screen UI: the type and tag sidebar, the filterable item list, the detail pane
+// and the status bar. The vault is read and changed only by Update, the background sync works with
+// the vault alone: the changes wait for it to end.
+type Model struct {
+	session       *Session
+	width, height int
+	pane          pane
+
+	sidebar []sidebarEntry
+	side    int // selected sidebar entry.
+
+	filter    textinput.Model
+	filtering bool
+	items     []models.Item // listed items, read by refresh: the sync doesn't change them.
+	selected  int
+
+	detail string // rendered selected item.
+	reveal bool   // secrets of the selected item are shown.
+
+	form          *form // edited item, nil if the form is closed.
+	choosingType  bool  // the type of the new item is asked.
+	confirmDelete bool  // the deletion of the selected item is asked.
+
+	syncing   bool
+	quitting  bool // quit after the sync ends.
+	lastSync  time.Time
+	itemCount int
+	queued    int
+	conflicts int
+	status    string
+}
+
+// New returns the UI model of the session.
+func New(s *Session) Model {
+	filter := textinput.New()
+	filter.Prompt = "/ "
+	filter.Placeholder = "filter"
+	m := Model{session: s, pane: paneList, filter: filter, width: 100, height: 30}
+	m.refresh()
+	return m
+}
+
+// Init starts the sync: the vault is shown with the latest server changes.
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(func() tea.Msg { return syncStartMsg{} }, tick())
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+// Update handles the messages. Keys go to the open form, the question or the filter first.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case syncStartMsg:
+		return m.startSync()
+	case syncDoneMsg:
+		m.syncing = false
+		m.refresh()
+		m.status = syncStatus(msg.report, msg.err, m.queued, m.conflicts)
+		if msg.err == nil {
+			m.lastSync = time.Now()
+		}
+		if m.quitting {
+			return m, tea.Quit
+		}
+		return m, nil
+	case tickMsg:
+		if !m.syncing {
+			m.updateDetail()
+		}
+		return m, tick()
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m.quit()
+		}
+		switch {
+		case m.form != nil:
+			return m.updateForm(msg)
+		case m.choosingType:
+			return m.chooseType(msg)
+		case m.confirmDelete:
+			return m.confirm(msg)
+		case m.filtering:
+			return m.updateFilter(msg)
+		}
+		return m.handleKey(msg)
+	}
+
+	// cursor blinks.
+	var cmd tea.Cmd
+	switch {
+	case m.form != nil:
+		cmd = m.form.update(msg)
+	case m.filtering:
+		m.filter, cmd = m.filter.Update(msg)
+	}
+	return m, cmd
+}
+
+// handleKey handles the keys of the main screen.
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m.quit()
+	case "tab", "shift+tab":
+		m.pane = 1 - m.pane
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "/":
+		m.filtering, m.pane = true, paneList
+		return m, m.filter.Focus()
+	case "esc":
+		if m.filter.Value() != `` {
+			m.filter.SetValue(``)
+			m.selectItem(0)
+		}
+	case "r":
+		m.reveal = !m.reveal
+		m.updateDetail()
+	case "s":
+		return m.startSync()
+	case "a":
+		if m.busy() {
+			break
+		}
+		if t := m.sidebar[m.side].dataType; t != `` && t != "bin" {
+			return m.openForm(t, nil)
+		}
+		m.choosingType = true
+		m.status = "New item: [p]air, [t]ext, [c]ard or [o]tp? esc to cancel"
+	case "e":
+		if it := m.current(); !m.busy() && it != nil {
+			if b, ok := it.(*models.Bin); ok && b.Streamed {
+				m.status = errStreamedBin.Error()
+				break
+			}
+			return m.openForm(it.Head().Type, it)
+		}
+	case "d":
+		if it := m.current(); !m.busy() && it != nil {
+			m.confirmDelete = true
+			m.status = fmt.Sprintf("Delete %s %q? y/n", it.Head().Type, it.Head().Title)
+		}
+	}
+	return m, nil
+}
+
+// busy reports, if the vault can't be changed: the sync is in progress. The reason is shown.
+func (m *Model) busy() bool {
+	if m.syncing {
+		m.status = "Sync is in progress, please wait."
+	}
+	return m.syncing
+}
+
+// move moves the selection of the focused pane.
+func (m *Model) move(delta int) {
+	if m.pane == paneSidebar {
+		if next := m.side + delta; next >= 0 && next < len(m.sidebar) && !m.syncing {
+			m.side = next
+			m.selectItem(0)
+		}
+		return
+	}
+	if next := m.selected + delta; next >= 0 && next < len(m.items) {
+		m.selected, m.reveal = next, false
+		m.updateDetail()
+	}
+}
+
+// selectItem refreshes the list and selects the item with the index.
+func (m *Model) selectItem(i int) {
+	m.selected, m.reveal = i, false
+	if !m.syncing {
+		m.items = nil
+		m.refresh()
+	}
+}
+
+func (m Model) quit() (tea.Model, tea.Cmd) {
+	if m.syncing {
+		// the vault is saved, when the sync ends.
+		m.quitting = true
+		m.status = "Waiting for the sync to end..."
+		return m, nil
+	}
+	return m, tea.Quit
+}
+
+// updateFilter handles the keys, typed to the filter. The list is filtered as the query is typed.
+func (m Model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter, tea.KeyTab:
+		m.filtering = false
+		m.filter.Blur()
+		return m, nil
+	case tea.KeyEsc:
+		m.filtering = false
+		m.filter.Blur()
+		m.filter.SetValue(``)
+		m.selectItem(0)
+		return m, nil
+	case tea.KeyUp:
+		m.move(-1)
+		return m, nil
+	case tea.KeyDown:
+		m.move(1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	query := m.filter.Value()
+	m.filter, cmd = m.filter.Update(msg)
+	if m.filter.Value() != query {
+		m.selectItem(0)
+	}
+	return m, cmd
+}
+
+// chooseType opens the form of the new item of the chosen type.
+func (m Model) chooseType(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.choosingType = false
+	types := map[string]string{"p": "pair", "t": "text", "c": "card", "o": "otp"}
+	if t, ok := types[msg.String()]; ok {
+		return m.openForm(t, nil)
+	}
+	m.status = ``
+	return m, nil
+}
+
+// confirm deletes the selected item, if confirmed.
+func (m Model) confirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.confirmDelete = false
+	it := m.current()
+	if msg.String() != "y" || it == nil || m.busy() {
+		m.status = ``
+		return m, nil
+	}
+
+	h := it.Head()
+	if err := m.session.delete(h.Type, h.ItemID); err != nil {
+		m.status = "Delete failed: " + err.Error()
+		return m, nil
+	}
+	m.refresh()
+	m.status = fmt.Sprintf("%s %q deleted locally.", h.Type, h.Title)
+	return m.changed()
+}
+
+func (m Model) openForm(dataType string, it models.Item) (tea.Model, tea.Cmd) {
+	m.form = newForm(dataType, it)
+	m.status = ``
+	return m, textinput.Blink
+}
+
+// updateForm handles the keys of the open form.
+func (m Model) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.form, m.status = nil, "Cancelled."
+		return m, nil
+	case tea.KeyTab, tea.KeyDown:
+		m.form.focus(1)
+		return m, nil
+	case tea.KeyShiftTab, tea.KeyUp:
+		m.form.focus(-1)
+		return m, nil
+	case tea.KeyCtrlR:
+		m.form.toggleReveal()
+		return m, nil
+	case tea.KeyEnter:
+		if m.form.focused < len(m.form.fields)-1 {
+			m.form.focus(1)
+			return m, nil
+		}
+		return m.saveForm()
+	case tea.KeyCtrlS:
+		return m.saveForm()
+	}
+	return m, m.form.update(msg)
+}
+
+// saveForm saves the edited item as the next version. The form is kept open, if the values are invalid.
+func (m Model) saveForm() (tea.Model, tea.Cmd) {
+	if m.busy() {
+		return m, nil
+	}
+	it, err := m.form.item()
+	if err != nil {
+		m.status = err.Error()
+		return m, nil
+	}
+	if err = m.session.save(it); err != nil {
+		m.status = "Save failed: " + err.Error()
+		return m, nil
+	}
+
+	h := it.Head()
+	m.form = nil
+	m.refresh()
+	for i, item := range m.items {
+		if item.Head().Type == h.Type && item.Head().ItemID == h.ItemID {
+			m.selected = i
+			m.updateDetail()
+		}
+	}
+	m.status = fmt.Sprintf("%s %q saved locally.", h.Type, h.Title)
+	return m.changed()
+}
+
+// changed starts the sync of the local change, if the outbox backoff has passed. Otherwise the change waits in the outbox.
+func (m Model) changed() (tea.Model, tea.Cmd) {
+	if m.session.outbox().Due(time.Now()) {
+		return m.startSync()
+	}
+	m.status += fmt.Sprintf(" %d change(s) queued.", m.queued)
+	return m, nil
+}
+
+// startSync starts the background sync. Only one runs at once.
+func (m Model) startSync() (tea.Model, tea.Cmd) {
+	if m.syncing {
+		return m, nil
+	}
+	m.syncing = true
+	s := m.session
+	return m, func() tea.Msg {
+		report, err := s.sync()
+		return syncDoneMsg{report: report, err: err}
+	}
+}
+
+// current returns the selected item. Nil, if the list is empty.
+func (m *Model) current() models.Item {
+	if m.selected >= len(m.items) {
+		return nil
+	}
+	return m.items[m.selected]
+}
+
+// refresh reads the vault again: the sidebar, the list, filtered by the selected entry and the query, and the detail.
+// The selection is kept, if the entry and the item are still there.
+func (m *Model) refresh() {
+	v := m.session.vault()
+	all, _ := clserv.ListItems(v, ``, ``)
+
+	var selected sidebarEntry
+	if m.side < len(m.sidebar) {
+		selected = m.sidebar[m.side]
+	}
+	counts := make(map[string]int)
+	tagCounts := make(map[string]int)
+	for _, h := range all {
+		counts[h.Type]++
+		for _, t := range h.Tags {
+			tagCounts[t]++
+		}
+	}
+	m.sidebar = []sidebarEntry{{label: fmt.Sprintf("All (%d)", len(all))}}
+	for _, t := range models.ItemTypes {
+		m.sidebar = append(m.sidebar, sidebarEntry{label: fmt.Sprintf("%s (%d)", t, counts[t]), dataType: t})
+	}
+	tags := make([]string, 0, len(tagCounts))
+	for t := range tagCounts {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+	for _, t := range tags {
+		m.sidebar = append(m.sidebar, sidebarEntry{label: fmt.Sprintf("#%s (%d)", t, tagCounts[t]), tag: t})
+	}
+	m.side = 0
+	for i, e := range m.sidebar {
+		if e.dataType == selected.dataType && e.tag == selected.tag {
+			m.side = i
+		}
+	}
+
+	var current models.ItemHead
+	if it := m.current(); it != nil {
+		current = it.Head()
+	}
+	entry := m.sidebar[m.side]
+	var heads []models.ItemHead
+	if query := strings.TrimSpace(m.filter.Value()); query != `` {
+		hits, _ := clserv.SearchItems(v, query, entry.dataType, entry.tag, true)
+		for _, hit := range hits {
+			heads = append(heads, hit.ItemHead)
+		}
+	} else {
+		heads, _ = clserv.ListItems(v, entry.dataType, entry.tag)
+	}
+	m.items = make([]models.Item, 0, len(heads))
+	found := false
+	for i, h := range heads {
+		it, _ := v.Item(h.Type, h.ItemID)
+		m.items = append(m.items, it)
+		if h.Type == current.Type && h.ItemID == current.ItemID {
+			m.selected, found = i, true
+		}
+	}
+	// another item is selected: its secrets are masked.
+	if current.ItemID != `` && !found {
+		m.reveal = false
+	}
+	if m.selected >= len(m.items) {
+		m.selected = 0
+	}
+
+	m.itemCount = len(all)
+	m.queued = len(m.session.outbox().Ops)
+	m.conflicts = len(v.Conflicts)
+	m.updateDetail()
+}
+
+// updateDetail renders the selected item.
+func (m *Model) updateDetail() {
+	it := m.current()
+	if it == nil {
+		m.detail = styleMuted.Render("No items. Press a to add one.")
+		return
+	}
+	m.detail = renderDetail(it, m.reveal, time.Now())
+}
+
+// syncStatus describes the sync result for the status bar.
+func syncStatus(r *clserv.SyncReport, err error, queued, conflicts int) string {
+	var sent, merged int
+	if r != nil {
+		for _, res := range r.Results {
+			if res.GetStatus() == pb.SyncItemStatus_SYNC_ACCEPTED {
+				sent++
+			}
+		}
+		for _, mr := range r.Merges {
+			if mr.Merged {
+				merged++
+			}
+		}
+	}
+
+	msg := fmt.Sprintf("Synced: %d sent, %d merged.", sent, merged)
+	if err != nil {
+		msg = "Sync failed: " + err.Error()
+	}
+	if queued > 0 {
+		msg += fmt.Sprintf(" %d change(s) queued.", queued)
+	}
+	if conflicts > 0 {
+		msg += fmt.Sprintf(" %d conflict(s), run conflicts to resolve.", conflicts)
+	}
+	return msg
+}
@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"errors"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	testUser = "tester"
+	testID1  = "00000000-0000-4000-8000-000000000001"
+	testID2  = "00000000-0000-4000-8000-000000000002"
+	testID3  = "00000000-0000-4000-8000-000000000003"
+)
+
+// testSession returns the session of the vault with a pair, a text and a card. The server is not reachable.
+func testSession(t *testing.T) (*Session, *int) {
+	v := clstor.MakeVault()
+	v.Pair[testID1] = &models.Pair{ItemID: testID1, Title: "github", Login: "me", Pass: "p4ss", Version: 1,
+		Tags: []string{"work"}}
+	v.Text[testID2] = &models.Text{ItemID: testID2, Title: "notes", Body: "hello", Version: 1, Tags: []string{"home"}}
+	v.Card[testID3] = &models.Card{ItemID: testID3, Title: "visa", Number: "4111111111111111", Version: 1}
+	clstor.Local = map[string]*models.Vault{testUser: v}
+	clstor.Outboxes = make(map[string]*clstor.Outbox)
+
+	saved := new(int)
+	t.Cleanup(func() {
+		clstor.Local, clstor.Outboxes = nil, nil
+	})
+	return &Session{
+		UserName: testUser,
+		Key:      clserv.VaultKey("0123456789abcdef0123456789abcdef"),
+		Dial: func() (pb.KeeperClient, error) {
+			return nil, errors.New("offline")
+		},
+		Persist: func() error {
+			*saved++
+			return nil
+		},
+	}, saved
+}
+
+// press sends the keys to the model: the special key names or the typed text.
+func press(m tea.Model, keys ...string) tea.Model {
+	special := map[string]tea.KeyType{"tab": tea.KeyTab, "enter": tea.KeyEnter, "esc": tea.KeyEsc,
+		"down": tea.KeyDown, "ctrl+s": tea.KeyCtrlS, "ctrl+u": tea.KeyCtrlU}
+	for _, k := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		if t, ok := special[k]; ok {
+			msg = tea.KeyMsg{Type: t}
+		}
+		m, _ = m.Update(msg)
+	}
+	return m
+}
+
+func titles(m tea.Model) []string {
+	var out []string
+	for _, it := range m.(Model).items {
+		out = append(out, it.Head().Title)
+	}
+	return out
+}
+
+// TestModel verifies, that:
+// 1) items are filtered by the sidebar type and tag and by the query, as it is typed
+// 2) secrets are masked until revealed and masked again for another item
+// 3) edit form saves the next version and queues it, new item is added, the title is required
+// 4) deletion is made only when confirmed
+func TestModel(t *testing.T) {
+	tests := []struct {
+		name   string
+		number uint8
+	}{
+		{name: "Test #1: sidebar filters by type and tag", number: 1},
+		{name: "Test #2: list is filtered as the query is typed", number: 2},
+		{name: "Test #3: secrets are masked until revealed", number: 3},
+		{name: "Test #4: edit form saves the next version", number: 4},
+		{name: "Test #5: new item is added", number: 5},
+		{name: "Test #6: deletion is confirmed", number: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, saved := testSession(t)
+			var m tea.Model = New(s)
+			v := clstor.Local[testUser]
+
+			switch tt.number {
+			case 1:
+				assert.Equal(t, []string{"github", "notes", "visa"}, titles(m))
+				// All, pair, text, bin, card, otp, #home, #work.
+				assert.Len(t, m.(Model).sidebar, 8)
+				m = press(m, "tab", "down", "down")
+				assert.Equal(t, []string{"notes"}, titles(m))
+				m = press(m, "down", "down", "down", "down", "down")
+				assert.Equal(t, "work", m.(Model).sidebar[m.(Model).side].tag)
+				assert.Equal(t, []string{"github"}, titles(m))
+			case 2:
+				m = press(m, "/", "v", "s")
+				assert.Equal(t, []string{"visa"}, titles(m))
+				m = press(m, "esc")
+				assert.Len(t, titles(m), 3)
+			case 3:
+				assert.Contains(t, m.View(), mask)
+				assert.NotContains(t, m.View(), "p4ss")
+				m = press(m, "r")
+				assert.Contains(t, m.View(), "p4ss")
+				// another item is selected - masked again.
+				m = press(m, "down", "down")
+				assert.NotContains(t, m.View(), "4111111111111111")
+			case 4:
+				m = press(m, "e", "ctrl+u", "gitlab", "tab", "tab", "tab", "ctrl+u", "gitlab.com", "ctrl+s")
+				assert.Nil(t, m.(Model).form)
+				assert.Equal(t, 1, *saved)
+				p := v.Pair[testID1]
+				assert.Equal(t, "gitlab", p.Title)
+				assert.Equal(t, "p4ss", p.Pass)
+				assert.Equal(t, []string{"https://gitlab.com"}, p.URLs)
+				assert.Equal(t, uint32(2), p.Version)
+				assert.Len(t, clstor.UserOutbox(testUser).Ops, 1)
+				assert.Contains(t, m.View(), "gitlab")
+			case 5:
+				// title is required.
+				m = press(m, "a", "t", "ctrl+s")
+				require.NotNil(t, m.(Model).form)
+				assert.Equal(t, errEmptyTitle.Error(), m.(Model).status)
+				m = press(m, "notes", "enter", "hi", "tab", "tab", "a:hidden=b", "enter", "x, y", "enter")
+				assert.Nil(t, m.(Model).form)
+				assert.Len(t, v.Text, 2)
+				var added *models.Text
+				for id, text := range v.Text {
+					if id != testID2 {
+						added = text
+					}
+				}
+				require.NotNil(t, added)
+				assert.Equal(t, "notes", added.Title)
+				assert.Equal(t, "hi", added.Body)
+				assert.Equal(t, []models.Field{{Name: "a", Type: clserv.FieldHidden, Value: "b"}}, added.Fields)
+				assert.Equal(t, []string{"x", "y"}, added.Tags)
+			case 6:
+				m = press(m, "d", "n")
+				assert.False(t, v.Pair[testID1].DeletedAt.Valid)
+				m = press(m, "d", "y")
+				assert.True(t, v.Pair[testID1].DeletedAt.Valid)
+				assert.Equal(t, []string{"notes", "visa"}, titles(m))
+				assert.Equal(t, 1, *saved)
+			}
+		})
+	}
+}
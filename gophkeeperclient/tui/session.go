@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"context"
+	clserv "github.com/EestiChameleon/gophkeeper/gophkeeperclient/service"
+	clstor "github.com/EestiChameleon/gophkeeper/gophkeeperclient/storage"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"time"
+)
+
+// Session is the authenticated user, the UI works for. The vault and outbox are the local ones of clstor,
+// so the changes are seen by the other commands.
+type Session struct {
+	UserName string
+	Key      clserv.VaultKey
+	// Dial returns the server client, usually grpcclient.DialUp.
+	Dial func() (pb.KeeperClient, error)
+	// Persist saves the local files, usually clstor.UpdateFiles.
+	Persist func() error
+}
+
+func (s *Session) vault() *models.Vault {
+	return clstor.Local[s.UserName]
+}
+
+func (s *Session) outbox() *clstor.Outbox {
+	return clstor.UserOutbox(s.UserName)
+}
+
+// save saves the item as the next version and queues the change.
+func (s *Session) save(it models.Item) error {
+	if err := clserv.SaveItem(s.vault(), s.outbox(), it, s.Key); err != nil {
+		return err
+	}
+	return s.Persist()
+}
+
+// delete records the tombstone of the item and queues the deletion.
+func (s *Session) delete(dataType, id string) error {
+	if err := clserv.DeleteItem(s.vault(), s.outbox(), dataType, id, s.Key); err != nil {
+		return err
+	}
+	return s.Persist()
+}
+
+// sync sends the queued changes and pulls the server changes, like syncVault does: merged versions
+// and conflict copies, queued by the sync itself, are sent with one more round.
+func (s *Session) sync() (*clserv.SyncReport, error) {
+	c, err := s.Dial()
+	if err != nil {
+		return nil, err
+	}
+
+	outbox := s.outbox()
+	report := new(clserv.SyncReport)
+	for round := 0; round < 2; round++ {
+		if round > 0 && (len(outbox.Ops) == 0 || outbox.Attempts > 0) {
+			break
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+		vault, r, err := clserv.SyncVault(ctx, c, s.vault(), outbox, s.Key)
+		cancel()
+		if r != nil {
+			report.Results = append(report.Results, r.Results...)
+			report.Merges = append(report.Merges, r.Merges...)
+		}
+		if err != nil {
+			return report, err
+		}
+		clstor.Local[s.UserName] = vault
+	}
+	return report, s.Persist()
+}
@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const sidebarWidth = 22
+
+var (
+	colorAccent = lipgloss.Color("62")
+	colorMuted  = lipgloss.Color("241")
+
+	styleTitle    = lipgloss.NewStyle().Bold(true)
+	styleMuted    = lipgloss.NewStyle().Foreground(colorMuted)
+	styleHelp     = lipgloss.NewStyle().Foreground(colorMuted)
+	styleSelected = lipgloss.NewStyle().Reverse(true)
+	styleCurrent  = lipgloss.NewStyle().Bold(true)
+	styleStatus   = lipgloss.NewStyle().Reverse(true)
+	stylePane     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(colorMuted).Padding(0, 1)
+	styleFocused  = stylePane.Copy().BorderForeground(colorAccent)
+)
+
+// View renders the sidebar, the list and the detail pane (or the form), the status bar and the key help.
+func (m Model) View() string {
+	// the status bar and the key help take a line each.
+	height := m.height - 2
+	listWidth := (m.width - sidebarWidth) * 2 / 5
+	detailWidth := m.width - sidebarWidth - listWidth
+
+	right := m.detail
+	if m.form != nil {
+		right = m.form.view()
+	}
+	main := lipgloss.JoinHorizontal(lipgloss.Top,
+		renderPane(m.sidebarView(height-2), sidebarWidth, height, m.pane == paneSidebar && m.form == nil),
+		renderPane(m.listView(height-2), listWidth, height, m.pane == paneList && m.form == nil),
+		renderPane(right, detailWidth, height, m.form != nil),
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, main, m.statusView(), m.helpView())
+}
+
+// renderPane renders the content in the bordered pane of the outer size. The content is wrapped and clipped.
+func renderPane(content string, width, height int, focused bool) string {
+	if width < 5 || height < 3 {
+		return ``
+	}
+	lines := strings.Split(lipgloss.NewStyle().Width(width-4).Render(content), "\n")
+	if len(lines) > height-2 {
+		lines = lines[:height-2]
+	}
+	style := stylePane
+	if focused {
+		style = styleFocused
+	}
+	return style.Width(width - 2).Height(height - 2).Render(strings.Join(lines, "\n"))
+}
+
+func (m Model) sidebarView(height int) string {
+	var b strings.Builder
+	for i, e := range m.sidebar {
+		if i >= height {
+			break
+		}
+		line := e.label
+		switch {
+		case i == m.side && m.pane == paneSidebar:
+			line = styleSelected.Render(line)
+		case i == m.side:
+			line = styleCurrent.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// listView renders the filter and the visible part of the list: the selected item is always visible.
+func (m Model) listView(height int) string {
+	var b strings.Builder
+	switch {
+	case m.filtering || m.filter.Value() != ``:
+		b.WriteString(m.filter.View() + "\n")
+	default:
+		b.WriteString(styleMuted.Render("/ to filter") + "\n")
+	}
+
+	rows := height - 1
+	first := 0
+	if m.selected >= rows {
+		first = m.selected - rows + 1
+	}
+	for i := first; i < len(m.items) && i < first+rows; i++ {
+		h := m.items[i].Head()
+		line := fmt.Sprintf("%-4s %s", h.Type, h.Title)
+		switch {
+		case i == m.selected && m.pane == paneList:
+			line = styleSelected.Render(line)
+		case i == m.selected:
+			line = styleCurrent.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if len(m.items) == 0 {
+		b.WriteString(styleMuted.Render("Nothing found.") + "\n")
+	}
+	return b.String()
+}
+
+// statusView renders the status bar: the user, the vault state, the sync state and the last message.
+func (m Model) statusView() string {
+	parts := []string{m.session.UserName, fmt.Sprintf("%d items", m.itemCount), fmt.Sprintf("%d queued", m.queued)}
+	if m.conflicts > 0 {
+		parts = append(parts, fmt.Sprintf("%d conflict(s)", m.conflicts))
+	}
+	switch {
+	case m.syncing:
+		parts = append(parts, "syncing...")
+	case m.lastSync.IsZero():
+		parts = append(parts, "not synced")
+	default:
+		parts = append(parts, "synced "+m.lastSync.Format("15:04:05"))
+	}
+	if m.status != `` {
+		parts = append(parts, m.status)
+	}
+	return styleStatus.Width(m.width).MaxHeight(1).Render(" " + strings.Join(parts, " │ "))
+}
+
+func (m Model) helpView() string {
+	help := "tab pane • ↑/↓ move • / filter • r reveal • a add • e edit • d delete • s sync • q quit"
+	switch {
+	case m.form != nil:
+		help = ``
+	case m.filtering:
+		help = "type to filter • ↑/↓ move • enter done • esc clear"
+	}
+	return styleHelp.MaxWidth(m.width).Render(help)
+}
@@ -2,122 +2,635 @@ package models
 
 import (
 	"database/sql"
+	"encoding/json"
 	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"strconv"
 )
 
-// ProtoToModelsPair converts proto Pair data to local Pair.
-func ProtoToModelsPair(p *pb.Pair) *Pair {
+// Sealer encrypts and decrypts the item payloads. Implemented on the client side by the vault key,
+// the server never has one.
+type Sealer interface {
+	// Seal encrypts and authenticates plaintext. additionalData is authenticated, but not encrypted.
+	Seal(plaintext, additionalData []byte) ([]byte, error)
+	// Open decrypts ciphertext and verifies it together with additionalData.
+	Open(ciphertext, additionalData []byte) ([]byte, error)
+}
+
+// pairPayload is the sealed part of Pair.
+type pairPayload struct {
+	Login   string   `json:"login"`
+	Pass    string   `json:"pass"`
+	Comment string   `json:"comment"`
+	URLs    []string `json:"urls,omitempty"`
+	Fields  []Field  `json:"fields,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// textPayload is the sealed part of Text.
+type textPayload struct {
+	Body    string   `json:"body"`
+	Comment string   `json:"comment"`
+	Fields  []Field  `json:"fields,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// binPayload is the sealed part of Bin.
+type binPayload struct {
+	Body        []byte   `json:"body"`
+	Comment     string   `json:"comment"`
+	StreamTitle string   `json:"stream_title,omitempty"`
+	Fields      []Field  `json:"fields,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// cardPayload is the sealed part of Card.
+type cardPayload struct {
+	Number         string   `json:"number"`
+	ExpirationDate string   `json:"expiration_date"`
+	Comment        string   `json:"comment"`
+	Fields         []Field  `json:"fields,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+}
+
+// otpPayload is the sealed part of OTP.
+type otpPayload struct {
+	Kind      string   `json:"kind"`
+	Secret    string   `json:"secret"`
+	Issuer    string   `json:"issuer"`
+	Account   string   `json:"account"`
+	Algorithm string   `json:"algorithm"`
+	Digits    int      `json:"digits"`
+	Period    int      `json:"period"`
+	Counter   uint64   `json:"counter"`
+	Comment   string   `json:"comment"`
+	Fields    []Field  `json:"fields,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+// itemAD returns the additional data, the item data is bound to: the item type and id. The streamed body is bound
+// to it: the versions share the body.
+func itemAD(dataType, id string) string {
+	return dataType + "/" + id
+}
+
+// versionAD returns the additional data, the item payload is bound to: the item type, id and version.
+func versionAD(dataType, id string, version uint32) string {
+	return itemAD(dataType, id) + "/" + strconv.FormatUint(uint64(version), 10)
+}
+
+// legacyAD returns the additional data of the items, sealed before the item ids: the item type and title.
+// The separator differs from itemAD, so the forms never match.
+func legacyAD(dataType, title string) string {
+	return dataType + ":" + title
+}
+
+// sealPayload marshals the payload and seals it. Payload is bound to the item type, id and version,
+// so the server can't swap payloads between items or return the older version as the latest one.
+func sealPayload(s Sealer, dataType, id string, version uint32, payload interface{}) ([]byte, error) {
+	plain, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return s.Seal(plain, []byte(versionAD(dataType, id, version)))
+}
+
+// openPayload opens the sealed payload of the item version and unmarshals it to dest. Payload, sealed before
+// the version binding, is bound to the item type and id only: it is opened, until the item is saved again.
+// Payload of the item, saved before the item ids, is bound to the title: it is opened, only if the id is derived
+// from that title (see LegacyItemID). legacy reports such a payload.
+func openPayload(s Sealer, dataType, id, title string, version uint32, sealed []byte, dest interface{}) (legacy bool,
+	err error) {
+	plain, err := s.Open(sealed, []byte(versionAD(dataType, id, version)))
+	if err != nil {
+		plain, err = s.Open(sealed, []byte(itemAD(dataType, id)))
+	}
+	if err != nil && id == LegacyItemID(dataType, title) {
+		legacy = true
+		plain, err = s.Open(sealed, []byte(legacyAD(dataType, title)))
+	}
+	if err != nil {
+		return false, err
+	}
+	return legacy, json.Unmarshal(plain, dest)
+}
+
+// OpenPayloadFields opens the sealed payload of the item version and returns its fields as is (by the json names).
+// Used to work with the items regardless of the type, like the three-way merge.
+func OpenPayloadFields(s Sealer, dataType, id, title string, version uint32, sealed []byte) (map[string]json.RawMessage,
+	error) {
+	fields := make(map[string]json.RawMessage)
+	legacy, err := openPayload(s, dataType, id, title, version, sealed, &fields)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := fields["stream_title"]; legacy && dataType == "bin" && !ok && string(fields["body"]) == "null" {
+		// streamed body (the payload has no body) keeps the title binding, when the payload is sealed again.
+		fields["stream_title"], _ = json.Marshal(title)
+	}
+	return fields, nil
+}
+
+// SealPayloadFields seals the item payload fields for the item version, see OpenPayloadFields.
+func SealPayloadFields(s Sealer, dataType, id string, version uint32, fields map[string]json.RawMessage) ([]byte,
+	error) {
+	return sealPayload(s, dataType, id, version, fields)
+}
+
+// ProtoToModelsPair converts proto Pair data to local Pair. Payload is opened with the passed Sealer.
+// Tombstone is returned with DeletedAt set and without the payload.
+func ProtoToModelsPair(p *pb.Pair, s Sealer) (*Pair, error) {
+	if p.GetDeleted() {
+		// tombstone has no payload
+		return &Pair{ItemID: p.GetId(), Title: p.GetTitle(), Version: p.GetVersion(), DeletedAt: sql.NullTime{Valid: true}}, nil
+	}
+
+	payload := new(pairPayload)
+	if _, err := openPayload(s, "pair", p.GetId(), p.GetTitle(), p.GetVersion(), p.GetPayload(), payload); err != nil {
+		return nil, err
+	}
+
 	return &Pair{
 		ID:        0,
+		ItemID:    p.GetId(),
 		UserID:    0,
 		Title:     p.GetTitle(),
-		Login:     p.GetLogin(),
-		Pass:      p.GetPass(),
-		Comment:   p.GetComment(),
+		Login:     payload.Login,
+		Pass:      payload.Pass,
+		Comment:   payload.Comment,
+		URLs:      payload.URLs,
+		Fields:    payload.Fields,
+		Tags:      payload.Tags,
 		Version:   p.GetVersion(),
 		DeletedAt: sql.NullTime{},
-	}
+	}, nil
 }
 
-// ProtoToModelsText converts proto Text data to local Text.
-func ProtoToModelsText(t *pb.Text) *Text {
+// ProtoToModelsText converts proto Text data to local Text. Payload is opened with the passed Sealer.
+// Tombstone is returned with DeletedAt set and without the payload.
+func ProtoToModelsText(t *pb.Text, s Sealer) (*Text, error) {
+	if t.GetDeleted() {
+		// tombstone has no payload
+		return &Text{ItemID: t.GetId(), Title: t.GetTitle(), Version: t.GetVersion(), DeletedAt: sql.NullTime{Valid: true}}, nil
+	}
+
+	payload := new(textPayload)
+	if _, err := openPayload(s, "text", t.GetId(), t.GetTitle(), t.GetVersion(), t.GetPayload(), payload); err != nil {
+		return nil, err
+	}
+
 	return &Text{
 		ID:        0,
+		ItemID:    t.GetId(),
 		UserID:    0,
 		Title:     t.GetTitle(),
-		Body:      t.GetBody(),
-		Comment:   t.GetComment(),
+		Body:      payload.Body,
+		Comment:   payload.Comment,
+		Fields:    payload.Fields,
+		Tags:      payload.Tags,
 		Version:   t.GetVersion(),
 		DeletedAt: sql.NullTime{},
-	}
+	}, nil
 }
 
-// ProtoToModelsBin converts proto Binary data to local Binary.
-func ProtoToModelsBin(b *pb.Bin) *Bin {
-	return &Bin{
-		ID:        0,
-		UserID:    0,
-		Title:     b.GetTitle(),
-		Body:      b.GetBody(),
-		Comment:   b.GetComment(),
-		Version:   b.GetVersion(),
-		DeletedAt: sql.NullTime{},
+// ProtoToModelsBin converts proto Binary data to local Binary. Payload is opened with the passed Sealer.
+// Tombstone is returned with DeletedAt set and without the payload.
+func ProtoToModelsBin(b *pb.Bin, s Sealer) (*Bin, error) {
+	if b.GetDeleted() {
+		// tombstone has no payload
+		return &Bin{ItemID: b.GetId(), Title: b.GetTitle(), Version: b.GetVersion(), DeletedAt: sql.NullTime{Valid: true}}, nil
+	}
+
+	payload := new(binPayload)
+	legacy, err := openPayload(s, "bin", b.GetId(), b.GetTitle(), b.GetVersion(), b.GetPayload(), payload)
+	if err != nil {
+		return nil, err
+	}
+	if legacy && b.GetStreamed() && payload.StreamTitle == `` {
+		// streamed body of the item, saved before the item ids, is bound to the title.
+		payload.StreamTitle = b.GetTitle()
 	}
+
+	return &Bin{
+		ID:          0,
+		ItemID:      b.GetId(),
+		UserID:      0,
+		Title:       b.GetTitle(),
+		Body:        payload.Body,
+		Comment:     payload.Comment,
+		Fields:      payload.Fields,
+		Tags:        payload.Tags,
+		Version:     b.GetVersion(),
+		DeletedAt:   sql.NullTime{},
+		Streamed:    b.GetStreamed(),
+		Size:        int64(b.GetSize()),
+		StreamTitle: payload.StreamTitle,
+	}, nil
 }
 
-// ProtoToModelsCard converts proto Card data to local Card.
-func ProtoToModelsCard(c *pb.Card) *Card {
+// ProtoToModelsCard converts proto Card data to local Card. Payload is opened with the passed Sealer.
+// Tombstone is returned with DeletedAt set and without the payload.
+func ProtoToModelsCard(c *pb.Card, s Sealer) (*Card, error) {
+	if c.GetDeleted() {
+		// tombstone has no payload
+		return &Card{ItemID: c.GetId(), Title: c.GetTitle(), Version: c.GetVersion(), DeletedAt: sql.NullTime{Valid: true}}, nil
+	}
+
+	payload := new(cardPayload)
+	if _, err := openPayload(s, "card", c.GetId(), c.GetTitle(), c.GetVersion(), c.GetPayload(), payload); err != nil {
+		return nil, err
+	}
+
 	return &Card{
 		ID:             0,
+		ItemID:         c.GetId(),
 		UserID:         0,
 		Title:          c.GetTitle(),
-		Number:         c.GetNumber(),
-		ExpirationDate: c.GetExpdate(),
-		Comment:        c.GetComment(),
+		Number:         payload.Number,
+		ExpirationDate: payload.ExpirationDate,
+		Comment:        payload.Comment,
+		Fields:         payload.Fields,
+		Tags:           payload.Tags,
 		Version:        c.GetVersion(),
 		DeletedAt:      sql.NullTime{},
+	}, nil
+}
+
+// ProtoToModelsOTP converts proto Item of the otp type to local OTP. Payload is opened with the passed Sealer.
+// Tombstone is returned with DeletedAt set and without the payload. OTP has no typed message.
+func ProtoToModelsOTP(o *pb.Item, s Sealer) (*OTP, error) {
+	if o.GetDeleted() {
+		// tombstone has no payload
+		return &OTP{ItemID: o.GetId(), Title: o.GetTitle(), Version: o.GetVersion(), DeletedAt: sql.NullTime{Valid: true}}, nil
+	}
+
+	payload := new(otpPayload)
+	if _, err := openPayload(s, "otp", o.GetId(), o.GetTitle(), o.GetVersion(), o.GetPayload(), payload); err != nil {
+		return nil, err
 	}
+
+	return &OTP{
+		ItemID:    o.GetId(),
+		Title:     o.GetTitle(),
+		Kind:      payload.Kind,
+		Secret:    payload.Secret,
+		Issuer:    payload.Issuer,
+		Account:   payload.Account,
+		Algorithm: payload.Algorithm,
+		Digits:    payload.Digits,
+		Period:    payload.Period,
+		Counter:   payload.Counter,
+		Comment:   payload.Comment,
+		Fields:    payload.Fields,
+		Tags:      payload.Tags,
+		Version:   o.GetVersion(),
+	}, nil
 }
 
-// ModelsToProtoPair converts local Pair structure to proto Pair structure.
-func ModelsToProtoPair(in *Pair) *pb.Pair {
+// ModelsToProtoPair converts local Pair structure to proto Pair structure. Payload is sealed with the passed Sealer.
+// Deleted item is converted to a tombstone.
+func ModelsToProtoPair(in *Pair, s Sealer) (*pb.Pair, error) {
+	if in.DeletedAt.Valid {
+		return &pb.Pair{Id: in.ItemID, Title: in.Title, Version: in.Version, Deleted: true}, nil
+	}
+
+	payload, err := sealPayload(s, "pair", in.ItemID, in.Version, pairPayload{Login: in.Login, Pass: in.Pass,
+		Comment: in.Comment, URLs: in.URLs, Fields: in.Fields, Tags: in.Tags})
+	if err != nil {
+		return nil, err
+	}
+
 	return &pb.Pair{
+		Id:      in.ItemID,
 		Title:   in.Title,
-		Login:   in.Login,
-		Pass:    in.Pass,
-		Comment: in.Comment,
 		Version: in.Version,
-	}
+		Payload: payload,
+	}, nil
 }
 
-// ModelsToProtoText converts local Text structure to proto Text structure.
-func ModelsToProtoText(in *Text) *pb.Text {
+// ModelsToProtoText converts local Text structure to proto Text structure. Payload is sealed with the passed Sealer.
+// Deleted item is converted to a tombstone.
+func ModelsToProtoText(in *Text, s Sealer) (*pb.Text, error) {
+	if in.DeletedAt.Valid {
+		return &pb.Text{Id: in.ItemID, Title: in.Title, Version: in.Version, Deleted: true}, nil
+	}
+
+	payload, err := sealPayload(s, "text", in.ItemID, in.Version, textPayload{Body: in.Body, Comment: in.Comment,
+		Fields: in.Fields, Tags: in.Tags})
+	if err != nil {
+		return nil, err
+	}
+
 	return &pb.Text{
+		Id:      in.ItemID,
 		Title:   in.Title,
-		Body:    in.Body,
-		Comment: in.Comment,
 		Version: in.Version,
-	}
+		Payload: payload,
+	}, nil
 }
 
-// ModelsToProtoBin converts local Bin structure to proto Bin structure.
-func ModelsToProtoBin(in *Bin) *pb.Bin {
+// ModelsToProtoBin converts local Bin structure to proto Bin structure. Payload is sealed with the passed Sealer.
+// Deleted item is converted to a tombstone.
+func ModelsToProtoBin(in *Bin, s Sealer) (*pb.Bin, error) {
+	if in.DeletedAt.Valid {
+		return &pb.Bin{Id: in.ItemID, Title: in.Title, Version: in.Version, Deleted: true}, nil
+	}
+
+	payload, err := sealPayload(s, "bin", in.ItemID, in.Version, binPayload{Body: in.Body, Comment: in.Comment,
+		StreamTitle: in.StreamTitle, Fields: in.Fields, Tags: in.Tags})
+	if err != nil {
+		return nil, err
+	}
+
 	return &pb.Bin{
+		Id:       in.ItemID,
+		Title:    in.Title,
+		Version:  in.Version,
+		Payload:  payload,
+		Streamed: in.Streamed,
+		Size:     uint64(in.Size),
+	}, nil
+}
+
+// ModelsToProtoCard converts local Card structure to proto Card structure. Payload is sealed with the passed Sealer.
+// Deleted item is converted to a tombstone.
+func ModelsToProtoCard(in *Card, s Sealer) (*pb.Card, error) {
+	if in.DeletedAt.Valid {
+		return &pb.Card{Id: in.ItemID, Title: in.Title, Version: in.Version, Deleted: true}, nil
+	}
+
+	payload, err := sealPayload(s, "card", in.ItemID, in.Version,
+		cardPayload{Number: in.Number, ExpirationDate: in.ExpirationDate, Comment: in.Comment, Fields: in.Fields,
+			Tags: in.Tags})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Card{
+		Id:      in.ItemID,
 		Title:   in.Title,
-		Body:    in.Body,
-		Comment: in.Comment,
 		Version: in.Version,
-	}
+		Payload: payload,
+	}, nil
 }
 
-// ModelsToProtoCard converts local Card structure to proto Card structure.
-func ModelsToProtoCard(in *Card) *pb.Card {
-	return &pb.Card{
+// ModelsToProtoOTP converts local OTP structure to proto Item structure. Payload is sealed with the passed Sealer.
+// Deleted item is converted to a tombstone.
+func ModelsToProtoOTP(in *OTP, s Sealer) (*pb.Item, error) {
+	if in.DeletedAt.Valid {
+		return &pb.Item{Id: in.ItemID, Type: "otp", Title: in.Title, Version: in.Version, Deleted: true}, nil
+	}
+
+	payload, err := sealPayload(s, "otp", in.ItemID, in.Version, otpPayload{Kind: in.Kind, Secret: in.Secret,
+		Issuer: in.Issuer, Account: in.Account, Algorithm: in.Algorithm, Digits: in.Digits, Period: in.Period,
+		Counter: in.Counter, Comment: in.Comment, Fields: in.Fields, Tags: in.Tags})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Item{
+		Id:      in.ItemID,
+		Type:    "otp",
 		Title:   in.Title,
-		Number:  in.Number,
-		Expdate: in.ExpirationDate,
-		Comment: in.Comment,
 		Version: in.Version,
+		Payload: payload,
+	}, nil
+}
+
+// SealedToProtoItem converts database item of the type to proto Item structure. Payload and deletion mark are passed
+// as is.
+func SealedToProtoItem(dataType string, in *Sealed) *pb.Item {
+	return &pb.Item{
+		Id:       in.ItemID,
+		Type:     dataType,
+		Title:    in.Title,
+		Version:  in.Version,
+		Payload:  in.Payload,
+		Deleted:  in.DeletedAt.Valid,
+		Streamed: in.Streamed,
+		Size:     uint64(in.Size),
+	}
+}
+
+// SealedToProtoPair converts database item to proto Pair structure. Payload and deletion mark are passed as is.
+func SealedToProtoPair(in *Sealed) *pb.Pair {
+	return ItemToPair(SealedToProtoItem("pair", in))
+}
+
+// SealedToProtoText converts database item to proto Text structure. Payload and deletion mark are passed as is.
+func SealedToProtoText(in *Sealed) *pb.Text {
+	return ItemToText(SealedToProtoItem("text", in))
+}
+
+// SealedToProtoBin converts database item to proto Bin structure. Payload and deletion mark are passed as is.
+func SealedToProtoBin(in *Sealed) *pb.Bin {
+	return ItemToBin(SealedToProtoItem("bin", in))
+}
+
+// SealedToProtoCard converts database item to proto Card structure. Payload and deletion mark are passed as is.
+func SealedToProtoCard(in *Sealed) *pb.Card {
+	return ItemToCard(SealedToProtoItem("card", in))
+}
+
+// LegacyToProto converts the database plaintext item to proto LegacyItem structure.
+func LegacyToProto(in *Legacy) *pb.LegacyItem {
+	return &pb.LegacyItem{
+		Type:           in.Type,
+		Id:             in.ItemID,
+		Title:          in.Title,
+		Version:        in.Version,
+		Login:          in.Login,
+		Pass:           in.Pass,
+		Body:           in.Body,
+		BinBody:        in.BinBody,
+		Number:         in.Number,
+		ExpirationDate: in.ExpirationDate,
+		Comment:        in.Comment,
 	}
 }
 
-// convertActualDataToProto converts local data structures, used for DB interactions, to gRPC proto structures.
+// LegacyToItem converts proto LegacyItem to the local item of its type with the plaintext version.
+func LegacyToItem(in *pb.LegacyItem) (Item, error) {
+	switch in.GetType() {
+	case "pair":
+		return &Pair{ItemID: in.GetId(), Title: in.GetTitle(), Login: in.GetLogin(), Pass: in.GetPass(),
+			Comment: in.GetComment(), Version: in.GetVersion()}, nil
+	case "text":
+		return &Text{ItemID: in.GetId(), Title: in.GetTitle(), Body: in.GetBody(), Comment: in.GetComment(),
+			Version: in.GetVersion()}, nil
+	case "bin":
+		return &Bin{ItemID: in.GetId(), Title: in.GetTitle(), Body: in.GetBinBody(), Comment: in.GetComment(),
+			Version: in.GetVersion()}, nil
+	case "card":
+		return &Card{ItemID: in.GetId(), Title: in.GetTitle(), Number: in.GetNumber(),
+			ExpirationDate: in.GetExpirationDate(), Comment: in.GetComment(), Version: in.GetVersion()}, nil
+	}
+	return nil, ErrUnknownItemType
+}
+
+// PairToItem wraps proto Pair to the Item envelope.
+func PairToItem(in *pb.Pair) *pb.Item {
+	return &pb.Item{Id: in.GetId(), Type: "pair", Title: in.GetTitle(), Version: in.GetVersion(),
+		Payload: in.GetPayload(), Deleted: in.GetDeleted()}
+}
+
+// TextToItem wraps proto Text to the Item envelope.
+func TextToItem(in *pb.Text) *pb.Item {
+	return &pb.Item{Id: in.GetId(), Type: "text", Title: in.GetTitle(), Version: in.GetVersion(),
+		Payload: in.GetPayload(), Deleted: in.GetDeleted()}
+}
+
+// BinToItem wraps proto Bin to the Item envelope.
+func BinToItem(in *pb.Bin) *pb.Item {
+	return &pb.Item{Id: in.GetId(), Type: "bin", Title: in.GetTitle(), Version: in.GetVersion(),
+		Payload: in.GetPayload(), Deleted: in.GetDeleted(), Streamed: in.GetStreamed(), Size: in.GetSize()}
+}
+
+// CardToItem wraps proto Card to the Item envelope.
+func CardToItem(in *pb.Card) *pb.Item {
+	return &pb.Item{Id: in.GetId(), Type: "card", Title: in.GetTitle(), Version: in.GetVersion(),
+		Payload: in.GetPayload(), Deleted: in.GetDeleted()}
+}
+
+// ItemToPair unwraps the Item envelope to proto Pair. The type is not checked.
+func ItemToPair(in *pb.Item) *pb.Pair {
+	return &pb.Pair{Id: in.GetId(), Title: in.GetTitle(), Version: in.GetVersion(), Payload: in.GetPayload(),
+		Deleted: in.GetDeleted()}
+}
+
+// ItemToText unwraps the Item envelope to proto Text. The type is not checked.
+func ItemToText(in *pb.Item) *pb.Text {
+	return &pb.Text{Id: in.GetId(), Title: in.GetTitle(), Version: in.GetVersion(), Payload: in.GetPayload(),
+		Deleted: in.GetDeleted()}
+}
+
+// ItemToBin unwraps the Item envelope to proto Bin. The type is not checked.
+func ItemToBin(in *pb.Item) *pb.Bin {
+	return &pb.Bin{Id: in.GetId(), Title: in.GetTitle(), Version: in.GetVersion(), Payload: in.GetPayload(),
+		Deleted: in.GetDeleted(), Streamed: in.GetStreamed(), Size: in.GetSize()}
+}
+
+// ItemToCard unwraps the Item envelope to proto Card. The type is not checked.
+func ItemToCard(in *pb.Item) *pb.Card {
+	return &pb.Card{Id: in.GetId(), Title: in.GetTitle(), Version: in.GetVersion(), Payload: in.GetPayload(),
+		Deleted: in.GetDeleted()}
+}
+
+// SealItem converts the local item of any type to proto Item structure. Payload is sealed with the passed Sealer.
+// Deleted item is converted to a tombstone.
+func SealItem(in Item, s Sealer) (*pb.Item, error) {
+	switch it := in.(type) {
+	case *Pair:
+		p, err := ModelsToProtoPair(it, s)
+		if err != nil {
+			return nil, err
+		}
+		return PairToItem(p), nil
+	case *Text:
+		t, err := ModelsToProtoText(it, s)
+		if err != nil {
+			return nil, err
+		}
+		return TextToItem(t), nil
+	case *Bin:
+		b, err := ModelsToProtoBin(it, s)
+		if err != nil {
+			return nil, err
+		}
+		return BinToItem(b), nil
+	case *Card:
+		c, err := ModelsToProtoCard(it, s)
+		if err != nil {
+			return nil, err
+		}
+		return CardToItem(c), nil
+	case *OTP:
+		return ModelsToProtoOTP(it, s)
+	}
+	return nil, ErrUnknownItemType
+}
+
+// OpenItem converts proto Item to the local item of its type. Payload is opened with the passed Sealer.
+// Tombstone is returned with DeletedAt set and without the payload.
+func OpenItem(in *pb.Item, s Sealer) (Item, error) {
+	var (
+		it  Item
+		err error
+	)
+	switch in.GetType() {
+	case "pair":
+		var p *Pair
+		if p, err = ProtoToModelsPair(ItemToPair(in), s); err == nil {
+			it = p
+		}
+	case "text":
+		var t *Text
+		if t, err = ProtoToModelsText(ItemToText(in), s); err == nil {
+			it = t
+		}
+	case "bin":
+		var b *Bin
+		if b, err = ProtoToModelsBin(ItemToBin(in), s); err == nil {
+			it = b
+		}
+	case "card":
+		var c *Card
+		if c, err = ProtoToModelsCard(ItemToCard(in), s); err == nil {
+			it = c
+		}
+	case "otp":
+		var o *OTP
+		if o, err = ProtoToModelsOTP(in, s); err == nil {
+			it = o
+		}
+	default:
+		err = ErrUnknownItemType
+	}
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// ItemVersionsToProto converts database item versions to proto ItemVersion structures.
+func ItemVersionsToProto(in []*ItemVersion) []*pb.ItemVersion {
+	out := make([]*pb.ItemVersion, 0, len(in))
+	for _, v := range in {
+		iv := &pb.ItemVersion{
+			Version: v.Version,
+			Deleted: v.Tombstone,
+			Size:    uint64(v.Size),
+			Title:   v.Title,
+		}
+		if v.DeletedAt.Valid {
+			iv.DeletedAt = v.DeletedAt.Time.Unix()
+		}
+		out = append(out, iv)
+	}
+
+	return out
+}
+
+// ActualDataToProto converts local data structures, used for DB interactions, to gRPC proto structures.
 func ActualDataToProto(in *ActualData) *ActualProtoData {
 	out := new(ActualProtoData)
 	for _, v := range in.Pairs {
-		out.Pairs = append(out.Pairs, ModelsToProtoPair(v))
+		out.Pairs = append(out.Pairs, SealedToProtoPair(v))
 	}
 
 	for _, v := range in.Texts {
-		out.Texts = append(out.Texts, ModelsToProtoText(v))
+		out.Texts = append(out.Texts, SealedToProtoText(v))
 	}
 
 	for _, v := range in.Bins {
-		out.Bins = append(out.Bins, ModelsToProtoBin(v))
+		out.Bins = append(out.Bins, SealedToProtoBin(v))
 	}
 
 	for _, v := range in.Cards {
-		out.Cards = append(out.Cards, ModelsToProtoCard(v))
+		out.Cards = append(out.Cards, SealedToProtoCard(v))
+	}
+
+	for _, v := range in.OTPs {
+		out.Items = append(out.Items, SealedToProtoItem("otp", v))
 	}
 
 	return out
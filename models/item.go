@@ -0,0 +1,199 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+)
+
+var (
+	ErrUnknownItemType = errors.New("unknown item type")
+
+	// ItemTypes are the item types, in the order they are handled.
+	ItemTypes = []string{"pair", "text", "bin", "card", "otp"}
+)
+
+// ValidItemType reports, if the item type is known.
+func ValidItemType(dataType string) bool {
+	for _, t := range ItemTypes {
+		if t == dataType {
+			return true
+		}
+	}
+	return false
+}
+
+// Item is a local item of any type: Pair, Text, Bin, Card or OTP.
+type Item interface {
+	Head() ItemHead
+}
+
+// ItemHead is the type independent part of the local item.
+type ItemHead struct {
+	Type    string   `json:"type"`
+	ItemID  string   `json:"id"`
+	Title   string   `json:"title"`
+	Version uint32   `json:"version"`
+	Deleted bool     `json:"deleted,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+func (p *Pair) Head() ItemHead {
+	return ItemHead{Type: "pair", ItemID: p.ItemID, Title: p.Title, Version: p.Version, Deleted: p.DeletedAt.Valid,
+		Tags: p.Tags}
+}
+
+func (t *Text) Head() ItemHead {
+	return ItemHead{Type: "text", ItemID: t.ItemID, Title: t.Title, Version: t.Version, Deleted: t.DeletedAt.Valid,
+		Tags: t.Tags}
+}
+
+func (b *Bin) Head() ItemHead {
+	return ItemHead{Type: "bin", ItemID: b.ItemID, Title: b.Title, Version: b.Version, Deleted: b.DeletedAt.Valid,
+		Tags: b.Tags}
+}
+
+func (c *Card) Head() ItemHead {
+	return ItemHead{Type: "card", ItemID: c.ItemID, Title: c.Title, Version: c.Version, Deleted: c.DeletedAt.Valid,
+		Tags: c.Tags}
+}
+
+func (o *OTP) Head() ItemHead {
+	return ItemHead{Type: "otp", ItemID: o.ItemID, Title: o.Title, Version: o.Version, Deleted: o.DeletedAt.Valid,
+		Tags: o.Tags}
+}
+
+// NewTombstone returns the local item of the type, deleted in the version.
+func NewTombstone(dataType, id, title string, version uint32) (Item, error) {
+	deleted := sql.NullTime{Valid: true}
+	switch dataType {
+	case "pair":
+		return &Pair{ItemID: id, Title: title, Version: version, DeletedAt: deleted}, nil
+	case "text":
+		return &Text{ItemID: id, Title: title, Version: version, DeletedAt: deleted}, nil
+	case "bin":
+		return &Bin{ItemID: id, Title: title, Version: version, DeletedAt: deleted}, nil
+	case "card":
+		return &Card{ItemID: id, Title: title, Version: version, DeletedAt: deleted}, nil
+	case "otp":
+		return &OTP{ItemID: id, Title: title, Version: version, DeletedAt: deleted}, nil
+	}
+	return nil, ErrUnknownItemType
+}
+
+// WithVersion returns the copy of the item with the version: the item payload is sealed for the version.
+func WithVersion(it Item, version uint32) Item {
+	switch item := it.(type) {
+	case *Pair:
+		c := *item
+		c.Version = version
+		return &c
+	case *Text:
+		c := *item
+		c.Version = version
+		return &c
+	case *Bin:
+		c := *item
+		c.Version = version
+		return &c
+	case *Card:
+		c := *item
+		c.Version = version
+		return &c
+	case *OTP:
+		c := *item
+		c.Version = version
+		return &c
+	}
+	return it
+}
+
+// Item returns the vault item of the type by id. False, if there is no such item.
+func (v *Vault) Item(dataType, id string) (Item, bool) {
+	switch dataType {
+	case "pair":
+		if p, ok := v.Pair[id]; ok {
+			return p, true
+		}
+	case "text":
+		if t, ok := v.Text[id]; ok {
+			return t, true
+		}
+	case "bin":
+		if b, ok := v.Bin[id]; ok {
+			return b, true
+		}
+	case "card":
+		if c, ok := v.Card[id]; ok {
+			return c, true
+		}
+	case "otp":
+		if o, ok := v.OTP[id]; ok {
+			return o, true
+		}
+	}
+	return nil, false
+}
+
+// Items returns the vault items of the type, tombstones included.
+func (v *Vault) Items(dataType string) []Item {
+	var items []Item
+	switch dataType {
+	case "pair":
+		for _, p := range v.Pair {
+			items = append(items, p)
+		}
+	case "text":
+		for _, t := range v.Text {
+			items = append(items, t)
+		}
+	case "bin":
+		for _, b := range v.Bin {
+			items = append(items, b)
+		}
+	case "card":
+		for _, c := range v.Card {
+			items = append(items, c)
+		}
+	case "otp":
+		for _, o := range v.OTP {
+			items = append(items, o)
+		}
+	}
+	return items
+}
+
+// PutItem puts the item to the vault by its id. The previous version is replaced.
+func (v *Vault) PutItem(it Item) {
+	switch item := it.(type) {
+	case *Pair:
+		v.Pair[item.ItemID] = item
+	case *Text:
+		v.Text[item.ItemID] = item
+	case *Bin:
+		v.Bin[item.ItemID] = item
+	case *Card:
+		v.Card[item.ItemID] = item
+	case *OTP:
+		if v.OTP == nil {
+			// vaults, saved before the type
+			v.OTP = make(map[string]*OTP)
+		}
+		v.OTP[item.ItemID] = item
+	}
+}
+
+// RemoveItem removes the item from the vault.
+func (v *Vault) RemoveItem(dataType, id string) {
+	switch dataType {
+	case "pair":
+		delete(v.Pair, id)
+	case "text":
+		delete(v.Text, id)
+	case "bin":
+		delete(v.Bin, id)
+	case "card":
+		delete(v.Card, id)
+	case "otp":
+		delete(v.OTP, id)
+	}
+}
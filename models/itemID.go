@@ -0,0 +1,49 @@
+package models
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// NewItemID generates a new item id: random UUID (version 4).
+func NewItemID() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return ``, err
+	}
+	u[6] = u[6]&0x0f | 0x40 // version 4
+	u[8] = u[8]&0x3f | 0x80 // RFC 4122 variant
+	return formatUUID(u), nil
+}
+
+// LegacyItemID returns the id of the item, saved before the item ids: md5 of "<type>:<title>" as UUID.
+// Server derives the same ids for the existing items, see the item_id migration, so the devices agree on them.
+func LegacyItemID(dataType, title string) string {
+	return formatUUID(md5.Sum([]byte(dataType + ":" + title)))
+}
+
+// ValidItemID reports, if id is a UUID in the canonical form.
+func ValidItemID(id string) bool {
+	if len(id) != 36 {
+		return false
+	}
+	for i, c := range id {
+		switch {
+		case i == 8 || i == 13 || i == 18 || i == 23:
+			if c != '-' {
+				return false
+			}
+		case (c < '0' || c > '9') && (c < 'a' || c > 'f'):
+			return false
+		}
+	}
+	return true
+}
+
+// formatUUID formats the UUID bytes in the canonical form: lowercase hex, hyphen separated.
+func formatUUID(u [16]byte) string {
+	h := hex.EncodeToString(u[:])
+	return fmt.Sprintf("%s-%s-%s-%s-%s", h[:8], h[8:12], h[12:16], h[16:20], h[20:])
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// matchSubstring is the lowest score of the text, containing the query. Fuzzy matches score below.
+	matchSubstring = 1000
+)
+
+// Match reports, if the text matches the query, and scores the match: the higher, the better. The case is ignored.
+// The text, containing the query, is matched best - the closer to the start, the better. Fuzzy match finds
+// the query letters in the text in order, with others in between - the fewer there are, the better.
+// Empty query matches any text.
+func Match(query, text string, fuzzy bool) (score int, ok bool) {
+	query, text = strings.ToLower(strings.TrimSpace(query)), strings.ToLower(text)
+	if query == `` {
+		return matchSubstring, true
+	}
+	if i := strings.Index(text, query); i >= 0 {
+		return matchSubstring + max(matchSubstring-utf8.RuneCountInString(text[:i]), 0), true
+	}
+	if !fuzzy {
+		return 0, false
+	}
+
+	// each query letter is matched by the first one in the rest of the text.
+	gaps, started, rest := 0, false, text
+	for _, q := range query {
+		i := strings.IndexRune(rest, q)
+		if i < 0 {
+			return 0, false
+		}
+		if started {
+			gaps += utf8.RuneCountInString(rest[:i])
+		}
+		started = true
+		rest = rest[i+utf8.RuneLen(q):]
+	}
+	return max(matchSubstring-1-gaps, 1), true
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
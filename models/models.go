@@ -3,75 +3,200 @@ package models
 import (
 	"database/sql"
 	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"time"
 )
 
 // User is a local struct for database interactions. Table gophkeeper_users.
 type User struct {
-	ID       int    `json:"id"`
-	Login    string `json:"login"`
-	Password string `json:"password"`
+	ID           int            `json:"id"`
+	Login        string         `json:"login"`
+	PasswordHash string         `json:"password_hash"`
+	KDFParams    sql.NullString `json:"kdf_params" db:"kdf_params"` // vault key derivation parameters, set by the client.
+	KDFCheck     []byte         `json:"kdf_check" db:"kdf_check"`   // sealed known value to verify the master password.
 }
 
-// Pair is a local struct for database interactions. Table gk_pair.
+// Session is a local struct for database interactions. Table gk_session.
+// RefreshHash keeps only sha256 of the refresh token, the token itself is known only to the client.
+// PreviousRefreshHash is sha256 of the rotated refresh token.
+type Session struct {
+	ID                  int            `json:"id"`
+	UserID              int            `json:"user_id"`
+	RefreshHash         string         `json:"refresh_hash"`
+	PreviousRefreshHash sql.NullString `json:"previous_refresh_hash"`
+	CreatedAt           time.Time      `json:"created_at"`
+	ExpiresAt           time.Time      `json:"expires_at"`
+	RevokedAt           sql.NullTime   `json:"revoked_at"`
+}
+
+// Sealed is a local struct for database interactions. Tables gk_pair, gk_text, gk_bin, gk_card, gk_otp.
+// Server knows only the item id, title and version, the data is sealed by the client.
+type Sealed struct {
+	ID        int          `json:"id"`
+	ItemID    string       `json:"item_id"` // item id, generated by the client. Shared by all the item versions.
+	UserID    int          `json:"user_id"`
+	Title     string       `json:"title"`
+	Payload   []byte       `json:"payload"`
+	Version   uint32       `json:"version"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+	Streamed  bool         `json:"streamed"` // gk_bin only: the sealed body is kept apart from the payload.
+	Size      int64        `json:"size"`     // gk_bin only: streamed sealed body size.
+	// gk_bin only: blob store hash of the payload. Payload is read from the blob store, when set.
+	PayloadHash sql.NullString `json:"-"`
+}
+
+// Legacy is the latest plaintext version of the item, saved before the items were sealed on the client side.
+// The fields of the other item types are empty.
+type Legacy struct {
+	Type           string `json:"type"`
+	ItemID         string `json:"item_id"`
+	Title          string `json:"title"`
+	Version        uint32 `json:"version"`
+	Login          string `json:"login"`           // pair.
+	Pass           string `json:"pass"`            // pair.
+	Body           string `json:"body"`            // text.
+	BinBody        []byte `json:"bin_body"`        // bin.
+	Number         string `json:"number"`          // card.
+	ExpirationDate string `json:"expiration_date"` // card.
+	Comment        string `json:"comment"`
+}
+
+// Pair is a local struct for client interactions. Sealed to gk_pair payload.
 type Pair struct {
 	ID        int          `json:"id"`
+	ItemID    string       `json:"item_id"`
 	UserID    int          `json:"user_id"`
 	Title     string       `json:"title"`
 	Login     string       `json:"login"`
 	Pass      string       `json:"pass"`
 	Comment   string       `json:"comment"`
+	URLs      []string     `json:"urls,omitempty"` // sites, the login is used on.
+	Fields    []Field      `json:"fields,omitempty"`
+	Tags      []string     `json:"tags,omitempty"`
 	Version   uint32       `json:"version"`
 	DeletedAt sql.NullTime `json:"deleted_at"`
 }
 
-// Text is a local struct for database interactions. Table gk_text.
+// Text is a local struct for client interactions. Sealed to gk_text payload.
 type Text struct {
 	ID        int          `json:"id"`
+	ItemID    string       `json:"item_id"`
 	UserID    int          `json:"user_id"`
 	Title     string       `json:"title"`
 	Body      string       `json:"body"`
 	Comment   string       `json:"comment"`
+	Fields    []Field      `json:"fields,omitempty"`
+	Tags      []string     `json:"tags,omitempty"`
 	Version   uint32       `json:"version"`
 	DeletedAt sql.NullTime `json:"deleted_at"`
 }
 
-// Bin is a local struct for database interactions. Table gk_bin.
+// Bin is a local struct for client interactions. Sealed to gk_bin payload.
 type Bin struct {
 	ID        int          `json:"id"`
+	ItemID    string       `json:"item_id"`
 	UserID    int          `json:"user_id"`
 	Title     string       `json:"title"`
 	Body      []byte       `json:"body"`
 	Comment   string       `json:"comment"`
+	Fields    []Field      `json:"fields,omitempty"`
+	Tags      []string     `json:"tags,omitempty"`
 	Version   uint32       `json:"version"`
 	DeletedAt sql.NullTime `json:"deleted_at"`
+	Streamed  bool         `json:"streamed"` // body is kept on the server only, see UploadBin/DownloadBin.
+	Size      int64        `json:"size"`     // streamed sealed body size.
+	// title, the streamed body was sealed with. Empty for the bodies, sealed with the item id.
+	StreamTitle string `json:"stream_title,omitempty"`
 }
 
-// Card is a local struct for database interactions. Table gk_card.
+// Card is a local struct for client interactions. Sealed to gk_card payload.
 type Card struct {
 	ID             int          `json:"id"`
+	ItemID         string       `json:"item_id"`
 	UserID         int          `json:"user_id"`
 	Title          string       `json:"title"`
 	Number         string       `json:"number"`
 	ExpirationDate string       `json:"expiration_date"`
 	Comment        string       `json:"comment"`
+	Fields         []Field      `json:"fields,omitempty"`
+	Tags           []string     `json:"tags,omitempty"`
 	Version        uint32       `json:"version"`
 	DeletedAt      sql.NullTime `json:"deleted_at"`
 }
 
-// Vault is a local struct for client interactions. Mostly for easy and fast search.
+// OTP is a local struct for client interactions: the one-time password generator seed. Sealed to gk_otp payload.
+type OTP struct {
+	ID        int          `json:"id"`
+	ItemID    string       `json:"item_id"`
+	UserID    int          `json:"user_id"`
+	Title     string       `json:"title"`
+	Kind      string       `json:"kind"`   // totp or hotp.
+	Secret    string       `json:"secret"` // base32 encoded, as in the otpauth URI.
+	Issuer    string       `json:"issuer"`
+	Account   string       `json:"account"`
+	Algorithm string       `json:"algorithm"` // SHA1, SHA256 or SHA512.
+	Digits    int          `json:"digits"`
+	Period    int          `json:"period"`  // totp only: code lifetime, seconds.
+	Counter   uint64       `json:"counter"` // hotp only: the counter of the next code.
+	Comment   string       `json:"comment"`
+	Fields    []Field      `json:"fields,omitempty"`
+	Tags      []string     `json:"tags,omitempty"`
+	Version   uint32       `json:"version"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+// Field is a custom field of the item: a security question, PIN, URL or any other value, the fixed fields don't cover.
+// Sealed to the item payload together with the fixed fields.
+type Field struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"` // text, hidden, url or date.
+	Value string `json:"value"`
+}
+
+// Vault is a local struct for client interactions. Mostly for easy and fast search. Items are kept by the item id.
 type Vault struct {
-	Pair map[string]*Pair `json:"pair"`
-	Text map[string]*Text `json:"text"`
-	Bin  map[string]*Bin  `json:"bin"`
-	Card map[string]*Card `json:"card"`
+	Pair      map[string]*Pair `json:"pair"`
+	Text      map[string]*Text `json:"text"`
+	Bin       map[string]*Bin  `json:"bin"`
+	Card      map[string]*Card `json:"card"`
+	OTP       map[string]*OTP  `json:"otp"`
+	Cursor    int64            `json:"cursor"`              // server change cursor of the last synchronization.
+	Conflicts []*Conflict      `json:"conflicts,omitempty"` // concurrent changes, that could not be merged.
+	// the plaintext items, kept by the server, are sealed, see service.SealLegacyItems.
+	LegacySealed bool `json:"legacy_sealed,omitempty"`
+}
+
+// Conflict is a local change, made concurrently with a change on another device, that could not be merged.
+// The item keeps the remote version. The local content is kept as a separate item - the conflict copy.
+type Conflict struct {
+	Type          string    `json:"type"` // pair, text, bin, card or otp.
+	ItemID        string    `json:"item_id"`
+	Title         string    `json:"title"`
+	LocalTitle    string    `json:"local_title,omitempty"` // title of the local change, if it differs from the remote one.
+	CopyID        string    `json:"copy_id"`               // item id of the conflict copy.
+	CopyTitle     string    `json:"copy_title"`            // title of the conflict copy. Empty, if the local change is a deletion.
+	LocalDeleted  bool      `json:"local_deleted"`         // item was deleted locally and changed remotely.
+	BaseVersion   uint32    `json:"base_version"`          // version both changes were made from.
+	RemoteVersion uint32    `json:"remote_version"`
+	Fields        []string  `json:"fields"` // fields, changed on both sides.
+	DetectedAt    time.Time `json:"detected_at"`
+}
+
+// ItemVersion is a local struct for database interactions: a stored version of the item, without the data.
+type ItemVersion struct {
+	Version   uint32       `json:"version"`
+	Title     string       `json:"title"`
+	DeletedAt sql.NullTime `json:"deleted_at"` // all the versions of the deleted item are marked.
+	Tombstone bool         `json:"tombstone"`  // the item was deleted in this version.
+	Size      int64        `json:"size"`       // sealed data size. Streamed body is included.
 }
 
 // ActualData is a local struct for database interactions. Unites all data.
 type ActualData struct {
-	Pairs []*Pair `json:"pairs"`
-	Texts []*Text `json:"texts"`
-	Bins  []*Bin  `json:"bins"`
-	Cards []*Card `json:"cards"`
+	Pairs []*Sealed `json:"pairs"`
+	Texts []*Sealed `json:"texts"`
+	Bins  []*Sealed `json:"bins"`
+	Cards []*Sealed `json:"cards"`
+	OTPs  []*Sealed `json:"otps"`
 }
 
 // VaultProto is a structure for client local data operations.
@@ -88,4 +213,5 @@ type ActualProtoData struct {
 	Texts []*pb.Text
 	Bins  []*pb.Bin
 	Cards []*pb.Card
+	Items []*pb.Item // items of the types without the typed message: otp.
 }
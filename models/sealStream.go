@@ -0,0 +1,139 @@
+package models
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// StreamChunkSize is the plaintext size of one sealed stream chunk.
+	StreamChunkSize = 64 * 1024
+
+	streamLastMark = 1 << 31
+	// streamMaxSealed limits the sealed chunk size: chunk plus the Sealer nonce and tag.
+	streamMaxSealed = StreamChunkSize + 1024
+)
+
+var (
+	ErrStreamTruncated = errors.New("sealed stream is truncated")
+	ErrStreamMalformed = errors.New("sealed stream is malformed")
+)
+
+// SealStream reads the plaintext from src, seals it by chunks and writes them to dst. Returns the plaintext size.
+// Each chunk is sealed separately and bound to the item, its position and the end mark, so the chunks can't be
+// reordered, removed or cut off. Format: [4 bytes big endian: end mark bit, sealed chunk length][sealed chunk]...
+// The last chunk (possibly empty) has the end mark. The stream is bound to the item type and id.
+func SealStream(s Sealer, dataType, id string, dst io.Writer, src io.Reader) (int64, error) {
+	buf, next := make([]byte, StreamChunkSize), make([]byte, StreamChunkSize)
+	var total int64
+
+	n, eof, err := readChunk(src, buf)
+	for index := uint32(0); ; index++ {
+		if err != nil {
+			return total, err
+		}
+
+		// the chunk is the last one, if the source ends with it.
+		m, nextEOF := 0, true
+		if !eof {
+			if m, nextEOF, err = readChunk(src, next); err != nil {
+				return total, err
+			}
+		}
+		last := eof || (m == 0 && nextEOF)
+
+		sealed, err := s.Seal(buf[:n], streamAD(itemAD(dataType, id), index, last))
+		if err != nil {
+			return total, err
+		}
+		header := uint32(len(sealed))
+		if last {
+			header |= streamLastMark
+		}
+		if err = binary.Write(dst, binary.BigEndian, header); err != nil {
+			return total, err
+		}
+		if _, err = dst.Write(sealed); err != nil {
+			return total, err
+		}
+		total += int64(n)
+
+		if last {
+			return total, nil
+		}
+		buf, next = next, buf
+		n, eof = m, nextEOF
+	}
+}
+
+// OpenStream reads the sealed chunks from src, see SealStream, opens them and writes the plaintext to dst.
+// Returns the plaintext size. The stream must end with the end mark chunk. legacyTitle is the title, the stream
+// was bound to before the item ids (see Bin.StreamTitle), empty - the stream is bound to the id.
+func OpenStream(s Sealer, dataType, id, legacyTitle string, dst io.Writer, src io.Reader) (int64, error) {
+	sealed := make([]byte, streamMaxSealed)
+	var total int64
+
+	item := itemAD(dataType, id)
+	if legacyTitle != `` {
+		item = legacyAD(dataType, legacyTitle)
+	}
+
+	for index := uint32(0); ; index++ {
+		var header uint32
+		if err := binary.Read(src, binary.BigEndian, &header); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return total, ErrStreamTruncated
+			}
+			return total, err
+		}
+
+		last := header&streamLastMark != 0
+		size := header &^ streamLastMark
+		if size > streamMaxSealed {
+			return total, ErrStreamMalformed
+		}
+		if _, err := io.ReadFull(src, sealed[:size]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return total, ErrStreamTruncated
+			}
+			return total, err
+		}
+
+		plain, err := s.Open(sealed[:size], streamAD(item, index, last))
+		if err != nil {
+			return total, err
+		}
+		if _, err = dst.Write(plain); err != nil {
+			return total, err
+		}
+		total += int64(len(plain))
+
+		if last {
+			// nothing is expected after the end mark.
+			if n, _ := src.Read(sealed[:1]); n != 0 {
+				return total, ErrStreamMalformed
+			}
+			return total, nil
+		}
+	}
+}
+
+// readChunk fills buf from r. eof reports, that r has ended.
+func readChunk(r io.Reader, buf []byte) (n int, eof bool, err error) {
+	n, err = io.ReadFull(r, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return n, true, nil
+	}
+	return n, false, err
+}
+
+// streamAD returns the additional data of the stream chunk. item is the additional data of the item.
+func streamAD(item string, index uint32, last bool) []byte {
+	ad := fmt.Sprintf("%s:%d", item, index)
+	if last {
+		ad += ":last"
+	}
+	return []byte(ad)
+}
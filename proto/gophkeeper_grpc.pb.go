@@ -24,6 +24,9 @@ const _ = grpc.SupportPackageIsVersion7
 type KeeperClient interface {
 	RegisterUser(ctx context.Context, in *RegisterUserRequest, opts ...grpc.CallOption) (*RegisterUserResponse, error)
 	LoginUser(ctx context.Context, in *LoginUserRequest, opts ...grpc.CallOption) (*LoginUserResponse, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+	SetVaultKDF(ctx context.Context, in *SetVaultKDFRequest, opts ...grpc.CallOption) (*SetVaultKDFResponse, error)
 	GetPair(ctx context.Context, in *GetPairRequest, opts ...grpc.CallOption) (*GetPairResponse, error)
 	PostPair(ctx context.Context, in *PostPairRequest, opts ...grpc.CallOption) (*PostPairResponse, error)
 	DelPair(ctx context.Context, in *DelPairRequest, opts ...grpc.CallOption) (*DelPairResponse, error)
@@ -33,10 +36,22 @@ type KeeperClient interface {
 	GetBin(ctx context.Context, in *GetBinRequest, opts ...grpc.CallOption) (*GetBinResponse, error)
 	PostBin(ctx context.Context, in *PostBinRequest, opts ...grpc.CallOption) (*PostBinResponse, error)
 	DelBin(ctx context.Context, in *DelBinRequest, opts ...grpc.CallOption) (*DelBinResponse, error)
+	UploadBin(ctx context.Context, opts ...grpc.CallOption) (Keeper_UploadBinClient, error)
+	DownloadBin(ctx context.Context, in *DownloadBinRequest, opts ...grpc.CallOption) (Keeper_DownloadBinClient, error)
 	GetCard(ctx context.Context, in *GetCardRequest, opts ...grpc.CallOption) (*GetCardResponse, error)
 	PostCard(ctx context.Context, in *PostCardRequest, opts ...grpc.CallOption) (*PostCardResponse, error)
 	DelCard(ctx context.Context, in *DelCardRequest, opts ...grpc.CallOption) (*DelCardResponse, error)
+	GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*GetItemResponse, error)
+	PutItem(ctx context.Context, in *PutItemRequest, opts ...grpc.CallOption) (*PutItemResponse, error)
+	DeleteItem(ctx context.Context, in *DeleteItemRequest, opts ...grpc.CallOption) (*DeleteItemResponse, error)
+	ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (*ListItemsResponse, error)
+	SearchItems(ctx context.Context, in *SearchItemsRequest, opts ...grpc.CallOption) (*SearchItemsResponse, error)
 	SyncVault(ctx context.Context, in *SyncVaultRequest, opts ...grpc.CallOption) (*SyncVaultResponse, error)
+	ListVersions(ctx context.Context, in *ListVersionsRequest, opts ...grpc.CallOption) (*ListVersionsResponse, error)
+	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
+	RestoreItem(ctx context.Context, in *RestoreItemRequest, opts ...grpc.CallOption) (*RestoreItemResponse, error)
+	PurgeItem(ctx context.Context, in *PurgeItemRequest, opts ...grpc.CallOption) (*PurgeItemResponse, error)
+	LegacyItems(ctx context.Context, in *LegacyItemsRequest, opts ...grpc.CallOption) (Keeper_LegacyItemsClient, error)
 }
 
 type keeperClient struct {
@@ -65,6 +80,33 @@ func (c *keeperClient) LoginUser(ctx context.Context, in *LoginUserRequest, opts
 	return out, nil
 }
 
+func (c *keeperClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error) {
+	out := new(RefreshTokenResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/RefreshToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error) {
+	out := new(LogoutResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/Logout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperClient) SetVaultKDF(ctx context.Context, in *SetVaultKDFRequest, opts ...grpc.CallOption) (*SetVaultKDFResponse, error) {
+	out := new(SetVaultKDFResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/SetVaultKDF", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *keeperClient) GetPair(ctx context.Context, in *GetPairRequest, opts ...grpc.CallOption) (*GetPairResponse, error) {
 	out := new(GetPairResponse)
 	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/GetPair", in, out, opts...)
@@ -146,6 +188,72 @@ func (c *keeperClient) DelBin(ctx context.Context, in *DelBinRequest, opts ...gr
 	return out, nil
 }
 
+func (c *keeperClient) UploadBin(ctx context.Context, opts ...grpc.CallOption) (Keeper_UploadBinClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Keeper_ServiceDesc.Streams[0], "/gophkeeper.proto.Keeper/UploadBin", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &keeperUploadBinClient{stream}
+	return x, nil
+}
+
+type Keeper_UploadBinClient interface {
+	Send(*UploadBinRequest) error
+	CloseAndRecv() (*UploadBinResponse, error)
+	grpc.ClientStream
+}
+
+type keeperUploadBinClient struct {
+	grpc.ClientStream
+}
+
+func (x *keeperUploadBinClient) Send(m *UploadBinRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *keeperUploadBinClient) CloseAndRecv() (*UploadBinResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadBinResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *keeperClient) DownloadBin(ctx context.Context, in *DownloadBinRequest, opts ...grpc.CallOption) (Keeper_DownloadBinClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Keeper_ServiceDesc.Streams[1], "/gophkeeper.proto.Keeper/DownloadBin", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &keeperDownloadBinClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Keeper_DownloadBinClient interface {
+	Recv() (*DownloadBinResponse, error)
+	grpc.ClientStream
+}
+
+type keeperDownloadBinClient struct {
+	grpc.ClientStream
+}
+
+func (x *keeperDownloadBinClient) Recv() (*DownloadBinResponse, error) {
+	m := new(DownloadBinResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *keeperClient) GetCard(ctx context.Context, in *GetCardRequest, opts ...grpc.CallOption) (*GetCardResponse, error) {
 	out := new(GetCardResponse)
 	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/GetCard", in, out, opts...)
@@ -173,6 +281,51 @@ func (c *keeperClient) DelCard(ctx context.Context, in *DelCardRequest, opts ...
 	return out, nil
 }
 
+func (c *keeperClient) GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*GetItemResponse, error) {
+	out := new(GetItemResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/GetItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperClient) PutItem(ctx context.Context, in *PutItemRequest, opts ...grpc.CallOption) (*PutItemResponse, error) {
+	out := new(PutItemResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/PutItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperClient) DeleteItem(ctx context.Context, in *DeleteItemRequest, opts ...grpc.CallOption) (*DeleteItemResponse, error) {
+	out := new(DeleteItemResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/DeleteItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperClient) ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (*ListItemsResponse, error) {
+	out := new(ListItemsResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/ListItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperClient) SearchItems(ctx context.Context, in *SearchItemsRequest, opts ...grpc.CallOption) (*SearchItemsResponse, error) {
+	out := new(SearchItemsResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/SearchItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *keeperClient) SyncVault(ctx context.Context, in *SyncVaultRequest, opts ...grpc.CallOption) (*SyncVaultResponse, error) {
 	out := new(SyncVaultResponse)
 	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/SyncVault", in, out, opts...)
@@ -182,12 +335,83 @@ func (c *keeperClient) SyncVault(ctx context.Context, in *SyncVaultRequest, opts
 	return out, nil
 }
 
+func (c *keeperClient) ListVersions(ctx context.Context, in *ListVersionsRequest, opts ...grpc.CallOption) (*ListVersionsResponse, error) {
+	out := new(ListVersionsResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/ListVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error) {
+	out := new(GetVersionResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/GetVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperClient) RestoreItem(ctx context.Context, in *RestoreItemRequest, opts ...grpc.CallOption) (*RestoreItemResponse, error) {
+	out := new(RestoreItemResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/RestoreItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperClient) PurgeItem(ctx context.Context, in *PurgeItemRequest, opts ...grpc.CallOption) (*PurgeItemResponse, error) {
+	out := new(PurgeItemResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.proto.Keeper/PurgeItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperClient) LegacyItems(ctx context.Context, in *LegacyItemsRequest, opts ...grpc.CallOption) (Keeper_LegacyItemsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Keeper_ServiceDesc.Streams[2], "/gophkeeper.proto.Keeper/LegacyItems", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &keeperLegacyItemsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Keeper_LegacyItemsClient interface {
+	Recv() (*LegacyItemsResponse, error)
+	grpc.ClientStream
+}
+
+type keeperLegacyItemsClient struct {
+	grpc.ClientStream
+}
+
+func (x *keeperLegacyItemsClient) Recv() (*LegacyItemsResponse, error) {
+	m := new(LegacyItemsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // KeeperServer is the server API for Keeper service.
 // All implementations must embed UnimplementedKeeperServer
 // for forward compatibility
 type KeeperServer interface {
 	RegisterUser(context.Context, *RegisterUserRequest) (*RegisterUserResponse, error)
 	LoginUser(context.Context, *LoginUserRequest) (*LoginUserResponse, error)
+	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	SetVaultKDF(context.Context, *SetVaultKDFRequest) (*SetVaultKDFResponse, error)
 	GetPair(context.Context, *GetPairRequest) (*GetPairResponse, error)
 	PostPair(context.Context, *PostPairRequest) (*PostPairResponse, error)
 	DelPair(context.Context, *DelPairRequest) (*DelPairResponse, error)
@@ -197,10 +421,22 @@ type KeeperServer interface {
 	GetBin(context.Context, *GetBinRequest) (*GetBinResponse, error)
 	PostBin(context.Context, *PostBinRequest) (*PostBinResponse, error)
 	DelBin(context.Context, *DelBinRequest) (*DelBinResponse, error)
+	UploadBin(Keeper_UploadBinServer) error
+	DownloadBin(*DownloadBinRequest, Keeper_DownloadBinServer) error
 	GetCard(context.Context, *GetCardRequest) (*GetCardResponse, error)
 	PostCard(context.Context, *PostCardRequest) (*PostCardResponse, error)
 	DelCard(context.Context, *DelCardRequest) (*DelCardResponse, error)
+	GetItem(context.Context, *GetItemRequest) (*GetItemResponse, error)
+	PutItem(context.Context, *PutItemRequest) (*PutItemResponse, error)
+	DeleteItem(context.Context, *DeleteItemRequest) (*DeleteItemResponse, error)
+	ListItems(context.Context, *ListItemsRequest) (*ListItemsResponse, error)
+	SearchItems(context.Context, *SearchItemsRequest) (*SearchItemsResponse, error)
 	SyncVault(context.Context, *SyncVaultRequest) (*SyncVaultResponse, error)
+	ListVersions(context.Context, *ListVersionsRequest) (*ListVersionsResponse, error)
+	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
+	RestoreItem(context.Context, *RestoreItemRequest) (*RestoreItemResponse, error)
+	PurgeItem(context.Context, *PurgeItemRequest) (*PurgeItemResponse, error)
+	LegacyItems(*LegacyItemsRequest, Keeper_LegacyItemsServer) error
 	mustEmbedUnimplementedKeeperServer()
 }
 
@@ -214,6 +450,15 @@ func (UnimplementedKeeperServer) RegisterUser(context.Context, *RegisterUserRequ
 func (UnimplementedKeeperServer) LoginUser(context.Context, *LoginUserRequest) (*LoginUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LoginUser not implemented")
 }
+func (UnimplementedKeeperServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedKeeperServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedKeeperServer) SetVaultKDF(context.Context, *SetVaultKDFRequest) (*SetVaultKDFResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVaultKDF not implemented")
+}
 func (UnimplementedKeeperServer) GetPair(context.Context, *GetPairRequest) (*GetPairResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPair not implemented")
 }
@@ -241,6 +486,12 @@ func (UnimplementedKeeperServer) PostBin(context.Context, *PostBinRequest) (*Pos
 func (UnimplementedKeeperServer) DelBin(context.Context, *DelBinRequest) (*DelBinResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DelBin not implemented")
 }
+func (UnimplementedKeeperServer) UploadBin(Keeper_UploadBinServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadBin not implemented")
+}
+func (UnimplementedKeeperServer) DownloadBin(*DownloadBinRequest, Keeper_DownloadBinServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadBin not implemented")
+}
 func (UnimplementedKeeperServer) GetCard(context.Context, *GetCardRequest) (*GetCardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCard not implemented")
 }
@@ -250,9 +501,39 @@ func (UnimplementedKeeperServer) PostCard(context.Context, *PostCardRequest) (*P
 func (UnimplementedKeeperServer) DelCard(context.Context, *DelCardRequest) (*DelCardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DelCard not implemented")
 }
+func (UnimplementedKeeperServer) GetItem(context.Context, *GetItemRequest) (*GetItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItem not implemented")
+}
+func (UnimplementedKeeperServer) PutItem(context.Context, *PutItemRequest) (*PutItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutItem not implemented")
+}
+func (UnimplementedKeeperServer) DeleteItem(context.Context, *DeleteItemRequest) (*DeleteItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteItem not implemented")
+}
+func (UnimplementedKeeperServer) ListItems(context.Context, *ListItemsRequest) (*ListItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListItems not implemented")
+}
+func (UnimplementedKeeperServer) SearchItems(context.Context, *SearchItemsRequest) (*SearchItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchItems not implemented")
+}
 func (UnimplementedKeeperServer) SyncVault(context.Context, *SyncVaultRequest) (*SyncVaultResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SyncVault not implemented")
 }
+func (UnimplementedKeeperServer) ListVersions(context.Context, *ListVersionsRequest) (*ListVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVersions not implemented")
+}
+func (UnimplementedKeeperServer) GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
+func (UnimplementedKeeperServer) RestoreItem(context.Context, *RestoreItemRequest) (*RestoreItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreItem not implemented")
+}
+func (UnimplementedKeeperServer) PurgeItem(context.Context, *PurgeItemRequest) (*PurgeItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeItem not implemented")
+}
+func (UnimplementedKeeperServer) LegacyItems(*LegacyItemsRequest, Keeper_LegacyItemsServer) error {
+	return status.Errorf(codes.Unimplemented, "method LegacyItems not implemented")
+}
 func (UnimplementedKeeperServer) mustEmbedUnimplementedKeeperServer() {}
 
 // UnsafeKeeperServer may be embedded to opt out of forward compatibility for this service.
@@ -302,6 +583,60 @@ func _Keeper_LoginUser_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Keeper_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.proto.Keeper/RefreshToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keeper_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.proto.Keeper/Logout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keeper_SetVaultKDF_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVaultKDFRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).SetVaultKDF(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.proto.Keeper/SetVaultKDF",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).SetVaultKDF(ctx, req.(*SetVaultKDFRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Keeper_GetPair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPairRequest)
 	if err := dec(in); err != nil {
@@ -464,6 +799,53 @@ func _Keeper_DelBin_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Keeper_UploadBin_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(KeeperServer).UploadBin(&keeperUploadBinServer{stream})
+}
+
+type Keeper_UploadBinServer interface {
+	SendAndClose(*UploadBinResponse) error
+	Recv() (*UploadBinRequest, error)
+	grpc.ServerStream
+}
+
+type keeperUploadBinServer struct {
+	grpc.ServerStream
+}
+
+func (x *keeperUploadBinServer) SendAndClose(m *UploadBinResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *keeperUploadBinServer) Recv() (*UploadBinRequest, error) {
+	m := new(UploadBinRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Keeper_DownloadBin_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadBinRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KeeperServer).DownloadBin(m, &keeperDownloadBinServer{stream})
+}
+
+type Keeper_DownloadBinServer interface {
+	Send(*DownloadBinResponse) error
+	grpc.ServerStream
+}
+
+type keeperDownloadBinServer struct {
+	grpc.ServerStream
+}
+
+func (x *keeperDownloadBinServer) Send(m *DownloadBinResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Keeper_GetCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetCardRequest)
 	if err := dec(in); err != nil {
@@ -518,6 +900,96 @@ func _Keeper_DelCard_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Keeper_GetItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).GetItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.proto.Keeper/GetItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).GetItem(ctx, req.(*GetItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keeper_PutItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).PutItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.proto.Keeper/PutItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).PutItem(ctx, req.(*PutItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keeper_DeleteItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).DeleteItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.proto.Keeper/DeleteItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).DeleteItem(ctx, req.(*DeleteItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keeper_ListItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).ListItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.proto.Keeper/ListItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).ListItems(ctx, req.(*ListItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keeper_SearchItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).SearchItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.proto.Keeper/SearchItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).SearchItems(ctx, req.(*SearchItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Keeper_SyncVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SyncVaultRequest)
 	if err := dec(in); err != nil {
@@ -536,6 +1008,99 @@ func _Keeper_SyncVault_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Keeper_ListVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).ListVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.proto.Keeper/ListVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).ListVersions(ctx, req.(*ListVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keeper_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.proto.Keeper/GetVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).GetVersion(ctx, req.(*GetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keeper_RestoreItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).RestoreItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.proto.Keeper/RestoreItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).RestoreItem(ctx, req.(*RestoreItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keeper_PurgeItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).PurgeItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.proto.Keeper/PurgeItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).PurgeItem(ctx, req.(*PurgeItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keeper_LegacyItems_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LegacyItemsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KeeperServer).LegacyItems(m, &keeperLegacyItemsServer{stream})
+}
+
+type Keeper_LegacyItemsServer interface {
+	Send(*LegacyItemsResponse) error
+	grpc.ServerStream
+}
+
+type keeperLegacyItemsServer struct {
+	grpc.ServerStream
+}
+
+func (x *keeperLegacyItemsServer) Send(m *LegacyItemsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Keeper_ServiceDesc is the grpc.ServiceDesc for Keeper service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -551,6 +1116,18 @@ var Keeper_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LoginUser",
 			Handler:    _Keeper_LoginUser_Handler,
 		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _Keeper_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _Keeper_Logout_Handler,
+		},
+		{
+			MethodName: "SetVaultKDF",
+			Handler:    _Keeper_SetVaultKDF_Handler,
+		},
 		{
 			MethodName: "GetPair",
 			Handler:    _Keeper_GetPair_Handler,
@@ -599,11 +1176,63 @@ var Keeper_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DelCard",
 			Handler:    _Keeper_DelCard_Handler,
 		},
+		{
+			MethodName: "GetItem",
+			Handler:    _Keeper_GetItem_Handler,
+		},
+		{
+			MethodName: "PutItem",
+			Handler:    _Keeper_PutItem_Handler,
+		},
+		{
+			MethodName: "DeleteItem",
+			Handler:    _Keeper_DeleteItem_Handler,
+		},
+		{
+			MethodName: "ListItems",
+			Handler:    _Keeper_ListItems_Handler,
+		},
+		{
+			MethodName: "SearchItems",
+			Handler:    _Keeper_SearchItems_Handler,
+		},
 		{
 			MethodName: "SyncVault",
 			Handler:    _Keeper_SyncVault_Handler,
 		},
+		{
+			MethodName: "ListVersions",
+			Handler:    _Keeper_ListVersions_Handler,
+		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _Keeper_GetVersion_Handler,
+		},
+		{
+			MethodName: "RestoreItem",
+			Handler:    _Keeper_RestoreItem_Handler,
+		},
+		{
+			MethodName: "PurgeItem",
+			Handler:    _Keeper_PurgeItem_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadBin",
+			Handler:       _Keeper_UploadBin_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadBin",
+			Handler:       _Keeper_DownloadBin_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "LegacyItems",
+			Handler:       _Keeper_LegacyItems_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/gophkeeper.proto",
 }
@@ -1,9 +1,15 @@
 package cfg
 
+import "time"
+
 const (
-	PostgreDatabaseURI = "postgresql://localhost:5432/yandex_practicum_db?sslmode=disable" // local DB.
-	CryptoKey          = "secret_123456789"                                                // for test proj ok, but sure it's better to pass it via ENV ;)
-	ServerAddress      = "localhost:3200"                                                  // also could be passed via ENV, but ok for test proj.
+	// argon2id password hashing cost parameters. Existing hashes are upgraded on login after a change.
+	Argon2Time      = 1         // number of passes over the memory.
+	Argon2MemoryKiB = 64 * 1024 // 64 MiB.
+	Argon2Threads   = 4         // degree of parallelism.
+
+	AccessTokenTTL  = 15 * time.Minute    // lifetime of the JWT access token.
+	RefreshTokenTTL = 30 * 24 * time.Hour // lifetime of the session refresh token. Prolonged on every refresh.
 )
 
 var (
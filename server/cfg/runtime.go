@@ -0,0 +1,134 @@
+package cfg
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/config"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMissingValue = errors.New("required config value is not set")
+	ErrInvalidValue = errors.New("config value is invalid")
+
+	// Current is the server runtime configuration. Set by main after Load.
+	Current = Default()
+)
+
+// Config is the server runtime configuration.
+// Sources precedence: flags > environment > config file (-config / GOPHKEEPER_CONFIG) > defaults.
+type Config struct {
+	DatabaseURI     string `json:"database_uri" env:"GOPHKEEPER_DATABASE_URI" flag:"d"`
+	ServerAddress   string `json:"server_address" env:"GOPHKEEPER_SERVER_ADDRESS" flag:"a"`
+	CryptoKey       string `json:"crypto_key" env:"GOPHKEEPER_CRYPTO_KEY"` // JWT signing key. Not accepted as a flag, so it isn't visible in the process list.
+	CryptoKeyFile   string `json:"crypto_key_file" env:"GOPHKEEPER_CRYPTO_KEY_FILE" flag:"crypto-key-file"`
+	TLSCertFile     string `json:"tls_cert" env:"GOPHKEEPER_TLS_CERT" flag:"tls-cert"`
+	TLSKeyFile      string `json:"tls_key" env:"GOPHKEEPER_TLS_KEY" flag:"tls-key"`
+	TLSClientCAFile string `json:"tls_client_ca" env:"GOPHKEEPER_TLS_CLIENT_CA" flag:"tls-client-ca"`
+	BlobStore       string `json:"blob_store" env:"GOPHKEEPER_BLOB_STORE" flag:"blob-store"` // binary data storage kind: fs.
+	BlobDir         string `json:"blob_dir" env:"GOPHKEEPER_BLOB_DIR" flag:"blob-dir"`       // fs blob store directory.
+	// Retention policy, applied by the background job every RetentionInterval. 0 - the data is kept forever.
+	// Devices, that were offline longer than TombstoneDays, don't learn about the deletion and keep their copies.
+	RetainVersions    int           `json:"retain_versions" env:"GOPHKEEPER_RETAIN_VERSIONS" flag:"retain-versions"`          // last versions of the item to keep.
+	TombstoneDays     int           `json:"tombstone_days" env:"GOPHKEEPER_TOMBSTONE_DAYS" flag:"tombstone-days"`             // deleted items are erased after.
+	RetentionInterval time.Duration `json:"retention_interval" env:"GOPHKEEPER_RETENTION_INTERVAL" flag:"retention-interval"` // Go duration in env and flags, nanoseconds in the file.
+}
+
+// Default returns the configuration defaults for the local development.
+func Default() *Config {
+	return &Config{
+		DatabaseURI:   "postgresql://localhost:5432/yandex_practicum_db?sslmode=disable",
+		ServerAddress: "localhost:3200",
+		TLSCertFile:   "certs/server.crt",
+		TLSKeyFile:    "certs/server.key",
+		BlobStore:     "fs",
+		BlobDir:       "blobs",
+
+		RetentionInterval: time.Hour,
+	}
+}
+
+// Load builds the configuration from the command line arguments, environment and config file.
+// Signing key is read from the CryptoKeyFile, if it is set. The result is validated.
+func Load(args []string) (*Config, error) {
+	var (
+		c          = Default()
+		fl         = Default()
+		configPath = os.Getenv("GOPHKEEPER_CONFIG")
+	)
+
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	fs.StringVar(&configPath, "config", configPath, "path to JSON config file")
+	fs.StringVar(&fl.DatabaseURI, "d", fl.DatabaseURI, "database URI")
+	fs.StringVar(&fl.ServerAddress, "a", fl.ServerAddress, "server address host:port")
+	fs.StringVar(&fl.CryptoKeyFile, "crypto-key-file", fl.CryptoKeyFile, "file with the JWT signing key")
+	fs.StringVar(&fl.TLSCertFile, "tls-cert", fl.TLSCertFile, "server TLS certificate")
+	fs.StringVar(&fl.TLSKeyFile, "tls-key", fl.TLSKeyFile, "server TLS certificate key")
+	fs.StringVar(&fl.TLSClientCAFile, "tls-client-ca", fl.TLSClientCAFile, "CA to verify client certificates. If set, mutual TLS is required")
+	fs.StringVar(&fl.BlobStore, "blob-store", fl.BlobStore, "binary data storage kind: fs")
+	fs.StringVar(&fl.BlobDir, "blob-dir", fl.BlobDir, "binary data directory of the fs blob store")
+	fs.IntVar(&fl.RetainVersions, "retain-versions", fl.RetainVersions, "number of the last item versions to keep. 0 - all")
+	fs.IntVar(&fl.TombstoneDays, "tombstone-days", fl.TombstoneDays, "days to keep the deleted items. 0 - forever")
+	fs.DurationVar(&fl.RetentionInterval, "retention-interval", fl.RetentionInterval, "retention policy check interval")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	fs.Visit(func(f *flag.Flag) {
+		changed = append(changed, f.Name)
+	})
+
+	if err := config.Load(c, fl, changed, configPath); err != nil {
+		return nil, err
+	}
+
+	if c.CryptoKeyFile != `` {
+		key, err := os.ReadFile(c.CryptoKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("read crypto key file: %w", err)
+		}
+		c.CryptoKey = strings.TrimSpace(string(key))
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Validate checks that all the required values are set and the retention policy is valid.
+func (c *Config) Validate() error {
+	required := []struct {
+		name  string
+		value string
+	}{
+		{"database uri", c.DatabaseURI},
+		{"server address", c.ServerAddress},
+		{"crypto key", c.CryptoKey},
+		{"tls certificate", c.TLSCertFile},
+		{"tls certificate key", c.TLSKeyFile},
+		{"blob store", c.BlobStore},
+	}
+	for _, r := range required {
+		if r.value == `` {
+			return fmt.Errorf("%s: %w", r.name, ErrMissingValue)
+		}
+	}
+
+	if c.BlobStore == "fs" && c.BlobDir == `` {
+		return fmt.Errorf("blob dir: %w", ErrMissingValue)
+	}
+	if c.RetainVersions < 0 || c.TombstoneDays < 0 {
+		return fmt.Errorf("retention policy: %w", ErrInvalidValue)
+	}
+	if (c.RetainVersions > 0 || c.TombstoneDays > 0) && c.RetentionInterval <= 0 {
+		return fmt.Errorf("retention interval: %w", ErrInvalidValue)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package cfg
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestLoad verifies, that:
+// 1) crypto key is required
+// 2) crypto key is read from the key file
+// 3) flags override environment and config file
+// 4) retention policy is validated
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "jwt.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("file_secret\n"), 0600))
+	confFile := filepath.Join(dir, "server.json")
+	require.NoError(t, os.WriteFile(confFile, []byte(`{"server_address":"file:3200","crypto_key":"conf_secret"}`), 0600))
+
+	type want struct {
+		address string
+		key     string
+		err     error
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		want want
+	}{
+		{
+			name: "Test #1: crypto key not set",
+			want: want{err: ErrMissingValue},
+		},
+		{
+			name: "Test #2: crypto key from env",
+			env:  map[string]string{"GOPHKEEPER_CRYPTO_KEY": "env_secret"},
+			want: want{address: "localhost:3200", key: "env_secret"},
+		},
+		{
+			name: "Test #3: crypto key file",
+			args: []string{"-crypto-key-file", keyFile},
+			env:  map[string]string{"GOPHKEEPER_CRYPTO_KEY": "env_secret"},
+			want: want{address: "localhost:3200", key: "file_secret"},
+		},
+		{
+			name: "Test #4: config file, env and flags",
+			args: []string{"-config", confFile, "-a", "flag:3200"},
+			env:  map[string]string{"GOPHKEEPER_SERVER_ADDRESS": "env:3200"},
+			want: want{address: "flag:3200", key: "conf_secret"},
+		},
+		{
+			name: "Test #5: config file and env",
+			args: []string{"-config", confFile},
+			env:  map[string]string{"GOPHKEEPER_SERVER_ADDRESS": "env:3200"},
+			want: want{address: "env:3200", key: "conf_secret"},
+		},
+		{
+			name: "Test #6: negative retention",
+			args: []string{"-retain-versions", "-1"},
+			env:  map[string]string{"GOPHKEEPER_CRYPTO_KEY": "env_secret"},
+			want: want{err: ErrInvalidValue},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			c, err := Load(tt.args)
+			assert.ErrorIs(t, err, tt.want.err)
+			if tt.want.err != nil {
+				return
+			}
+			assert.Equal(t, tt.want.address, c.ServerAddress)
+			assert.Equal(t, tt.want.key, c.CryptoKey)
+		})
+	}
+}
@@ -7,7 +7,8 @@ import (
 type ctxkey string
 
 var (
-	userID ctxkey = "userID"
+	userID    ctxkey = "userID"
+	sessionID ctxkey = "sessionID"
 )
 
 // GetUserIDFromCTX returns from context userID if found.
@@ -23,3 +24,17 @@ func GetUserIDFromCTX(ctx context.Context) int {
 func SetUserIDToCTX(ctx context.Context, value int) context.Context {
 	return context.WithValue(ctx, userID, value)
 }
+
+// GetSessionIDFromCTX returns from context login session id if found.
+func GetSessionIDFromCTX(ctx context.Context) int {
+	value, ok := ctx.Value(sessionID).(int)
+	if !ok {
+		return -1
+	}
+	return value
+}
+
+// SetSessionIDToCTX add login session id to the context.
+func SetSessionIDToCTX(ctx context.Context, value int) context.Context {
+	return context.WithValue(ctx, sessionID, value)
+}
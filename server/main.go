@@ -1,15 +1,39 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/server/cfg"
 	"github.com/EestiChameleon/gophkeeper/server/router"
+	"github.com/EestiChameleon/gophkeeper/server/service"
 	"github.com/EestiChameleon/gophkeeper/server/storage"
+	"github.com/EestiChameleon/gophkeeper/server/tlsconf"
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 )
 
 func main() {
+	// helper command: server gen-certs [-dir=certs] [-hosts=localhost,127.0.0.1]
+	if len(os.Args) > 1 && os.Args[1] == "gen-certs" {
+		if err := genCerts(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// load config: flags > env > config file > defaults
+	conf, err := cfg.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	cfg.Current = conf
+
 	// init the grpc server
 	server, err := grpcserver.InitGRPCServer()
 	if err != nil {
@@ -21,6 +45,18 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// retention policy job works until the shutdown
+	retentionCtx, stopRetention := context.WithCancel(context.Background())
+	retentionDone := make(chan struct{})
+	go func() {
+		service.RunRetention(retentionCtx, service.RetentionPolicy{
+			KeepVersions:  cfg.Current.RetainVersions,
+			TombstoneAge:  time.Duration(cfg.Current.TombstoneDays) * 24 * time.Hour,
+			CheckInterval: cfg.Current.RetentionInterval,
+		})
+		close(retentionDone)
+	}()
+
 	// channel to alert about shutdown
 	gracefulShutdownChan := make(chan struct{})
 	// channel to redirect the interrupt
@@ -54,6 +90,8 @@ func main() {
 	// здесь можно освобождать ресурсы перед выходом,
 	// например закрыть соединение с базой данных,
 	// закрыть открытые файлы
+	stopRetention()
+	<-retentionDone
 
 	if err = storage.Close(); err != nil {
 		log.Fatal(err)
@@ -61,3 +99,25 @@ func main() {
 
 	log.Println("server gracefully shutdown: done")
 }
+
+// genCerts creates a self-signed development CA, server and client certificates.
+func genCerts(args []string) error {
+	fs := flag.NewFlagSet("gen-certs", flag.ExitOnError)
+	dir := fs.String("dir", "certs", "output directory")
+	hosts := fs.String("hosts", "localhost,127.0.0.1", "comma separated server DNS names and IPs")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := tlsconf.GenerateDevCerts(*dir, strings.Split(*hosts, ",")); err != nil {
+		return err
+	}
+
+	fp, err := tlsconf.Fingerprint(filepath.Join(*dir, tlsconf.ServerCertFile))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("certificates saved to %s\nserver certificate sha256 fingerprint: %s\n", *dir, fp)
+	return nil
+}
@@ -2,21 +2,26 @@ package migration
 
 import (
 	"database/sql"
+	"embed"
 	"errors"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 	_ "github.com/lib/pq"
 )
 
 var (
 	m *migrate.Migrate
+
+	// sqlScripts are embedded to the binary, so the server could be launched from any working directory.
+	//go:embed sqlscripts/*.sql
+	sqlScripts embed.FS
 )
 
 // InitMigration creates needed tables and functions in the database.
-func InitMigration() error {
+func InitMigration(databaseURI string) error {
 	// connect
-	if err := migrateInitConnect(); err != nil {
+	if err := migrateInitConnect(databaseURI); err != nil {
 		return err
 	}
 	// create
@@ -49,8 +54,8 @@ func downGophKeeperStorage() error {
 }
 
 // migrateInitConnect establishes connection to the DB for migrate operations.
-func migrateInitConnect() error {
-	conn, err := sql.Open("postgres", "postgres://localhost:5432/yandex_practicum_db?sslmode=disable")
+func migrateInitConnect(databaseURI string) error {
+	conn, err := sql.Open("postgres", databaseURI)
 	if err != nil {
 		return err
 	}
@@ -60,9 +65,12 @@ func migrateInitConnect() error {
 		return err
 	}
 
-	db, err := migrate.NewWithDatabaseInstance(
-		"file://server/migrations/sqlscripts",
-		"postgres", driver)
+	src, err := iofs.New(sqlScripts, "sqlscripts")
+	if err != nil {
+		return err
+	}
+
+	db, err := migrate.NewWithInstance("iofs", src, "postgres", driver)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,8 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+DROP TABLE IF EXISTS gk_otp;
+
+COMMIT;
@@ -0,0 +1,25 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+-- one-time password generators. Like the other item types, server keeps the title, version and the sealed payload.
+CREATE TABLE IF NOT EXISTS gk_otp
+(
+    id         serial primary key,
+    user_id    int                not null,
+    item_id    uuid               not null,
+    title      varchar            not null,
+    payload    bytea              not null,
+    version    smallint default 1 not null,
+    deleted_at timestamp,
+    change_seq bigint   default 0 not null,
+    sealed     boolean  default true not null -- always true: otp items were never saved in plaintext.
+);
+CREATE INDEX IF NOT EXISTS gk_otp_user_id_change_seq_index on gk_otp (user_id, change_seq);
+-- the version is unique, like the sealed versions of the other types.
+CREATE UNIQUE INDEX IF NOT EXISTS gk_otp_user_id_item_id_version_uindex
+    on gk_otp (user_id, item_id, version);
+CREATE INDEX IF NOT EXISTS gk_otp_user_id_title_index on gk_otp (user_id, title);
+
+COMMIT;
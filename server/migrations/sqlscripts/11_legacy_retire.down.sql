@@ -0,0 +1,12 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+DROP TRIGGER IF EXISTS gk_pair_retire_legacy ON gk_pair;
+DROP TRIGGER IF EXISTS gk_text_retire_legacy ON gk_text;
+DROP TRIGGER IF EXISTS gk_bin_retire_legacy ON gk_bin;
+DROP TRIGGER IF EXISTS gk_card_retire_legacy ON gk_card;
+DROP FUNCTION IF EXISTS gk_retire_legacy();
+
+COMMIT;
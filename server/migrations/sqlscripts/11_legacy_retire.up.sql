@@ -0,0 +1,41 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+-- plaintext rows (sealed = false, see 4_sealed_payload) are erased, once the item gets a sealed version:
+-- the client has sealed the plaintext data and saved it as the next version.
+CREATE OR REPLACE FUNCTION gk_retire_legacy() RETURNS trigger AS
+$$
+BEGIN
+    EXECUTE format('DELETE FROM %I WHERE user_id = $1 AND item_id = $2 AND NOT sealed', TG_TABLE_NAME)
+        USING NEW.user_id, NEW.item_id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER gk_pair_retire_legacy AFTER INSERT ON gk_pair
+    FOR EACH ROW WHEN (NEW.sealed) EXECUTE PROCEDURE gk_retire_legacy();
+CREATE TRIGGER gk_text_retire_legacy AFTER INSERT ON gk_text
+    FOR EACH ROW WHEN (NEW.sealed) EXECUTE PROCEDURE gk_retire_legacy();
+CREATE TRIGGER gk_bin_retire_legacy AFTER INSERT ON gk_bin
+    FOR EACH ROW WHEN (NEW.sealed) EXECUTE PROCEDURE gk_retire_legacy();
+CREATE TRIGGER gk_card_retire_legacy AFTER INSERT ON gk_card
+    FOR EACH ROW WHEN (NEW.sealed) EXECUTE PROCEDURE gk_retire_legacy();
+
+----------
+-- DATA --
+----------
+
+-- the items, deleted before the tombstones were introduced, got the sealed tombstone (6_tombstones):
+-- their plaintext rows are not needed anymore.
+DELETE FROM gk_pair l WHERE NOT sealed AND EXISTS
+    (SELECT 1 FROM gk_pair s WHERE s.user_id = l.user_id AND s.item_id = l.item_id AND s.sealed);
+DELETE FROM gk_text l WHERE NOT sealed AND EXISTS
+    (SELECT 1 FROM gk_text s WHERE s.user_id = l.user_id AND s.item_id = l.item_id AND s.sealed);
+DELETE FROM gk_bin l WHERE NOT sealed AND EXISTS
+    (SELECT 1 FROM gk_bin s WHERE s.user_id = l.user_id AND s.item_id = l.item_id AND s.sealed);
+DELETE FROM gk_card l WHERE NOT sealed AND EXISTS
+    (SELECT 1 FROM gk_card s WHERE s.user_id = l.user_id AND s.item_id = l.item_id AND s.sealed);
+
+COMMIT;
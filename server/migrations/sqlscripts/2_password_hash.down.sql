@@ -0,0 +1,13 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+ALTER TABLE gophkeeper_users DROP COLUMN IF EXISTS password_updated_at;
+ALTER TABLE gophkeeper_users ALTER COLUMN password_hash TYPE varchar;
+ALTER TABLE gophkeeper_users RENAME COLUMN password_hash TO password;
+
+----------
+-- DATA --
+----------
+
+COMMIT;
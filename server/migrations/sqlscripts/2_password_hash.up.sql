@@ -0,0 +1,17 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+-- password column keeps encoded hashes of different schemes: $<scheme>$<params>$<salt>$<hash>.
+-- Legacy unsalted md5 hex values are upgraded on the next successful login.
+ALTER TABLE gophkeeper_users RENAME COLUMN password TO password_hash;
+ALTER TABLE gophkeeper_users ALTER COLUMN password_hash TYPE text;
+ALTER TABLE gophkeeper_users ADD COLUMN IF NOT EXISTS password_updated_at timestamp;
+
+----------
+-- DATA --
+----------
+
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+DROP INDEX IF EXISTS gk_session_previous_refresh_hash_index;
+DROP INDEX IF EXISTS gk_session_refresh_hash_uindex;
+DROP TABLE IF EXISTS gk_session;
+
+----------
+-- DATA --
+----------
+
+COMMIT;
@@ -0,0 +1,29 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+-- gk_session keeps the login sessions. Refresh token is stored as sha256 hash only.
+-- Revoked session invalidates its refresh token and all the access tokens issued for it.
+-- previous_refresh_hash is the rotated token: it is used again only if it was stolen, the session is revoked then.
+CREATE TABLE IF NOT EXISTS gk_session
+(
+    id                    serial primary key,
+    user_id               int                                 not null,
+    refresh_hash          varchar                             not null,
+    previous_refresh_hash varchar,
+    created_at            timestamp default current_timestamp not null,
+    expires_at            timestamp                           not null,
+    revoked_at            timestamp
+);
+CREATE UNIQUE INDEX IF NOT EXISTS gk_session_refresh_hash_uindex
+    on gk_session (refresh_hash);
+CREATE INDEX IF NOT EXISTS gk_session_previous_refresh_hash_index
+    on gk_session (previous_refresh_hash);
+
+----------
+-- DATA --
+----------
+
+
+COMMIT;
@@ -0,0 +1,36 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+-- sealed rows can't be opened on the server side - they are removed, the plaintext rows are left.
+DELETE FROM gk_pair WHERE sealed;
+ALTER TABLE gk_pair DROP COLUMN payload;
+ALTER TABLE gk_pair DROP COLUMN sealed;
+ALTER TABLE gk_pair ALTER COLUMN login SET NOT NULL;
+ALTER TABLE gk_pair ALTER COLUMN pass SET NOT NULL;
+
+DELETE FROM gk_text WHERE sealed;
+ALTER TABLE gk_text DROP COLUMN payload;
+ALTER TABLE gk_text DROP COLUMN sealed;
+ALTER TABLE gk_text ALTER COLUMN body SET NOT NULL;
+
+DELETE FROM gk_bin WHERE sealed;
+ALTER TABLE gk_bin DROP COLUMN payload;
+ALTER TABLE gk_bin DROP COLUMN sealed;
+ALTER TABLE gk_bin ALTER COLUMN body SET NOT NULL;
+
+DELETE FROM gk_card WHERE sealed;
+ALTER TABLE gk_card DROP COLUMN payload;
+ALTER TABLE gk_card DROP COLUMN sealed;
+ALTER TABLE gk_card ALTER COLUMN number SET NOT NULL;
+ALTER TABLE gk_card ALTER COLUMN expiration_date SET NOT NULL;
+
+ALTER TABLE gophkeeper_users DROP COLUMN IF EXISTS kdf_check;
+ALTER TABLE gophkeeper_users DROP COLUMN IF EXISTS kdf_params;
+
+----------
+-- DATA --
+----------
+
+COMMIT;
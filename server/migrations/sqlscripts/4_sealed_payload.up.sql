@@ -0,0 +1,42 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+-- vault key derivation data. The client derives the key from the master password, server only shares these values
+-- between the user devices.
+ALTER TABLE gophkeeper_users ADD COLUMN IF NOT EXISTS kdf_params varchar;
+ALTER TABLE gophkeeper_users ADD COLUMN IF NOT EXISTS kdf_check bytea;
+
+-- items are sealed on the client side: server keeps the title, version and the sealed payload only.
+-- Plaintext rows can't be sealed on the server side - they are kept with sealed = false and are not served as
+-- the item versions. The client seals them and saves as the next version, then the plaintext rows are erased,
+-- see 11_legacy_retire. The plaintext columns are kept for these rows only.
+ALTER TABLE gk_pair ALTER COLUMN login DROP NOT NULL;
+ALTER TABLE gk_pair ALTER COLUMN pass DROP NOT NULL;
+ALTER TABLE gk_pair ADD COLUMN payload bytea default ''::bytea not null;
+ALTER TABLE gk_pair ADD COLUMN sealed boolean default true not null;
+
+ALTER TABLE gk_text ALTER COLUMN body DROP NOT NULL;
+ALTER TABLE gk_text ADD COLUMN payload bytea default ''::bytea not null;
+ALTER TABLE gk_text ADD COLUMN sealed boolean default true not null;
+
+ALTER TABLE gk_bin ALTER COLUMN body DROP NOT NULL;
+ALTER TABLE gk_bin ADD COLUMN payload bytea default ''::bytea not null;
+ALTER TABLE gk_bin ADD COLUMN sealed boolean default true not null;
+
+ALTER TABLE gk_card ALTER COLUMN number DROP NOT NULL;
+ALTER TABLE gk_card ALTER COLUMN expiration_date DROP NOT NULL;
+ALTER TABLE gk_card ADD COLUMN payload bytea default ''::bytea not null;
+ALTER TABLE gk_card ADD COLUMN sealed boolean default true not null;
+
+----------
+-- DATA --
+----------
+
+UPDATE gk_pair SET sealed = false;
+UPDATE gk_text SET sealed = false;
+UPDATE gk_bin SET sealed = false;
+UPDATE gk_card SET sealed = false;
+
+COMMIT;
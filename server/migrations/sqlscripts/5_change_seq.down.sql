@@ -0,0 +1,23 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+DROP INDEX IF EXISTS gk_pair_user_id_change_seq_index;
+ALTER TABLE gk_pair DROP COLUMN IF EXISTS change_seq;
+
+DROP INDEX IF EXISTS gk_text_user_id_change_seq_index;
+ALTER TABLE gk_text DROP COLUMN IF EXISTS change_seq;
+
+DROP INDEX IF EXISTS gk_bin_user_id_change_seq_index;
+ALTER TABLE gk_bin DROP COLUMN IF EXISTS change_seq;
+
+DROP INDEX IF EXISTS gk_card_user_id_change_seq_index;
+ALTER TABLE gk_card DROP COLUMN IF EXISTS change_seq;
+
+ALTER TABLE gophkeeper_users DROP COLUMN IF EXISTS change_seq;
+
+----------
+-- DATA --
+----------
+
+COMMIT;
@@ -0,0 +1,33 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+-- change_seq is a per-user change counter. Every item insert or delete increments the user counter and stamps the
+-- item row with the new value, so the clients could request only the changes made after their last sync.
+ALTER TABLE gophkeeper_users ADD COLUMN IF NOT EXISTS change_seq bigint default 0 not null;
+
+ALTER TABLE gk_pair ADD COLUMN IF NOT EXISTS change_seq bigint default 0 not null;
+CREATE INDEX IF NOT EXISTS gk_pair_user_id_change_seq_index on gk_pair (user_id, change_seq);
+
+ALTER TABLE gk_text ADD COLUMN IF NOT EXISTS change_seq bigint default 0 not null;
+CREATE INDEX IF NOT EXISTS gk_text_user_id_change_seq_index on gk_text (user_id, change_seq);
+
+ALTER TABLE gk_bin ADD COLUMN IF NOT EXISTS change_seq bigint default 0 not null;
+CREATE INDEX IF NOT EXISTS gk_bin_user_id_change_seq_index on gk_bin (user_id, change_seq);
+
+ALTER TABLE gk_card ADD COLUMN IF NOT EXISTS change_seq bigint default 0 not null;
+CREATE INDEX IF NOT EXISTS gk_card_user_id_change_seq_index on gk_card (user_id, change_seq);
+
+----------
+-- DATA --
+----------
+
+-- existing rows become the first change, so they are returned to the clients with cursor 0.
+UPDATE gophkeeper_users SET change_seq = 1;
+UPDATE gk_pair SET change_seq = 1;
+UPDATE gk_text SET change_seq = 1;
+UPDATE gk_bin SET change_seq = 1;
+UPDATE gk_card SET change_seq = 1;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+----------
+-- DATA --
+----------
+
+-- tombstones are the deleted rows with an empty payload.
+DELETE FROM gk_pair WHERE deleted_at IS NOT NULL AND payload = ''::bytea;
+DELETE FROM gk_text WHERE deleted_at IS NOT NULL AND payload = ''::bytea;
+DELETE FROM gk_bin WHERE deleted_at IS NOT NULL AND payload = ''::bytea;
+DELETE FROM gk_card WHERE deleted_at IS NOT NULL AND payload = ''::bytea;
+
+COMMIT;
@@ -0,0 +1,34 @@
+BEGIN;
+----------
+-- DATA --
+----------
+
+-- items deleted before the tombstones were introduced get one: the next version with an empty payload.
+-- Tombstones are stamped with a new change, so the clients receive them with their current cursor.
+UPDATE gophkeeper_users SET change_seq = change_seq + 1;
+
+INSERT INTO gk_pair (user_id, title, payload, version, deleted_at, change_seq)
+SELECT t.user_id, t.title, ''::bytea, max(t.version) + 1, max(t.deleted_at), u.change_seq
+FROM gk_pair t JOIN gophkeeper_users u ON u.id = t.user_id
+GROUP BY t.user_id, t.title, u.change_seq
+HAVING bool_and(t.deleted_at IS NOT NULL);
+
+INSERT INTO gk_text (user_id, title, payload, version, deleted_at, change_seq)
+SELECT t.user_id, t.title, ''::bytea, max(t.version) + 1, max(t.deleted_at), u.change_seq
+FROM gk_text t JOIN gophkeeper_users u ON u.id = t.user_id
+GROUP BY t.user_id, t.title, u.change_seq
+HAVING bool_and(t.deleted_at IS NOT NULL);
+
+INSERT INTO gk_bin (user_id, title, payload, version, deleted_at, change_seq)
+SELECT t.user_id, t.title, ''::bytea, max(t.version) + 1, max(t.deleted_at), u.change_seq
+FROM gk_bin t JOIN gophkeeper_users u ON u.id = t.user_id
+GROUP BY t.user_id, t.title, u.change_seq
+HAVING bool_and(t.deleted_at IS NOT NULL);
+
+INSERT INTO gk_card (user_id, title, payload, version, deleted_at, change_seq)
+SELECT t.user_id, t.title, ''::bytea, max(t.version) + 1, max(t.deleted_at), u.change_seq
+FROM gk_card t JOIN gophkeeper_users u ON u.id = t.user_id
+GROUP BY t.user_id, t.title, u.change_seq
+HAVING bool_and(t.deleted_at IS NOT NULL);
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+-- streamed items can't be kept without the body.
+DELETE FROM gk_bin WHERE content IS NOT NULL;
+ALTER TABLE gk_bin DROP COLUMN IF EXISTS content;
+
+COMMIT;
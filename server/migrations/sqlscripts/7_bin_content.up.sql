@@ -0,0 +1,10 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+-- sealed body of the binary items, uploaded with UploadBin. The payload of these rows keeps the comment only.
+-- NULL for the items, saved as one message.
+ALTER TABLE gk_bin ADD COLUMN IF NOT EXISTS content bytea;
+
+COMMIT;
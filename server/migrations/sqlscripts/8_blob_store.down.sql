@@ -0,0 +1,12 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+-- the data of these rows is kept in the blob store only, the rows can't be kept without it.
+DELETE FROM gk_bin WHERE payload_hash IS NOT NULL OR content_hash IS NOT NULL;
+ALTER TABLE gk_bin DROP COLUMN IF EXISTS payload_hash;
+ALTER TABLE gk_bin DROP COLUMN IF EXISTS content_hash;
+DROP TABLE IF EXISTS gk_blob;
+
+COMMIT;
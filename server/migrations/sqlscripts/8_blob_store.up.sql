@@ -0,0 +1,20 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+-- binary item data, kept in the blob store by the content hash. refs counts the gk_bin rows, that use the blob.
+CREATE TABLE IF NOT EXISTS gk_blob
+(
+    hash varchar(64) primary key,
+    size bigint      not null,
+    refs int         default 0 not null
+);
+
+-- payload_hash: payload of the items, saved as one message. The payload column is left empty.
+-- content_hash: sealed body of the streamed items. The payload column keeps the comment only.
+-- Existing payload and content are moved to the blob store on the server start, the content column stays empty.
+ALTER TABLE gk_bin ADD COLUMN IF NOT EXISTS payload_hash varchar(64);
+ALTER TABLE gk_bin ADD COLUMN IF NOT EXISTS content_hash varchar(64);
+
+COMMIT;
@@ -0,0 +1,31 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+-- the title unique index of 1_db_tables is restored: it fails, if the live items share the title and the version.
+DROP INDEX IF EXISTS gk_pair_user_id_item_id_version_uindex;
+DROP INDEX IF EXISTS gk_pair_user_id_title_index;
+ALTER TABLE gk_pair DROP COLUMN IF EXISTS item_id;
+CREATE UNIQUE INDEX IF NOT EXISTS gk_pair_user_id_title_version_deleted_at_uindex
+    on gk_pair (user_id, title, version, deleted_at);
+
+DROP INDEX IF EXISTS gk_text_user_id_item_id_version_uindex;
+DROP INDEX IF EXISTS gk_text_user_id_title_index;
+ALTER TABLE gk_text DROP COLUMN IF EXISTS item_id;
+CREATE UNIQUE INDEX IF NOT EXISTS gk_text_user_id_title_version_deleted_at_uindex
+    on gk_text (user_id, title, version, deleted_at);
+
+DROP INDEX IF EXISTS gk_bin_user_id_item_id_version_uindex;
+DROP INDEX IF EXISTS gk_bin_user_id_title_index;
+ALTER TABLE gk_bin DROP COLUMN IF EXISTS item_id;
+CREATE UNIQUE INDEX IF NOT EXISTS gk_bin_user_id_title_version_deleted_at_uindex
+    on gk_bin (user_id, title, version, deleted_at);
+
+DROP INDEX IF EXISTS gk_card_user_id_item_id_version_uindex;
+DROP INDEX IF EXISTS gk_card_user_id_title_index;
+ALTER TABLE gk_card DROP COLUMN IF EXISTS item_id;
+CREATE UNIQUE INDEX IF NOT EXISTS gk_card_user_id_title_version_deleted_at_uindex
+    on gk_card (user_id, title, version, deleted_at);
+
+COMMIT;
@@ -0,0 +1,52 @@
+BEGIN;
+------------
+-- TABLES --
+------------
+
+-- item_id identifies the item, it is generated by the client. The title is an item attribute, changed by the next
+-- version, and it is not unique: the items are addressed by the id. The item version is unique: of the concurrent
+-- saves of the same version only the first one is stored. The legacy plaintext rows are not indexed: they could repeat
+-- the version (the deleted item was added again from version 1), and they are retired, when the client seals them.
+ALTER TABLE gk_pair ADD COLUMN IF NOT EXISTS item_id uuid;
+DROP INDEX IF EXISTS gk_pair_user_id_title_version_deleted_at_uindex;
+CREATE UNIQUE INDEX IF NOT EXISTS gk_pair_user_id_item_id_version_uindex
+    on gk_pair (user_id, item_id, version) WHERE sealed;
+CREATE INDEX IF NOT EXISTS gk_pair_user_id_title_index on gk_pair (user_id, title);
+
+ALTER TABLE gk_text ADD COLUMN IF NOT EXISTS item_id uuid;
+DROP INDEX IF EXISTS gk_text_user_id_title_version_deleted_at_uindex;
+CREATE UNIQUE INDEX IF NOT EXISTS gk_text_user_id_item_id_version_uindex
+    on gk_text (user_id, item_id, version) WHERE sealed;
+CREATE INDEX IF NOT EXISTS gk_text_user_id_title_index on gk_text (user_id, title);
+
+ALTER TABLE gk_bin ADD COLUMN IF NOT EXISTS item_id uuid;
+DROP INDEX IF EXISTS gk_bin_user_id_title_version_deleted_at_uindex;
+CREATE UNIQUE INDEX IF NOT EXISTS gk_bin_user_id_item_id_version_uindex
+    on gk_bin (user_id, item_id, version) WHERE sealed;
+CREATE INDEX IF NOT EXISTS gk_bin_user_id_title_index on gk_bin (user_id, title);
+
+ALTER TABLE gk_card ADD COLUMN IF NOT EXISTS item_id uuid;
+DROP INDEX IF EXISTS gk_card_user_id_title_version_deleted_at_uindex;
+CREATE UNIQUE INDEX IF NOT EXISTS gk_card_user_id_item_id_version_uindex
+    on gk_card (user_id, item_id, version) WHERE sealed;
+CREATE INDEX IF NOT EXISTS gk_card_user_id_title_index on gk_card (user_id, title);
+
+----------
+-- DATA --
+----------
+
+-- existing items get the id, derived from the type and the title: md5('<type>:<title>') as uuid.
+-- The clients derive the same ids for their local items, so all the devices agree on them.
+UPDATE gk_pair SET item_id = md5('pair:' || title)::uuid WHERE item_id IS NULL;
+ALTER TABLE gk_pair ALTER COLUMN item_id SET NOT NULL;
+
+UPDATE gk_text SET item_id = md5('text:' || title)::uuid WHERE item_id IS NULL;
+ALTER TABLE gk_text ALTER COLUMN item_id SET NOT NULL;
+
+UPDATE gk_bin SET item_id = md5('bin:' || title)::uuid WHERE item_id IS NULL;
+ALTER TABLE gk_bin ALTER COLUMN item_id SET NOT NULL;
+
+UPDATE gk_card SET item_id = md5('card:' || title)::uuid WHERE item_id IS NULL;
+ALTER TABLE gk_card ALTER COLUMN item_id SET NOT NULL;
+
+COMMIT;
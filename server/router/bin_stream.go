@@ -0,0 +1,194 @@
+package grpcserver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/EestiChameleon/gophkeeper/server/ctxfunc"
+	"github.com/EestiChameleon/gophkeeper/server/storage"
+	"github.com/EestiChameleon/gophkeeper/server/storage/postgre"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"hash"
+	"io"
+	"log"
+)
+
+const (
+	// binChunkSize is the body chunk size of DownloadBin.
+	binChunkSize = 256 * 1024
+	// maxBinSize limits the uploaded body, so one item could not exhaust the blob store.
+	maxBinSize = 1<<30 - 1
+)
+
+var (
+	errChecksumMismatch = errors.New("body checksum mismatch")
+	errNoChecksum       = errors.New("stream ended without the body checksum")
+	errUnexpectedPart   = errors.New("unexpected stream part")
+	errBinTooLarge      = errors.New("body is too large")
+)
+
+// UploadBin handler saves new binary data, streamed by parts: the header, the body chunks and the checksum.
+// Version rules are the same, as in PostBin. The body is saved only if the checksum matches.
+func (g *GRPCServer) UploadBin(stream pb.Keeper_UploadBinServer) error {
+	uID := ctxfunc.GetUserIDFromCTX(stream.Context())
+
+	first, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument, "invalid argument")
+		}
+		return err
+	}
+	header := first.GetHeader()
+	if header.GetVersion() < 1 || header.GetTitle() == `` || len(header.GetPayload()) == 0 || header.GetDeleted() ||
+		!validLookup(header.GetId(), header.GetTitle()) {
+		return status.Error(codes.InvalidArgument, "invalid argument")
+	}
+
+	id, err := itemID(uID, "bin", header.Id, header.Title)
+	if err != nil {
+		return lookupStatus(err)
+	}
+
+	// first - compare version in DB. Deleted version (tombstone) is compared too.
+	dbBin, err := storage.Vault.ItemByID("bin", id, uID)
+	if err != nil && !errors.Is(err, postgre.ErrNotFound) {
+		log.Println(err)
+		return status.Error(codes.Internal, failedDBQuery)
+	}
+	if err == nil && header.Version <= dbBin.Version {
+		return status.Error(codes.AlreadyExists, newerVersionDetected)
+	}
+
+	body := &uploadReader{stream: stream, hash: sha256.New()}
+	size, err := storage.Vault.BinAddContent(uID, id, header.Title, header.Payload, body, header.Version)
+	if err != nil {
+		switch {
+		case body.recvErr != nil:
+			return body.recvErr
+		case errors.Is(err, errChecksumMismatch):
+			return status.Error(codes.DataLoss, err.Error())
+		case errors.Is(err, errNoChecksum), errors.Is(err, errUnexpectedPart):
+			return status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, errBinTooLarge):
+			return status.Error(codes.ResourceExhausted, err.Error())
+		case errors.Is(err, postgre.ErrRecordAlreadyExists):
+			// the concurrent request has saved the version first.
+			return status.Error(codes.AlreadyExists, newerVersionDetected)
+		}
+		log.Println(err)
+		return status.Error(codes.Internal, failedToSaveNewVersion)
+	}
+
+	return stream.SendAndClose(&pb.UploadBinResponse{Status: "success", Size: uint64(size)})
+}
+
+// DownloadBin handler sends the binary data, found by id or title: the header, then the body chunks and the checksum,
+// if the body is streamed.
+func (g *GRPCServer) DownloadBin(in *pb.DownloadBinRequest, stream pb.Keeper_DownloadBinServer) error {
+	if !validLookup(in.Id, in.Title) {
+		return status.Error(codes.InvalidArgument, "invalid argument")
+	}
+	data, err := lookup(ctxfunc.GetUserIDFromCTX(stream.Context()), "bin", in.Id, in.Title)
+	if err == nil && data.DeletedAt.Valid {
+		// latest version is a tombstone
+		err = postgre.ErrNotFound
+	}
+	if err != nil {
+		if errors.Is(err, postgre.ErrNotFound) {
+			return status.Error(codes.NotFound, "not found")
+		}
+		if errors.Is(err, postgre.ErrAmbiguous) {
+			return status.Error(codes.FailedPrecondition, ambiguousTitle)
+		}
+		log.Println(err)
+		return status.Error(codes.Internal, "failed to obtain latest data")
+	}
+
+	err = stream.Send(&pb.DownloadBinResponse{Part: &pb.DownloadBinResponse_Header{Header: models.SealedToProtoBin(data)}})
+	if err != nil || !data.Streamed {
+		return err
+	}
+
+	content, err := storage.Vault.BinContent(data.ID)
+	if err != nil {
+		log.Println(err)
+		return status.Error(codes.Internal, "failed to obtain latest data")
+	}
+	defer content.Close()
+
+	h := sha256.New()
+	for {
+		// sent message can't be reused - every chunk gets a new buffer.
+		chunk := make([]byte, binChunkSize)
+		n, err := io.ReadFull(content, chunk)
+		if n > 0 {
+			h.Write(chunk[:n])
+			if sErr := stream.Send(&pb.DownloadBinResponse{Part: &pb.DownloadBinResponse_Chunk{Chunk: chunk[:n]}}); sErr != nil {
+				return sErr
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			log.Println(err)
+			return status.Error(codes.Internal, "failed to obtain latest data")
+		}
+	}
+
+	return stream.Send(&pb.DownloadBinResponse{Part: &pb.DownloadBinResponse_Sha256{Sha256: h.Sum(nil)}})
+}
+
+// uploadReader reads the body chunks of the UploadBin stream. The stream must end with the checksum of the body,
+// the reader returns io.EOF only if it matches.
+type uploadReader struct {
+	stream  pb.Keeper_UploadBinServer
+	hash    hash.Hash
+	size    int64
+	chunk   []byte
+	done    bool
+	recvErr error // transport error. Returned to the client as is.
+}
+
+// Read implements io.Reader.
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+
+		part, err := r.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return 0, errNoChecksum
+		}
+		if err != nil {
+			r.recvErr = err
+			return 0, err
+		}
+
+		switch part.GetPart().(type) {
+		case *pb.UploadBinRequest_Chunk:
+			r.chunk = part.GetChunk()
+			r.size += int64(len(r.chunk))
+			if r.size > maxBinSize {
+				return 0, errBinTooLarge
+			}
+			r.hash.Write(r.chunk)
+		case *pb.UploadBinRequest_Sha256:
+			if !bytes.Equal(part.GetSha256(), r.hash.Sum(nil)) {
+				return 0, errChecksumMismatch
+			}
+			r.done = true
+		default:
+			return 0, errUnexpectedPart
+		}
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
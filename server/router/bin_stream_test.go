@@ -0,0 +1,186 @@
+package grpcserver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/EestiChameleon/gophkeeper/server/storage"
+	"github.com/EestiChameleon/gophkeeper/server/storage/testdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"testing"
+)
+
+// TestUploadBin verifies, that:
+// 1) invalid header and outdated version are rejected
+// 2) body is saved only with the matching checksum
+// 3) in case of success the body is saved as received
+func TestUploadBin(t *testing.T) {
+	// init connect
+	ctx, conn := keeperTestConn(t)
+	defer conn.Close()
+
+	// create client
+	client := pb.NewKeeperClient(conn)
+	// init test storage
+	storage.InitTest()
+
+	body := bytes.Repeat([]byte("sealed body "), 50000)
+	sum := sha256.Sum256(body)
+
+	tests := []struct {
+		name          string
+		number        uint8
+		header        *pb.Bin
+		sum           []byte
+		errStatusCode codes.Code
+	}{
+		{
+			name:          "Test #1: empty header",
+			number:        1,
+			header:        &pb.Bin{},
+			sum:           sum[:],
+			errStatusCode: codes.InvalidArgument,
+		},
+		{
+			name:          "Test #2: outdated version",
+			number:        2,
+			header:        &pb.Bin{Title: testdb.TestStreamedBin.Title, Version: testdb.TestStreamedBin.Version, Payload: []byte("p")},
+			sum:           sum[:],
+			errStatusCode: codes.AlreadyExists,
+		},
+		{
+			name:          "Test #3: checksum mismatch",
+			number:        3,
+			header:        &pb.Bin{Title: "newBin", Version: 1, Payload: []byte("p")},
+			sum:           []byte("wrong"),
+			errStatusCode: codes.DataLoss,
+		},
+		{
+			name:          "Test #4: no checksum",
+			number:        4,
+			header:        &pb.Bin{Title: "newBin", Version: 1, Payload: []byte("p")},
+			errStatusCode: codes.InvalidArgument,
+		},
+		{
+			name:   "Test #5: correct data",
+			number: 5,
+			header: &pb.Bin{Title: "newBin", Version: 1, Payload: []byte("p")},
+			sum:    sum[:],
+		},
+		{
+			name:          "Test #6: version is saved by the concurrent request first",
+			number:        4,
+			header:        &pb.Bin{Title: testdb.TestRacedTitle, Version: 1, Payload: []byte("p")},
+			sum:           sum[:],
+			errStatusCode: codes.AlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testdb.TestUploaded = nil
+			stream, err := client.UploadBin(ctx)
+			require.NoError(t, err)
+
+			// server could reject the header before the body is sent - send errors are reported by CloseAndRecv.
+			_ = stream.Send(&pb.UploadBinRequest{Part: &pb.UploadBinRequest_Header{Header: tt.header}})
+			for rest := body; len(rest) > 0; rest = rest[minInt(len(rest), binChunkSize):] {
+				_ = stream.Send(&pb.UploadBinRequest{Part: &pb.UploadBinRequest_Chunk{Chunk: rest[:minInt(len(rest), binChunkSize)]}})
+			}
+			if tt.sum != nil {
+				_ = stream.Send(&pb.UploadBinRequest{Part: &pb.UploadBinRequest_Sha256{Sha256: tt.sum}})
+			}
+			resp, err := stream.CloseAndRecv()
+
+			switch tt.number {
+			case 1, 2, 3, 4:
+				assert.Equal(t, tt.errStatusCode, status.Code(err))
+				if tt.number != 1 && tt.number != 2 {
+					// the body was read, but not accepted
+					assert.Nil(t, testdb.TestUploaded)
+				}
+			case 5:
+				require.NoError(t, err)
+				assert.Equal(t, "success", resp.GetStatus())
+				assert.Equal(t, uint64(len(body)), resp.GetSize())
+				assert.Equal(t, body, testdb.TestUploaded)
+			}
+		})
+	}
+}
+
+// TestDownloadBin verifies, that:
+// 1) empty and unknown titles are rejected
+// 2) item, saved as one message, is sent with the header only
+// 3) streamed body is sent by chunks with the checksum
+func TestDownloadBin(t *testing.T) {
+	// init connect
+	ctx, conn := keeperTestConn(t)
+	defer conn.Close()
+
+	// create client
+	client := pb.NewKeeperClient(conn)
+	// init test storage
+	storage.InitTest()
+
+	tests := []struct {
+		name          string
+		number        uint8
+		title         string
+		errStatusCode codes.Code
+	}{
+		{name: "Test #1: empty title", number: 1, title: "", errStatusCode: codes.InvalidArgument},
+		{name: "Test #2: unknown title", number: 2, title: "unknown", errStatusCode: codes.NotFound},
+		{name: "Test #3: not streamed item", number: 3, title: testdb.TestBin.Title},
+		{name: "Test #4: streamed item", number: 4, title: testdb.TestStreamedBin.Title},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := client.DownloadBin(ctx, &pb.DownloadBinRequest{Title: tt.title})
+			require.NoError(t, err)
+
+			var parts []*pb.DownloadBinResponse
+			for {
+				part, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					assert.Equal(t, tt.errStatusCode, status.Code(err))
+					return
+				}
+				parts = append(parts, part)
+			}
+
+			switch tt.number {
+			case 1, 2:
+				t.Fatal("error expected")
+			case 3:
+				require.Len(t, parts, 1)
+				assert.Equal(t, testdb.TestBin.Payload, parts[0].GetHeader().GetPayload())
+				assert.False(t, parts[0].GetHeader().GetStreamed())
+			case 4:
+				require.True(t, len(parts) > 2)
+				assert.True(t, parts[0].GetHeader().GetStreamed())
+				assert.Equal(t, uint64(len(testdb.TestBinContent)), parts[0].GetHeader().GetSize())
+				var body []byte
+				for _, p := range parts[1 : len(parts)-1] {
+					body = append(body, p.GetChunk()...)
+				}
+				assert.Equal(t, testdb.TestBinContent, body)
+				sum := sha256.Sum256(body)
+				assert.Equal(t, sum[:], parts[len(parts)-1].GetSha256())
+			}
+		})
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,171 @@
+package grpcserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/EestiChameleon/gophkeeper/server/ctxfunc"
+	"github.com/EestiChameleon/gophkeeper/server/storage"
+	"github.com/EestiChameleon/gophkeeper/server/storage/postgre"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+)
+
+// historyItem finds the item of the history requests by type and id or title. Returns the response status on error.
+func historyItem(uID int, dataType, id, title string) (*models.Sealed, error) {
+	if !models.ValidItemType(dataType) || !validLookup(id, title) {
+		return nil, status.Error(codes.InvalidArgument, "invalid argument")
+	}
+	item, err := lookup(uID, dataType, id, title)
+	if err != nil {
+		return nil, lookupStatus(err)
+	}
+	return item, nil
+}
+
+// ListVersions handler returns all the stored versions of the item, the latest first. Tombstones included.
+func (g *GRPCServer) ListVersions(ctx context.Context, in *pb.ListVersionsRequest) (*pb.ListVersionsResponse, error) {
+	uID := ctxfunc.GetUserIDFromCTX(ctx)
+	item, err := historyItem(uID, in.Type, in.Id, in.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := storage.Vault.ItemVersions(in.Type, item.ItemID, uID)
+	if err != nil {
+		if errors.Is(err, postgre.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "not found")
+		}
+		log.Println(err)
+		return nil, status.Error(codes.Internal, failedDBQuery)
+	}
+
+	return &pb.ListVersionsResponse{
+		Versions: models.ItemVersionsToProto(versions),
+		Status:   "success",
+	}, nil
+}
+
+// GetVersion handler returns the requested version of the item. Streamed binary data is returned without the body.
+func (g *GRPCServer) GetVersion(ctx context.Context, in *pb.GetVersionRequest) (*pb.GetVersionResponse, error) {
+	if in.Version < 1 {
+		return nil, status.Error(codes.InvalidArgument, "invalid argument")
+	}
+	uID := ctxfunc.GetUserIDFromCTX(ctx)
+	item, err := historyItem(uID, in.Type, in.Id, in.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := storage.Vault.ItemVersion(in.Type, item.ItemID, uID, in.Version)
+	if err != nil {
+		if errors.Is(err, postgre.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "not found")
+		}
+		log.Println(err)
+		return nil, status.Error(codes.Internal, failedDBQuery)
+	}
+	// all the versions of the deleted item are marked deleted, but only the tombstone has no data.
+	if len(data.Payload) > 0 {
+		data.DeletedAt = sql.NullTime{}
+	}
+
+	resp := &pb.GetVersionResponse{Status: "success"}
+	switch in.Type {
+	case "pair":
+		resp.Item = &pb.GetVersionResponse_Pair{Pair: models.SealedToProtoPair(data)}
+	case "text":
+		resp.Item = &pb.GetVersionResponse_Text{Text: models.SealedToProtoText(data)}
+	case "bin":
+		resp.Item = &pb.GetVersionResponse_BinData{BinData: models.SealedToProtoBin(data)}
+	case "card":
+		resp.Item = &pb.GetVersionResponse_Card{Card: models.SealedToProtoCard(data)}
+	default:
+		resp.Item = &pb.GetVersionResponse_Envelope{Envelope: models.SealedToProtoItem(in.Type, data)}
+	}
+
+	return resp, nil
+}
+
+// RestoreItem handler saves the old version of the item as the new latest version. The deleted item is restored
+// this way too. Version 0 restores the latest version, that is not a tombstone. The payload is bound to the item
+// version, so the client sends the payload of the restored version, sealed for the new latest version next_version.
+func (g *GRPCServer) RestoreItem(ctx context.Context, in *pb.RestoreItemRequest) (*pb.RestoreItemResponse, error) {
+	uID := ctxfunc.GetUserIDFromCTX(ctx)
+	if len(in.Payload) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid argument")
+	}
+	item, err := historyItem(uID, in.Type, in.Id, in.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := storage.Vault.ItemVersions(in.Type, item.ItemID, uID)
+	if err != nil {
+		if errors.Is(err, postgre.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "not found")
+		}
+		log.Println(err)
+		return nil, status.Error(codes.Internal, failedDBQuery)
+	}
+
+	var restored *models.ItemVersion
+	for _, v := range versions {
+		if (in.Version == 0 && !v.Tombstone) || v.Version == in.Version {
+			restored = v
+			break
+		}
+	}
+	switch {
+	case restored == nil:
+		return nil, status.Error(codes.NotFound, "version not found")
+	case restored.Tombstone:
+		return nil, status.Error(codes.InvalidArgument, "deleted version can't be restored")
+	case restored.Version == versions[0].Version:
+		return nil, status.Error(codes.FailedPrecondition, "version is the latest already")
+	case in.NextVersion != versions[0].Version+1:
+		return nil, status.Error(codes.AlreadyExists, newerVersionDetected)
+	}
+
+	err = storage.Vault.ItemRestore(in.Type, item.ItemID, uID, restored.Version, in.NextVersion, in.Payload)
+	if err != nil {
+		switch {
+		case errors.Is(err, postgre.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "version not found")
+		case errors.Is(err, postgre.ErrRecordAlreadyExists):
+			return nil, status.Error(codes.AlreadyExists, newerVersionDetected)
+		}
+		log.Println(err)
+		return nil, status.Error(codes.Internal, "Restore failed. Please try again")
+	}
+
+	return &pb.RestoreItemResponse{Status: "success", Version: in.NextVersion}, nil
+}
+
+// PurgeItem handler erases all the versions of the item. The item is left as the tombstone without data, so the
+// other devices delete their copies. The plaintext item, that has no sealed version, is found by the id, derived
+// from the title.
+func (g *GRPCServer) PurgeItem(ctx context.Context, in *pb.PurgeItemRequest) (*pb.PurgeItemResponse, error) {
+	uID := ctxfunc.GetUserIDFromCTX(ctx)
+	if !models.ValidItemType(in.Type) || !validLookup(in.Id, in.Title) {
+		return nil, status.Error(codes.InvalidArgument, "invalid argument")
+	}
+	id, err := itemID(uID, in.Type, in.Id, in.Title)
+	if err != nil {
+		return nil, lookupStatus(err)
+	}
+
+	version, purged, err := storage.Vault.ItemPurge(in.Type, id, uID)
+	if err != nil {
+		if errors.Is(err, postgre.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "not found")
+		}
+		log.Println(err)
+		return nil, status.Error(codes.Internal, "Purge failed. Please try again")
+	}
+
+	return &pb.PurgeItemResponse{Status: "success", Version: version, Purged: uint32(purged)}, nil
+}
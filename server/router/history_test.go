@@ -0,0 +1,164 @@
+package grpcserver
+
+import (
+	"database/sql"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/EestiChameleon/gophkeeper/server/storage"
+	"github.com/EestiChameleon/gophkeeper/server/storage/testdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// TestHistory verifies, that:
+// 1) invalid and unknown items are rejected
+// 2) versions are listed the latest first, tombstones are marked
+// 3) old version of the deleted item is returned not deleted
+// 4) tombstone and the latest version are not restored, version 0 restores the latest not deleted version
+// 5) restore without the payload or for the version, that is not the one after the latest, is rejected
+func TestHistory(t *testing.T) {
+	// init connect
+	ctx, conn := keeperTestConn(t)
+	defer conn.Close()
+
+	// create client
+	client := pb.NewKeeperClient(conn)
+	// init test storage
+	storage.InitTest()
+	// other tests delete the test pair - it is restored after the test.
+	saved := *testdb.TestPair
+	defer func() { *testdb.TestPair = saved }()
+	testdb.TestPair.DeletedAt, testdb.TestPair.Version = sql.NullTime{}, 7
+
+	tests := []struct {
+		name          string
+		number        uint8
+		dataType      string
+		title         string
+		version       uint32
+		next          uint32
+		payload       []byte
+		errStatusCode codes.Code
+	}{
+		{name: "Test #1: unknown type", number: 1, dataType: "note", title: testdb.TestPair.Title, errStatusCode: codes.InvalidArgument},
+		{name: "Test #2: unknown title", number: 2, dataType: "pair", title: "unknown", errStatusCode: codes.NotFound},
+		{name: "Test #3: list versions", number: 3, dataType: "pair", title: testdb.TestPair.Title},
+		{name: "Test #4: get old version of the deleted item", number: 4, dataType: "pair", title: testdb.TestPair.Title, version: 5},
+		{name: "Test #5: restore tombstone", number: 5, dataType: "pair", title: testdb.TestPair.Title, version: 6, errStatusCode: codes.InvalidArgument},
+		{name: "Test #6: restore latest version", number: 6, dataType: "pair", title: testdb.TestPair.Title, version: 7, errStatusCode: codes.FailedPrecondition},
+		{name: "Test #7: restore old version", number: 7, dataType: "pair", title: testdb.TestPair.Title, version: 5},
+		{name: "Test #8: undelete", number: 8, dataType: "pair", title: testdb.TestPair.Title},
+		{name: "Test #9: restore without payload", number: 9, dataType: "pair", title: testdb.TestPair.Title, version: 5, next: 8, errStatusCode: codes.InvalidArgument},
+		{name: "Test #10: restore for taken version", number: 10, dataType: "pair", title: testdb.TestPair.Title, version: 5, next: 7, payload: []byte("sealed"), errStatusCode: codes.AlreadyExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testdb.TestRestored = 0
+
+			switch tt.number {
+			case 1, 2:
+				_, err := client.ListVersions(ctx, &pb.ListVersionsRequest{Type: tt.dataType, Title: tt.title})
+				assert.Equal(t, tt.errStatusCode, status.Code(err))
+				_, err = client.RestoreItem(ctx, &pb.RestoreItemRequest{Type: tt.dataType, Title: tt.title,
+					Payload: []byte("sealed"), NextVersion: 8})
+				assert.Equal(t, tt.errStatusCode, status.Code(err))
+			case 3:
+				resp, err := client.ListVersions(ctx, &pb.ListVersionsRequest{Type: tt.dataType, Title: tt.title})
+				require.NoError(t, err)
+				require.Len(t, resp.GetVersions(), 3)
+				assert.Equal(t, uint32(7), resp.GetVersions()[0].GetVersion())
+				assert.False(t, resp.GetVersions()[0].GetDeleted())
+				assert.Zero(t, resp.GetVersions()[0].GetDeletedAt())
+				assert.True(t, resp.GetVersions()[1].GetDeleted())
+				assert.False(t, resp.GetVersions()[2].GetDeleted())
+				assert.NotZero(t, resp.GetVersions()[2].GetDeletedAt())
+			case 4:
+				resp, err := client.GetVersion(ctx, &pb.GetVersionRequest{Type: tt.dataType, Title: tt.title, Version: tt.version})
+				require.NoError(t, err)
+				assert.Equal(t, tt.version, resp.GetPair().GetVersion())
+				assert.Equal(t, testdb.TestPairHistory[0].Payload, resp.GetPair().GetPayload())
+				assert.False(t, resp.GetPair().GetDeleted())
+			case 5, 6:
+				_, err := client.RestoreItem(ctx, &pb.RestoreItemRequest{Type: tt.dataType, Title: tt.title, Version: tt.version,
+					Payload: []byte("sealed"), NextVersion: 8})
+				assert.Equal(t, tt.errStatusCode, status.Code(err))
+				assert.Zero(t, testdb.TestRestored)
+			case 7:
+				resp, err := client.RestoreItem(ctx, &pb.RestoreItemRequest{Type: tt.dataType, Title: tt.title, Version: tt.version,
+					Payload: []byte("sealed"), NextVersion: 8})
+				require.NoError(t, err)
+				assert.Equal(t, uint32(8), resp.GetVersion())
+				assert.Equal(t, tt.version, testdb.TestRestored)
+			case 8:
+				// the item is deleted - the latest version is a tombstone
+				testdb.TestPair.DeletedAt, testdb.TestPair.Payload = sql.NullTime{Valid: true}, []byte{}
+
+				resp, err := client.RestoreItem(ctx, &pb.RestoreItemRequest{Type: tt.dataType, Title: tt.title,
+					Payload: []byte("sealed"), NextVersion: 8})
+				require.NoError(t, err)
+				assert.Equal(t, uint32(8), resp.GetVersion())
+				assert.Equal(t, uint32(5), testdb.TestRestored)
+			case 9, 10:
+				_, err := client.RestoreItem(ctx, &pb.RestoreItemRequest{Type: tt.dataType, Title: tt.title, Version: tt.version,
+					Payload: tt.payload, NextVersion: tt.next})
+				assert.Equal(t, tt.errStatusCode, status.Code(err))
+				assert.Zero(t, testdb.TestRestored)
+			}
+		})
+	}
+}
+
+// TestPurgeItem verifies, that invalid and unknown items are rejected and the item is erased with all the versions.
+func TestPurgeItem(t *testing.T) {
+	// init connect
+	ctx, conn := keeperTestConn(t)
+	defer conn.Close()
+
+	// create client
+	client := pb.NewKeeperClient(conn)
+	// init test storage
+	storage.InitTest()
+
+	tests := []struct {
+		name          string
+		number        uint8
+		dataType      string
+		id            string
+		title         string
+		errStatusCode codes.Code
+	}{
+		{name: "Test #1: empty title", number: 1, dataType: "pair", errStatusCode: codes.InvalidArgument},
+		{name: "Test #2: unknown title", number: 2, dataType: "text", title: "unknown", errStatusCode: codes.NotFound},
+		{name: "Test #3: ambiguous title", number: 3, dataType: "pair", title: testdb.TestAmbiguousTitle,
+			errStatusCode: codes.FailedPrecondition},
+		{name: "Test #4: invalid id", number: 4, dataType: "pair", id: "1", errStatusCode: codes.InvalidArgument},
+		{name: "Test #5: correct title", number: 5, dataType: "pair", title: testdb.TestPair.Title},
+		{name: "Test #6: correct id", number: 6, dataType: "pair", id: testdb.TestPair.ItemID},
+		{name: "Test #7: plaintext item without sealed versions", number: 7, dataType: "pair",
+			id: testdb.TestLegacyPair.ItemID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testdb.TestPurged = ``
+			resp, err := client.PurgeItem(ctx, &pb.PurgeItemRequest{Type: tt.dataType, Id: tt.id, Title: tt.title})
+
+			switch tt.number {
+			case 1, 2, 3, 4:
+				assert.Equal(t, tt.errStatusCode, status.Code(err))
+				assert.Empty(t, testdb.TestPurged)
+			case 5, 6:
+				require.NoError(t, err)
+				assert.Equal(t, testdb.TestPair.Version+1, resp.GetVersion())
+				assert.Equal(t, uint32(len(testdb.TestPairHistory)+1), resp.GetPurged())
+				assert.Equal(t, testdb.TestPair.ItemID, testdb.TestPurged)
+			case 7:
+				require.NoError(t, err)
+				assert.Equal(t, testdb.TestLegacyPair.Version+1, resp.GetVersion())
+				assert.Equal(t, uint32(1), resp.GetPurged())
+				assert.Equal(t, testdb.TestLegacyPair.ItemID, testdb.TestPurged)
+			}
+		})
+	}
+}
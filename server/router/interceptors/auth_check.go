@@ -2,8 +2,10 @@ package interceptors
 
 import (
 	"context"
+	"errors"
 	"github.com/EestiChameleon/gophkeeper/server/ctxfunc"
 	"github.com/EestiChameleon/gophkeeper/server/service"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -15,16 +17,40 @@ var (
 	SkipCheckMethods = map[string]struct{}{
 		"/gophkeeper.proto.Keeper/RegisterUser": {}, // we don't need to check the token
 		"/gophkeeper.proto.Keeper/LoginUser":    {}, // for these methods.
+		"/gophkeeper.proto.Keeper/RefreshToken": {},
 	}
 )
 
 // AuthCheckGRPC interceptor verifies the authentication bearer token.
+// Expired tokens and tokens of revoked sessions are rejected with codes.Unauthenticated.
 func AuthCheckGRPC(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 	log.Println("--> unary interceptor: ", info.FullMethod)
+	ctx, err = authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// AuthCheckStreamGRPC interceptor verifies the authentication bearer token of the streaming calls, see AuthCheckGRPC.
+func AuthCheckStreamGRPC(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	log.Println("--> stream interceptor: ", info.FullMethod)
+	ctx, err := authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	wrapped := grpc_middleware.WrapServerStream(ss)
+	wrapped.WrappedContext = ctx
+	return handler(srv, wrapped)
+}
+
+// authenticate checks the bearer token of the method call and puts the user and session id to the context.
+func authenticate(ctx context.Context, method string) (context.Context, error) {
 	// check for method, which doesn't need to be intercepted
-	_, ok := SkipCheckMethods[info.FullMethod]
+	_, ok := SkipCheckMethods[method]
 	if ok {
-		return handler(ctx, req)
+		return ctx, nil
 	}
 	// check part
 	token, err := grpc_auth.AuthFromMD(ctx, "bearer")
@@ -32,10 +58,19 @@ func AuthCheckGRPC(ctx context.Context, req interface{}, info *grpc.UnaryServerI
 		return nil, err
 	}
 
-	userID, err := service.JWTDecodeUserID(token)
+	claims, err := service.JWTDecodeAccess(token)
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, "invalid auth token: %v", err)
 	}
 
-	return handler(ctxfunc.SetUserIDToCTX(ctx, userID), req)
+	if err = service.CheckSession(claims.SessionID); err != nil {
+		if errors.Is(err, service.ErrSessionRevoked) {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid auth token: %v", err)
+		}
+		log.Println(err)
+		return nil, status.Error(codes.Internal, "failed to verify auth token")
+	}
+
+	ctx = ctxfunc.SetUserIDToCTX(ctx, claims.UserID)
+	return ctxfunc.SetSessionIDToCTX(ctx, claims.SessionID), nil
 }
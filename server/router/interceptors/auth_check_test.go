@@ -0,0 +1,103 @@
+package interceptors
+
+import (
+	"context"
+	"github.com/EestiChameleon/gophkeeper/server/ctxfunc"
+	"github.com/EestiChameleon/gophkeeper/server/service"
+	"github.com/EestiChameleon/gophkeeper/server/storage"
+	"github.com/robbert229/jwt"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+// TestAuthCheckGRPC verifies, that:
+// 1) methods from SkipCheckMethods are not checked
+// 2) missing, expired and revoked tokens are rejected
+// 3) valid token puts user and session id to the context
+func TestAuthCheckGRPC(t *testing.T) {
+	storage.InitTest()
+
+	active, err := service.NewSession(7)
+	assert.NoError(t, err)
+	activeClaims, err := service.JWTDecodeAccess(active.AccessToken)
+	assert.NoError(t, err)
+
+	revoked, err := service.NewSession(7)
+	assert.NoError(t, err)
+	revokedClaims, err := service.JWTDecodeAccess(revoked.AccessToken)
+	assert.NoError(t, err)
+	assert.NoError(t, service.RevokeSession(revokedClaims.SessionID))
+
+	expiredClaims := jwt.NewClaim()
+	expiredClaims.Set("sub", 7)
+	expiredClaims.Set("sid", activeClaims.SessionID)
+	expiredClaims.Set("jti", "expired")
+	expiredClaims.SetTime("exp", time.Now().Add(-time.Minute))
+	expired, err := service.JWTEncode(expiredClaims)
+	assert.NoError(t, err)
+
+	// handler returns ids found in the context
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return []int{ctxfunc.GetUserIDFromCTX(ctx), ctxfunc.GetSessionIDFromCTX(ctx)}, nil
+	}
+
+	type want struct {
+		errStatusCode codes.Code
+		ids           []int
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		token  string
+		want   want
+	}{
+		{
+			name:   "Test #1: skipped method",
+			method: "/gophkeeper.proto.Keeper/LoginUser",
+			want:   want{ids: []int{-1, -1}},
+		},
+		{
+			name:   "Test #2: no token",
+			method: "/gophkeeper.proto.Keeper/GetPair",
+			want:   want{errStatusCode: codes.Unauthenticated},
+		},
+		{
+			name:   "Test #3: expired token",
+			method: "/gophkeeper.proto.Keeper/GetPair",
+			token:  expired,
+			want:   want{errStatusCode: codes.Unauthenticated},
+		},
+		{
+			name:   "Test #4: revoked session",
+			method: "/gophkeeper.proto.Keeper/GetPair",
+			token:  revoked.AccessToken,
+			want:   want{errStatusCode: codes.Unauthenticated},
+		},
+		{
+			name:   "Test #5: valid token",
+			method: "/gophkeeper.proto.Keeper/GetPair",
+			token:  active.AccessToken,
+			want:   want{ids: []int{7, activeClaims.SessionID}},
+		},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.token != `` {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+tt.token))
+		}
+
+		resp, err := AuthCheckGRPC(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+		if tt.want.errStatusCode != codes.OK {
+			assert.Equal(t, tt.want.errStatusCode, status.Code(err), tt.name)
+			continue
+		}
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.want.ids, resp, tt.name)
+	}
+}
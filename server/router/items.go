@@ -0,0 +1,306 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/EestiChameleon/gophkeeper/server/ctxfunc"
+	"github.com/EestiChameleon/gophkeeper/server/storage"
+	"github.com/EestiChameleon/gophkeeper/server/storage/postgre"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"sort"
+)
+
+const ambiguousTitle = "Several items have the title. Please use the item id."
+
+// validLookup checks the item reference of the request: the item id or, if it is empty, the title.
+func validLookup(id, title string) bool {
+	if id != `` {
+		return models.ValidItemID(id)
+	}
+	return title != ``
+}
+
+// lookup finds the latest version of the item by id or, if it is empty, by title. Could be a tombstone.
+func lookup(uID int, dataType, id, title string) (*models.Sealed, error) {
+	if id != `` {
+		return storage.Vault.ItemByID(dataType, id, uID)
+	}
+	return storage.Vault.ItemByTitle(dataType, title, uID)
+}
+
+// itemID returns the id of the saved item. Clients, that don't know the item ids, send the items without them:
+// the item is found by title, the new one gets the id derived from the title, like the items saved before the ids.
+func itemID(uID int, dataType, id, title string) (string, error) {
+	if id != `` {
+		return id, nil
+	}
+
+	data, err := storage.Vault.ItemByTitle(dataType, title, uID)
+	switch {
+	case err == nil:
+		return data.ItemID, nil
+	case errors.Is(err, postgre.ErrNotFound):
+		return models.LegacyItemID(dataType, title), nil
+	}
+	return ``, err
+}
+
+// lookupStatus converts the item lookup error to the response status.
+func lookupStatus(err error) error {
+	switch {
+	case errors.Is(err, postgre.ErrNotFound):
+		return status.Error(codes.NotFound, "not found")
+	case errors.Is(err, postgre.ErrAmbiguous):
+		return status.Error(codes.FailedPrecondition, ambiguousTitle)
+	}
+	log.Println(err)
+	return status.Error(codes.Internal, failedDBQuery)
+}
+
+// saveVersion saves the new version of the item. Streamed bin version keeps the body of the latest one,
+// the body itself is sent with UploadBin: returns ErrNotFound, if there is no body to keep.
+func saveVersion(uID int, id string, it *pb.Item) error {
+	if it.Type == "bin" && it.Streamed {
+		return storage.Vault.BinAddMeta(uID, id, it.Title, it.Payload, it.Version)
+	}
+	return storage.Vault.ItemAdd(it.Type, uID, id, it.Title, it.Payload, it.Version)
+}
+
+// getItem returns the latest version of the item, found by id or title. Deleted item is not found.
+// Returns the response status on error.
+func getItem(uID int, dataType, id, title string) (*models.Sealed, error) {
+	if !models.ValidItemType(dataType) || !validLookup(id, title) {
+		return nil, status.Error(codes.InvalidArgument, "invalid argument")
+	}
+
+	data, err := lookup(uID, dataType, id, title)
+	if err == nil && data.DeletedAt.Valid {
+		// latest version is a tombstone
+		err = postgre.ErrNotFound
+	}
+	if err != nil {
+		if errors.Is(err, postgre.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "not found")
+		}
+		if errors.Is(err, postgre.ErrAmbiguous) {
+			return nil, status.Error(codes.FailedPrecondition, ambiguousTitle)
+		}
+		log.Println(err)
+		return nil, status.Error(codes.Internal, "failed to obtain latest data")
+	}
+
+	return data, nil
+}
+
+// putItem checks and saves the new version of the item. The version is saved, only if it is newer, than the latest
+// one in DB. Item without the id is found by title. The version with another title renames the item.
+// Returns the saved item id or the response status on error.
+func putItem(uID int, it *pb.Item) (string, error) {
+	if !models.ValidItemType(it.GetType()) || it.GetVersion() < 1 || it.GetTitle() == `` || len(it.GetPayload()) == 0 ||
+		!validLookup(it.GetId(), it.GetTitle()) {
+		return ``, status.Error(codes.InvalidArgument, "invalid argument")
+	}
+
+	id, err := itemID(uID, it.Type, it.Id, it.Title)
+	if err != nil {
+		return ``, lookupStatus(err)
+	}
+	// first - compare version in DB
+	db, err := storage.Vault.ItemByID(it.Type, id, uID)
+	if err != nil && !errors.Is(err, postgre.ErrNotFound) {
+		log.Println(err)
+		return ``, status.Error(codes.Internal, failedDBQuery)
+	}
+	// case when we found a version in DB. Deleted version (tombstone) is compared too:
+	// data, older than the deletion, must not resurrect the item.
+	if err == nil && it.Version <= db.Version {
+		// DB has actual or newer version - error and ask customer to sync
+		return ``, status.Error(codes.AlreadyExists, newerVersionDetected)
+	}
+
+	// received version is the latest (or the first one) => save. The concurrent request could save it first.
+	err = saveVersion(uID, id, it)
+	if errors.Is(err, postgre.ErrNotFound) {
+		return ``, status.Error(codes.FailedPrecondition, noStreamedBody)
+	}
+	if errors.Is(err, postgre.ErrRecordAlreadyExists) {
+		return ``, status.Error(codes.AlreadyExists, newerVersionDetected)
+	}
+	if err != nil {
+		log.Println(err)
+		return ``, status.Error(codes.Internal, failedToSaveNewVersion)
+	}
+
+	return id, nil
+}
+
+// deleteItem deletes the item, found by id or title. Returns the tombstone version or the response status on error.
+func deleteItem(uID int, dataType, id, title string) (uint32, error) {
+	if !models.ValidItemType(dataType) || !validLookup(id, title) {
+		return 0, status.Error(codes.InvalidArgument, "invalid argument")
+	}
+
+	data, err := lookup(uID, dataType, id, title)
+	if err != nil {
+		return 0, lookupStatus(err)
+	}
+
+	version, err := storage.Vault.ItemDelete(dataType, data.ItemID, uID)
+	if err != nil {
+		if errors.Is(err, postgre.ErrNotFound) {
+			return 0, status.Error(codes.NotFound, "not found")
+		}
+		log.Println(err)
+		return 0, status.Error(codes.Internal, "Delete failed. Please try again")
+	}
+
+	return version, nil
+}
+
+// GetItem handler returns the item of the type, found by id or title.
+func (g *GRPCServer) GetItem(ctx context.Context, in *pb.GetItemRequest) (*pb.GetItemResponse, error) {
+	data, err := getItem(ctxfunc.GetUserIDFromCTX(ctx), in.Type, in.Id, in.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetItemResponse{
+		Item:   models.SealedToProtoItem(in.Type, data),
+		Status: "success",
+	}, nil
+}
+
+// PutItem handler checks and saves the new version of the item, see putItem.
+func (g *GRPCServer) PutItem(ctx context.Context, in *pb.PutItemRequest) (*pb.PutItemResponse, error) {
+	id, err := putItem(ctxfunc.GetUserIDFromCTX(ctx), in.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PutItemResponse{Status: "success", Id: id}, nil
+}
+
+// DeleteItem handler deletes the item of the type by id or title.
+func (g *GRPCServer) DeleteItem(ctx context.Context, in *pb.DeleteItemRequest) (*pb.DeleteItemResponse, error) {
+	version, err := deleteItem(ctxfunc.GetUserIDFromCTX(ctx), in.Type, in.Id, in.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteItemResponse{Status: "success", Version: version}, nil
+}
+
+// ListItems handler returns the latest versions of the user items of the type, or of all types. Deleted items
+// (tombstones) are returned, only if requested.
+func (g *GRPCServer) ListItems(ctx context.Context, in *pb.ListItemsRequest) (*pb.ListItemsResponse, error) {
+	types := models.ItemTypes
+	if in.Type != `` {
+		if !models.ValidItemType(in.Type) {
+			return nil, status.Error(codes.InvalidArgument, "invalid argument")
+		}
+		types = []string{in.Type}
+	}
+
+	uID := ctxfunc.GetUserIDFromCTX(ctx)
+	resp := &pb.ListItemsResponse{Status: "success"}
+	for _, dataType := range types {
+		items, err := storage.Vault.ItemList(dataType, uID)
+		if err != nil {
+			log.Println(err)
+			return nil, status.Error(codes.Internal, failedDBQuery)
+		}
+		for _, item := range items {
+			if item.DeletedAt.Valid && !in.Deleted {
+				continue
+			}
+			resp.Items = append(resp.Items, models.SealedToProtoItem(dataType, item))
+		}
+	}
+
+	return resp, nil
+}
+
+// SearchItems handler returns the latest versions of the live user items of the type, or of all types, whose title
+// matches the query, see models.Match. The best matches go first. The titles are searched, only the found items
+// are read.
+func (g *GRPCServer) SearchItems(ctx context.Context, in *pb.SearchItemsRequest) (*pb.SearchItemsResponse, error) {
+	types := models.ItemTypes
+	if in.Type != `` {
+		if !models.ValidItemType(in.Type) {
+			return nil, status.Error(codes.InvalidArgument, "invalid argument")
+		}
+		types = []string{in.Type}
+	}
+
+	uID := ctxfunc.GetUserIDFromCTX(ctx)
+	var (
+		found  []*pb.Item
+		scores []int
+	)
+	for _, dataType := range types {
+		titles, err := storage.Vault.ItemTitles(dataType, uID)
+		if err != nil {
+			log.Println(err)
+			return nil, status.Error(codes.Internal, failedDBQuery)
+		}
+		for _, head := range titles {
+			if head.DeletedAt.Valid {
+				continue
+			}
+			score, ok := models.Match(in.Query, head.Title, in.Fuzzy)
+			if !ok {
+				continue
+			}
+			item, err := storage.Vault.ItemByID(dataType, head.ItemID, uID)
+			if errors.Is(err, postgre.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				log.Println(err)
+				return nil, status.Error(codes.Internal, failedDBQuery)
+			}
+			// the item could be changed meanwhile.
+			if item.DeletedAt.Valid {
+				continue
+			}
+			found = append(found, models.SealedToProtoItem(dataType, item))
+			scores = append(scores, score)
+		}
+	}
+
+	order := make([]int, len(found))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return scores[order[i]] > scores[order[j]] })
+	resp := &pb.SearchItemsResponse{Status: "success"}
+	for _, i := range order {
+		resp.Items = append(resp.Items, found[i])
+	}
+
+	return resp, nil
+}
+
+// LegacyItems handler sends the plaintext items, saved before the items were sealed on the client side, one by one.
+// The client seals them and saves as the next version: the plaintext is erased then.
+func (g *GRPCServer) LegacyItems(in *pb.LegacyItemsRequest, stream pb.Keeper_LegacyItemsServer) error {
+	uID := ctxfunc.GetUserIDFromCTX(stream.Context())
+	for _, dataType := range models.ItemTypes {
+		items, err := storage.Vault.LegacyItems(dataType, uID)
+		if err != nil {
+			log.Println(err)
+			return status.Error(codes.Internal, failedDBQuery)
+		}
+		for _, item := range items {
+			if err = stream.Send(&pb.LegacyItemsResponse{Item: models.LegacyToProto(item)}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package grpcserver
+
+import (
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/EestiChameleon/gophkeeper/server/storage"
+	"github.com/EestiChameleon/gophkeeper/server/storage/testdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"testing"
+)
+
+// TestGetItem verifies, that the items of all types are returned in the envelope and the request is checked.
+func TestGetItem(t *testing.T) {
+	ctx, conn := keeperTestConn(t)
+	defer conn.Close()
+	client := pb.NewKeeperClient(conn)
+	storage.InitTest()
+
+	tests := []struct {
+		name     string
+		number   uint8
+		dataType string
+		id       string
+		title    string
+		code     codes.Code
+	}{
+		{name: "Test #1: unknown type", number: 1, dataType: "note", title: testdb.TestPair.Title, code: codes.InvalidArgument},
+		{name: "Test #2: unknown data", number: 1, dataType: "text", title: "unknown", code: codes.NotFound},
+		{name: "Test #3: ambiguous title", number: 1, dataType: "card", title: testdb.TestAmbiguousTitle,
+			code: codes.FailedPrecondition},
+		{name: "Test #4: by title", number: 2, dataType: "card", title: testdb.TestCard.Title},
+		{name: "Test #5: streamed bin by id", number: 3, dataType: "bin", id: testdb.TestStreamedBin.ItemID},
+		{name: "Test #6: type without the typed message", number: 4, dataType: "otp", title: testdb.TestOTP.Title},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.GetItem(ctx, &pb.GetItemRequest{Type: tt.dataType, Id: tt.id, Title: tt.title})
+			switch tt.number {
+			case 1:
+				assert.Equal(t, tt.code, status.Code(err))
+			case 2:
+				require.NoError(t, err)
+				assert.Equal(t, models.CardToItem(models.SealedToProtoCard(testdb.TestCard)), resp.GetItem())
+			case 3:
+				require.NoError(t, err)
+				assert.Equal(t, "bin", resp.GetItem().GetType())
+				assert.True(t, resp.GetItem().GetStreamed())
+				assert.Equal(t, uint64(testdb.TestStreamedBin.Size), resp.GetItem().GetSize())
+			case 4:
+				require.NoError(t, err)
+				assert.Equal(t, models.SealedToProtoItem("otp", testdb.TestOTP), resp.GetItem())
+			}
+		})
+	}
+}
+
+// TestPutItem verifies, that the items are saved with the PostPair & co rules.
+func TestPutItem(t *testing.T) {
+	ctx, conn := keeperTestConn(t)
+	defer conn.Close()
+	client := pb.NewKeeperClient(conn)
+	storage.InitTest()
+
+	tests := []struct {
+		name   string
+		number uint8
+		item   *pb.Item
+		code   codes.Code
+	}{
+		{name: "Test #1: unknown type", number: 1,
+			item: &pb.Item{Type: "note", Title: "n1", Version: 1, Payload: []byte("sealed")}, code: codes.InvalidArgument},
+		{name: "Test #2: outdated version", number: 1,
+			item: &pb.Item{Type: "text", Id: testdb.TestText.ItemID, Title: testdb.TestText.Title,
+				Version: testdb.TestText.Version, Payload: []byte("sealed")}, code: codes.AlreadyExists},
+		{name: "Test #3: streamed without the body", number: 1,
+			item: &pb.Item{Type: "bin", Id: testdb.TestBin.ItemID, Title: testdb.TestBin.Title,
+				Version: testdb.TestBin.Version + 1, Payload: []byte("sealed"), Streamed: true}, code: codes.FailedPrecondition},
+		{name: "Test #4: new item without the id", number: 2,
+			item: &pb.Item{Type: "card", Title: "newCard", Version: 1, Payload: []byte("sealed")}},
+		{name: "Test #5: version is saved by the concurrent request first", number: 1,
+			item: &pb.Item{Type: "pair", Title: testdb.TestRacedTitle, Version: 1, Payload: []byte("sealed")},
+			code: codes.AlreadyExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.PutItem(ctx, &pb.PutItemRequest{Item: tt.item})
+			switch tt.number {
+			case 1:
+				assert.Equal(t, tt.code, status.Code(err))
+			case 2:
+				require.NoError(t, err)
+				assert.Equal(t, models.LegacyItemID("card", "newCard"), resp.GetId())
+				assert.Equal(t, resp.GetId(), testdb.TestAdded.ItemID)
+				assert.Equal(t, "newCard", testdb.TestAdded.Title)
+			}
+		})
+	}
+}
+
+// TestDeleteItem verifies, that the item is deleted and not returned after that.
+func TestDeleteItem(t *testing.T) {
+	ctx, conn := keeperTestConn(t)
+	defer conn.Close()
+	client := pb.NewKeeperClient(conn)
+	storage.InitTest()
+	// other tests need the card
+	saved := *testdb.TestCard
+	defer func() { *testdb.TestCard = saved }()
+
+	_, err := client.DeleteItem(ctx, &pb.DeleteItemRequest{Type: "card"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := client.DeleteItem(ctx, &pb.DeleteItemRequest{Type: "card", Id: saved.ItemID})
+	require.NoError(t, err)
+	assert.Equal(t, saved.Version+1, resp.GetVersion())
+
+	_, err = client.GetItem(ctx, &pb.GetItemRequest{Type: "card", Id: saved.ItemID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.DeleteItem(ctx, &pb.DeleteItemRequest{Type: "card", Id: saved.ItemID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// tombstones are listed on request only
+	list, err := client.ListItems(ctx, &pb.ListItemsRequest{Type: "card"})
+	require.NoError(t, err)
+	assert.Empty(t, list.GetItems())
+	list, err = client.ListItems(ctx, &pb.ListItemsRequest{Type: "card", Deleted: true})
+	require.NoError(t, err)
+	require.Len(t, list.GetItems(), 1)
+	assert.True(t, list.GetItems()[0].GetDeleted())
+}
+
+// TestListItems verifies, that the items of one or all types are listed.
+func TestListItems(t *testing.T) {
+	ctx, conn := keeperTestConn(t)
+	defer conn.Close()
+	client := pb.NewKeeperClient(conn)
+	storage.InitTest()
+
+	tests := []struct {
+		name     string
+		number   uint8
+		dataType string
+	}{
+		{name: "Test #1: unknown type", number: 1, dataType: "note"},
+		{name: "Test #2: one type", number: 2, dataType: "bin"},
+		{name: "Test #3: all types", number: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.ListItems(ctx, &pb.ListItemsRequest{Type: tt.dataType})
+			switch tt.number {
+			case 1:
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			case 2:
+				require.NoError(t, err)
+				require.Len(t, resp.GetItems(), 2)
+				assert.Equal(t, testdb.TestBin.ItemID, resp.GetItems()[0].GetId())
+				assert.Equal(t, testdb.TestStreamedBin.ItemID, resp.GetItems()[1].GetId())
+			case 3:
+				require.NoError(t, err)
+				var types []string
+				for _, it := range resp.GetItems() {
+					types = append(types, it.GetType())
+				}
+				assert.Equal(t, []string{"pair", "text", "bin", "bin", "card", "otp"}, types)
+			}
+		})
+	}
+}
+
+// TestSearchItems verifies, that the items are found by title and the best matches go first.
+func TestSearchItems(t *testing.T) {
+	ctx, conn := keeperTestConn(t)
+	defer conn.Close()
+	client := pb.NewKeeperClient(conn)
+	storage.InitTest()
+
+	bins := []string{testdb.TestBin.ItemID, testdb.TestStreamedBin.ItemID}
+	tests := []struct {
+		name   string
+		number uint8
+		req    *pb.SearchItemsRequest
+		want   []string
+	}{
+		{name: "Test #1: unknown type", number: 1, req: &pb.SearchItemsRequest{Type: "note", Query: "test"}},
+		{name: "Test #2: substring, the case is ignored", number: 2, req: &pb.SearchItemsRequest{Query: "BIN"}, want: bins},
+		{name: "Test #3: no fuzzy match without fuzzy", number: 2, req: &pb.SearchItemsRequest{Query: "tsb"}},
+		{name: "Test #4: fuzzy", number: 2, req: &pb.SearchItemsRequest{Query: "tsb", Fuzzy: true}, want: bins},
+		{name: "Test #5: one type", number: 2, req: &pb.SearchItemsRequest{Type: "otp", Query: "test"},
+			want: []string{testdb.TestOTP.ItemID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.SearchItems(ctx, tt.req)
+			switch tt.number {
+			case 1:
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			case 2:
+				require.NoError(t, err)
+				var ids []string
+				for _, it := range resp.GetItems() {
+					ids = append(ids, it.GetId())
+					// the found items are read with the payloads.
+					assert.NotEmpty(t, it.GetPayload())
+				}
+				assert.Equal(t, tt.want, ids)
+			}
+		})
+	}
+}
+
+// TestLegacyItems verifies, that the plaintext items are streamed one by one.
+func TestLegacyItems(t *testing.T) {
+	ctx, conn := keeperTestConn(t)
+	defer conn.Close()
+	client := pb.NewKeeperClient(conn)
+	storage.InitTest()
+
+	stream, err := client.LegacyItems(ctx, &pb.LegacyItemsRequest{})
+	require.NoError(t, err)
+	var items []*pb.LegacyItem
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		items = append(items, resp.GetItem())
+	}
+
+	require.Len(t, items, 1)
+	assert.Equal(t, "pair", items[0].GetType())
+	assert.Equal(t, testdb.TestLegacyPair.ItemID, items[0].GetId())
+	assert.Equal(t, testdb.TestLegacyPair.Version, items[0].GetVersion())
+	assert.Equal(t, testdb.TestLegacyPair.Pass, items[0].GetPass())
+}
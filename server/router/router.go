@@ -11,8 +11,10 @@ import (
 	"github.com/EestiChameleon/gophkeeper/server/service"
 	"github.com/EestiChameleon/gophkeeper/server/storage"
 	"github.com/EestiChameleon/gophkeeper/server/storage/postgre"
+	"github.com/EestiChameleon/gophkeeper/server/tlsconf"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 	"log"
 	"net"
@@ -24,6 +26,7 @@ var (
 	newerVersionDetected   = "Current / newer version found in database. Please synchronize you app to get the most actual data."
 	failedToSaveNewVersion = "Failed to save new record. Please try again."
 	failedDBQuery          = "failed to obtain database data"
+	noStreamedBody         = "The latest version has no streamed body to keep. Please upload the data."
 )
 
 type GRPCServer struct {
@@ -31,11 +34,18 @@ type GRPCServer struct {
 	pb.UnimplementedKeeperServer
 }
 
-// InitGRPCServer initializes a new gRPC server.
+// InitGRPCServer initializes a new gRPC server. Connections are secured with TLS, certificates are taken from cfg.
 func InitGRPCServer() (*GRPCServer, error) {
+	tlsConf, err := tlsconf.ServerConfig(cfg.Current.TLSCertFile, cfg.Current.TLSKeyFile, cfg.Current.TLSClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
 	// creates a gRPC server
 	s := grpc.NewServer(
-		grpc.UnaryInterceptor(interceptors.AuthCheckGRPC))
+		grpc.Creds(credentials.NewTLS(tlsConf)),
+		grpc.UnaryInterceptor(interceptors.AuthCheckGRPC),
+		grpc.StreamInterceptor(interceptors.AuthCheckStreamGRPC))
 	// register the service
 	pb.RegisterKeeperServer(s, &GRPCServer{})
 
@@ -45,7 +55,7 @@ func InitGRPCServer() (*GRPCServer, error) {
 // Start launch the server.
 func (g *GRPCServer) Start() error {
 	// determines the server port
-	listen, err := net.Listen("tcp", cfg.ServerAddress)
+	listen, err := net.Listen("tcp", cfg.Current.ServerAddress)
 	if err != nil {
 		return err
 	}
@@ -61,37 +71,49 @@ func (g *GRPCServer) ShutDown() error {
 	return nil
 }
 
-// RegisterUser handler creates new user. Returns JWT with userID encoded.
+// RegisterUser handler creates new user. Returns tokens of the new login session.
 func (g *GRPCServer) RegisterUser(ctx context.Context, in *pb.RegisterUserRequest) (*pb.RegisterUserResponse, error) {
 	if in.ServiceLogin == `` || in.ServicePass == `` {
 		return nil, status.Error(codes.InvalidArgument, "invalid argument")
 	}
+	// vault key derivation data is optional - could be set later by SetVaultKDF.
+	if in.Kdf != nil && (in.Kdf.Params == `` || len(in.Kdf.Check) == 0) {
+		return nil, status.Error(codes.InvalidArgument, "invalid argument")
+	}
 
-	usrID, err := storage.Vault.UserAdd(in.ServiceLogin, service.EncryptPass(in.ServicePass))
+	passHash, err := service.HashPassword(in.ServicePass)
 	if err != nil {
 		log.Println(err)
 		return nil, status.Error(codes.Internal, "failed to register new user")
 	}
 
-	userJWT, err := service.JWTEncodeUserID(usrID)
+	usrID, err := storage.Vault.UserAdd(in.ServiceLogin, passHash, in.Kdf.GetParams(), in.Kdf.GetCheck())
+	if err != nil {
+		log.Println(err)
+		return nil, status.Error(codes.Internal, "failed to register new user")
+	}
+
+	tokens, err := service.NewSession(usrID)
 	if err != nil {
 		log.Println(err)
 		return nil, status.Error(codes.Internal, "failed to create jwt")
 	}
 
 	return &pb.RegisterUserResponse{
-		Status: "registered",
-		Jwt:    userJWT,
+		Status:       "registered",
+		Jwt:          tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt.Unix(),
 	}, nil
 }
 
-// LoginUser authenticates the user. Provides the latest data from database and JWT.
+// LoginUser authenticates the user. Returns tokens of the new login session.
 func (g *GRPCServer) LoginUser(ctx context.Context, in *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
 	if in.ServiceLogin == "" || in.ServicePass == "" {
 		return nil, status.Error(codes.InvalidArgument, "invalid argument")
 	}
 
-	token, err := service.CheckAuthData(service.LoginData{
+	u, tokens, err := service.CheckAuthData(service.LoginData{
 		Login:    in.ServiceLogin,
 		Password: in.ServicePass,
 	})
@@ -105,342 +127,210 @@ func (g *GRPCServer) LoginUser(ctx context.Context, in *pb.LoginUserRequest) (*p
 		}
 	}
 
-	return &pb.LoginUserResponse{
-		Status: "login successful",
-		Jwt:    token,
-	}, nil
+	resp := &pb.LoginUserResponse{
+		Status:       "login successful",
+		Jwt:          tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt.Unix(),
+	}
+	if u.KDFParams.Valid {
+		resp.Kdf = &pb.VaultKDF{Params: u.KDFParams.String, Check: u.KDFCheck}
+	}
+
+	return resp, nil
 }
 
-// GetPair handler returns the found by title pair data.
-func (g *GRPCServer) GetPair(ctx context.Context, in *pb.GetPairRequest) (*pb.GetPairResponse, error) {
-	if in.Title == "" {
+// RefreshToken handler exchanges the refresh token for a new access&refresh token pair.
+func (g *GRPCServer) RefreshToken(ctx context.Context, in *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	if in.RefreshToken == `` {
 		return nil, status.Error(codes.InvalidArgument, "invalid argument")
 	}
 
-	data, err := storage.Vault.PairByTitle(in.Title, ctxfunc.GetUserIDFromCTX(ctx))
+	tokens, err := service.RefreshSession(in.RefreshToken)
 	if err != nil {
-		if errors.Is(err, postgre.ErrNotFound) {
-			return &pb.GetPairResponse{
-				Pairs:  nil,
-				Status: "not found",
-			}, status.Error(codes.NotFound, "not found")
+		if errors.Is(err, service.ErrSessionRevoked) {
+			return nil, status.Error(codes.Unauthenticated, "session expired. please login")
 		}
 		log.Println(err)
-		return nil, status.Error(codes.Internal, "failed to obtain latest data")
+		return nil, status.Error(codes.Internal, "failed to refresh token")
 	}
 
-	return &pb.GetPairResponse{
-		Pairs:  models.ModelsToProtoPair(data),
-		Status: "success",
+	return &pb.RefreshTokenResponse{
+		Status:       "success",
+		Jwt:          tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt.Unix(),
 	}, nil
 }
 
-// PostPair handler checks and saves new pair data to database.
-// Handler verifies, if the provided pair data is the latest version and allows to proceed further.
-func (g *GRPCServer) PostPair(ctx context.Context, in *pb.PostPairRequest) (*pb.PostPairResponse, error) {
-	if in.Pair.Version < 1 || in.Pair.Login == `` || in.Pair.Title == `` || in.Pair.Pass == `` {
-		return nil, status.Error(codes.InvalidArgument, "invalid argument")
-	}
-
-	// first - compare version in DB
-	dbPair, err := storage.Vault.PairByTitle(in.Pair.Title, ctxfunc.GetUserIDFromCTX(ctx))
-	if err != nil && !errors.Is(err, postgre.ErrNotFound) {
+// Logout handler revokes the current login session. Its tokens are not accepted anymore.
+func (g *GRPCServer) Logout(ctx context.Context, in *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	if err := service.RevokeSession(ctxfunc.GetSessionIDFromCTX(ctx)); err != nil {
 		log.Println(err)
-		return nil, status.Error(codes.Internal, failedDBQuery)
-	}
-	// in case not found - we can save passed version. No overwriting data.
-	if errors.Is(err, postgre.ErrNotFound) {
-		err = storage.Vault.PairAdd(ctxfunc.GetUserIDFromCTX(ctx), in.Pair.Title, in.Pair.Login, in.Pair.Pass, in.Pair.Comment, in.Pair.Version)
-		if err != nil {
-			log.Println(err)
-			return nil, status.Error(codes.Internal, failedToSaveNewVersion)
-		}
-		// new record saved (first version?)
-		return &pb.PostPairResponse{Status: "success"}, nil
+		return nil, status.Error(codes.Internal, "Logout failed. Please try again")
 	}
 
-	// case when we found a version in DB
-	// check for deleted in DB
-	if !dbPair.DeletedAt.Valid { // not deleted
-		// compare versions:
-		if in.Pair.Version <= dbPair.Version {
-			// DB has actual or newer version - error and ask customer to sync
-			return nil, status.Error(codes.AlreadyExists, newerVersionDetected)
-		}
+	return &pb.LogoutResponse{Status: "success"}, nil
+}
+
+// SetVaultKDF handler saves the vault key derivation data of the user. Data can be set only once:
+// items, sealed with the derived key, can't be opened with another one.
+func (g *GRPCServer) SetVaultKDF(ctx context.Context, in *pb.SetVaultKDFRequest) (*pb.SetVaultKDFResponse, error) {
+	if in.Kdf.GetParams() == `` || len(in.Kdf.GetCheck()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid argument")
 	}
 
-	// db version is deleted OR received version is the latest => save
-	err = storage.Vault.PairAdd(ctxfunc.GetUserIDFromCTX(ctx), in.Pair.Title, in.Pair.Login, in.Pair.Pass, in.Pair.Comment, in.Pair.Version)
+	err := storage.Vault.UserSetKDF(ctxfunc.GetUserIDFromCTX(ctx), in.Kdf.Params, in.Kdf.Check)
 	if err != nil {
+		if errors.Is(err, postgre.ErrRecordAlreadyExists) {
+			return nil, status.Error(codes.AlreadyExists, "master password is already set")
+		}
 		log.Println(err)
-		return nil, status.Error(codes.Internal, failedToSaveNewVersion)
+		return nil, status.Error(codes.Internal, "failed to save master password data")
 	}
-	// record saved (the latest version)
-	return &pb.PostPairResponse{Status: "success"}, nil
+
+	return &pb.SetVaultKDFResponse{Status: "success"}, nil
 }
 
-// DelPair handler deletes the provided pair data by title.
-func (g *GRPCServer) DelPair(ctx context.Context, in *pb.DelPairRequest) (*pb.DelPairResponse, error) {
-	if in.Title == `` {
-		return nil, status.Error(codes.InvalidArgument, "invalid argument")
+// GetPair handler returns the pair data, found by id or title. Compatibility shim of GetItem.
+func (g *GRPCServer) GetPair(ctx context.Context, in *pb.GetPairRequest) (*pb.GetPairResponse, error) {
+	data, err := getItem(ctxfunc.GetUserIDFromCTX(ctx), "pair", in.Id, in.Title)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := storage.Vault.PairDelete(in.Title, ctxfunc.GetUserIDFromCTX(ctx)); err != nil {
-		log.Println(err)
-		return nil, status.Error(codes.Internal, "Delete failed. Please try again")
+	return &pb.GetPairResponse{
+		Pairs:  models.SealedToProtoPair(data),
+		Status: "success",
+	}, nil
+}
+
+// PostPair handler checks and saves new pair data to database, see putItem. Compatibility shim of PutItem.
+func (g *GRPCServer) PostPair(ctx context.Context, in *pb.PostPairRequest) (*pb.PostPairResponse, error) {
+	if _, err := putItem(ctxfunc.GetUserIDFromCTX(ctx), models.PairToItem(in.GetPair())); err != nil {
+		return nil, err
 	}
 
-	return &pb.DelPairResponse{Status: "success"}, nil
+	return &pb.PostPairResponse{Status: "success"}, nil
 }
 
-// GetText handler returns the found by title text data.
-func (g *GRPCServer) GetText(ctx context.Context, in *pb.GetTextRequest) (*pb.GetTextResponse, error) {
-	if in.Title == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid argument")
+// DelPair handler deletes the provided pair data by id or title. Compatibility shim of DeleteItem.
+func (g *GRPCServer) DelPair(ctx context.Context, in *pb.DelPairRequest) (*pb.DelPairResponse, error) {
+	version, err := deleteItem(ctxfunc.GetUserIDFromCTX(ctx), "pair", in.Id, in.Title)
+	if err != nil {
+		return nil, err
 	}
 
-	data, err := storage.Vault.TextByTitle(in.Title, ctxfunc.GetUserIDFromCTX(ctx))
+	return &pb.DelPairResponse{Status: "success", Version: version}, nil
+}
+
+// GetText handler returns the text data, found by id or title. Compatibility shim of GetItem.
+func (g *GRPCServer) GetText(ctx context.Context, in *pb.GetTextRequest) (*pb.GetTextResponse, error) {
+	data, err := getItem(ctxfunc.GetUserIDFromCTX(ctx), "text", in.Id, in.Title)
 	if err != nil {
-		if errors.Is(err, postgre.ErrNotFound) {
-			return &pb.GetTextResponse{
-				Text:   nil,
-				Status: "not found",
-			}, status.Error(codes.NotFound, "not found")
-		}
-		log.Println(err)
-		return nil, status.Error(codes.Internal, "failed to obtain latest data")
+		return nil, err
 	}
 
 	return &pb.GetTextResponse{
-		Text:   models.ModelsToProtoText(data),
+		Text:   models.SealedToProtoText(data),
 		Status: "success",
 	}, nil
 }
 
-// PostText handler checks and saves new text data to database.
-// Handler verifies, if the provided text data is the latest version and allows to proceed further.
+// PostText handler checks and saves new text data to database, see putItem. Compatibility shim of PutItem.
 func (g *GRPCServer) PostText(ctx context.Context, in *pb.PostTextRequest) (*pb.PostTextResponse, error) {
-	if in.Text.Version < 1 || in.Text.Title == `` || in.Text.Body == `` {
-		return nil, status.Error(codes.InvalidArgument, "invalid argument")
-	}
-	// first - compare version in DB
-	dbText, err := storage.Vault.TextByTitle(in.Text.Title, ctxfunc.GetUserIDFromCTX(ctx))
-	if err != nil && !errors.Is(err, postgre.ErrNotFound) {
-		log.Println(err)
-		return nil, status.Error(codes.Internal, failedDBQuery)
-	}
-	// in case not found - we can save version 1? or passed version?
-	if errors.Is(err, postgre.ErrNotFound) {
-		err = storage.Vault.TextAdd(ctxfunc.GetUserIDFromCTX(ctx), in.Text.Title, in.Text.Body, in.Text.Comment, in.Text.Version)
-		if err != nil {
-			log.Println(err)
-			return nil, status.Error(codes.Internal, failedToSaveNewVersion)
-		}
-		// new record saved (first version?)
-		return &pb.PostTextResponse{Status: "success"}, nil
-	}
-
-	// case when we found a version in DB
-	// check for deleted in DB
-	if !dbText.DeletedAt.Valid { // not deleted
-		// compare versions:
-		if in.Text.Version <= dbText.Version {
-			// DB has actual or newer version - error and ask customer to sync
-			return nil, status.Error(codes.AlreadyExists, newerVersionDetected)
-		}
+	if _, err := putItem(ctxfunc.GetUserIDFromCTX(ctx), models.TextToItem(in.GetText())); err != nil {
+		return nil, err
 	}
 
-	// db version is deleted OR received version is the latest => save
-	err = storage.Vault.TextAdd(ctxfunc.GetUserIDFromCTX(ctx), in.Text.Title, in.Text.Body, in.Text.Comment, in.Text.Version)
-	if err != nil {
-		log.Println(err)
-		return nil, status.Error(codes.Internal, failedToSaveNewVersion)
-	}
-	// record saved (the latest version)
 	return &pb.PostTextResponse{Status: "success"}, nil
 }
 
-// DelText handler deletes the provided text data by title.
+// DelText handler deletes the provided text data by id or title. Compatibility shim of DeleteItem.
 func (g *GRPCServer) DelText(ctx context.Context, in *pb.DelTextRequest) (*pb.DelTextResponse, error) {
-	if in.Title == `` {
-		return nil, status.Error(codes.InvalidArgument, "invalid argument")
-	}
-
-	err := storage.Vault.TextDelete(in.Title, ctxfunc.GetUserIDFromCTX(ctx))
+	version, err := deleteItem(ctxfunc.GetUserIDFromCTX(ctx), "text", in.Id, in.Title)
 	if err != nil {
-		log.Println(err)
-		return nil, status.Error(codes.Internal, "Delete failed. Please try again")
+		return nil, err
 	}
 
-	return &pb.DelTextResponse{Status: "success"}, nil
+	return &pb.DelTextResponse{Status: "success", Version: version}, nil
 }
 
-// GetBin handler returns the found by title binary data.
+// GetBin handler returns the binary data, found by id or title. Compatibility shim of GetItem.
 func (g *GRPCServer) GetBin(ctx context.Context, in *pb.GetBinRequest) (*pb.GetBinResponse, error) {
-	if in.Title == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid argument")
-	}
-	data, err := storage.Vault.BinByTitle(in.Title, ctxfunc.GetUserIDFromCTX(ctx))
+	data, err := getItem(ctxfunc.GetUserIDFromCTX(ctx), "bin", in.Id, in.Title)
 	if err != nil {
-		if errors.Is(err, postgre.ErrNotFound) {
-			return &pb.GetBinResponse{
-				BinData: nil,
-				Status:  "not found",
-			}, status.Error(codes.NotFound, "not found")
-		}
-		log.Println(err)
-		return nil, status.Error(codes.Internal, "failed to obtain latest data")
+		return nil, err
 	}
 
 	return &pb.GetBinResponse{
-		BinData: models.ModelsToProtoBin(data),
+		BinData: models.SealedToProtoBin(data),
 		Status:  "success",
 	}, nil
 }
 
-// PostBin handler checks and saves new binary data to database.
-// Handler verifies, if the provided binary data is the latest version and allows to proceed further.
+// PostBin handler checks and saves new binary data to database, see putItem. Compatibility shim of PutItem.
 func (g *GRPCServer) PostBin(ctx context.Context, in *pb.PostBinRequest) (*pb.PostBinResponse, error) {
-	if in.BinData.Version < 1 || in.BinData.Title == `` || len(in.BinData.Body) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "invalid argument")
-	}
-	// first - compare version in DB
-	dbBin, err := storage.Vault.BinByTitle(in.BinData.Title, ctxfunc.GetUserIDFromCTX(ctx))
-	if err != nil && !errors.Is(err, postgre.ErrNotFound) {
-		log.Println(err)
-		return nil, status.Error(codes.Internal, failedDBQuery)
-	}
-	// in case not found - we save passed version = 1
-	if errors.Is(err, postgre.ErrNotFound) {
-		err = storage.Vault.BinAdd(ctxfunc.GetUserIDFromCTX(ctx), in.BinData.Title, in.BinData.Body, in.BinData.Comment, in.BinData.Version)
-		if err != nil {
-			log.Println(err)
-			return nil, status.Error(codes.Internal, failedToSaveNewVersion)
-		}
-		// new record saved
-		return &pb.PostBinResponse{Status: "success"}, nil
+	if _, err := putItem(ctxfunc.GetUserIDFromCTX(ctx), models.BinToItem(in.GetBinData())); err != nil {
+		return nil, err
 	}
 
-	// case when we found a version in DB
-	// check for deleted in DB
-	if !dbBin.DeletedAt.Valid { // not deleted
-		// compare versions:
-		if in.BinData.Version <= dbBin.Version {
-			// DB has actual or newer version - error and ask customer to sync
-			return nil, status.Error(codes.AlreadyExists, newerVersionDetected)
-		}
-	}
-
-	// db version is deleted OR received version is the latest => save
-	err = storage.Vault.BinAdd(ctxfunc.GetUserIDFromCTX(ctx), in.BinData.Title, in.BinData.Body, in.BinData.Comment, in.BinData.Version)
-	if err != nil {
-		log.Println(err)
-		return nil, status.Error(codes.Internal, failedToSaveNewVersion)
-	}
-	// record saved (the latest version)
 	return &pb.PostBinResponse{Status: "success"}, nil
 }
 
-// DelBin handler deletes the provided binary data by title.
+// DelBin handler deletes the provided binary data by id or title. Compatibility shim of DeleteItem.
 func (g *GRPCServer) DelBin(ctx context.Context, in *pb.DelBinRequest) (*pb.DelBinResponse, error) {
-	if in.Title == `` {
-		return nil, status.Error(codes.InvalidArgument, "invalid argument")
-	}
-
-	err := storage.Vault.BinDelete(in.Title, ctxfunc.GetUserIDFromCTX(ctx))
+	version, err := deleteItem(ctxfunc.GetUserIDFromCTX(ctx), "bin", in.Id, in.Title)
 	if err != nil {
-		log.Println(err)
-		return nil, status.Error(codes.Internal, "Delete failed. Please try again")
+		return nil, err
 	}
 
-	return &pb.DelBinResponse{Status: "success"}, nil
+	return &pb.DelBinResponse{Status: "success", Version: version}, nil
 }
 
-// GetCard handler returns the found by title card data.
+// GetCard handler returns the card data, found by id or title. Compatibility shim of GetItem.
 func (g *GRPCServer) GetCard(ctx context.Context, in *pb.GetCardRequest) (*pb.GetCardResponse, error) {
-	if in.Title == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid argument")
-	}
-
-	data, err := storage.Vault.CardByTitle(in.Title, ctxfunc.GetUserIDFromCTX(ctx))
+	data, err := getItem(ctxfunc.GetUserIDFromCTX(ctx), "card", in.Id, in.Title)
 	if err != nil {
-		if errors.Is(err, postgre.ErrNotFound) {
-			return &pb.GetCardResponse{
-				Card:   nil,
-				Status: "not found",
-			}, status.Error(codes.NotFound, "not found")
-		}
-		log.Println(err)
-		return nil, status.Error(codes.Internal, "failed to obtain latest data")
+		return nil, err
 	}
 
 	return &pb.GetCardResponse{
-		Card:   models.ModelsToProtoCard(data),
+		Card:   models.SealedToProtoCard(data),
 		Status: "success",
 	}, nil
 }
 
-// PostCard handler checks and saves new card data to database.
-// Handler verifies, if the provided card data is the latest version and allows to proceed further.
+// PostCard handler checks and saves new card data to database, see putItem. Compatibility shim of PutItem.
 func (g *GRPCServer) PostCard(ctx context.Context, in *pb.PostCardRequest) (*pb.PostCardResponse, error) {
-	if in.Card.Version < 1 || in.Card.Title == `` || in.Card.Number == `` || in.Card.Expdate == `` {
-		return nil, status.Error(codes.InvalidArgument, "invalid argument")
-	}
-	// first - compare version in DB
-	dbCard, err := storage.Vault.CardByTitle(in.Card.Title, ctxfunc.GetUserIDFromCTX(ctx))
-	if err != nil && !errors.Is(err, postgre.ErrNotFound) {
-		log.Println(err)
-		return nil, status.Error(codes.Internal, failedDBQuery)
-	}
-
-	// in case not found - we can save version 1? or passed version?
-	if errors.Is(err, postgre.ErrNotFound) {
-		err = storage.Vault.CardAdd(ctxfunc.GetUserIDFromCTX(ctx), in.Card.Title, in.Card.Number, in.Card.Expdate, in.Card.Comment, in.Card.Version)
-		if err != nil {
-			log.Println(err)
-			return nil, status.Error(codes.Internal, failedToSaveNewVersion)
-		}
-		// new record saved (first version?)
-		return &pb.PostCardResponse{Status: "success"}, nil
+	if _, err := putItem(ctxfunc.GetUserIDFromCTX(ctx), models.CardToItem(in.GetCard())); err != nil {
+		return nil, err
 	}
 
-	// case when we found a version in DB
-	// check for deleted in DB
-	if !dbCard.DeletedAt.Valid { // not deleted
-		// compare versions:
-		if in.Card.Version <= dbCard.Version {
-			// DB has actual or newer version - error and ask customer to sync
-			return nil, status.Error(codes.AlreadyExists, newerVersionDetected)
-		}
-	}
-
-	// db version is deleted OR received version is the latest => save
-	err = storage.Vault.CardAdd(ctxfunc.GetUserIDFromCTX(ctx), in.Card.Title, in.Card.Number, in.Card.Expdate, in.Card.Comment, in.Card.Version)
-	if err != nil {
-		log.Println(err)
-		return nil, status.Error(codes.Internal, failedToSaveNewVersion)
-	}
-	// record saved (the latest version)
 	return &pb.PostCardResponse{Status: "success"}, nil
 }
 
-// DelCard handler deletes the provided card data by title.
+// DelCard handler deletes the provided card data by id or title. Compatibility shim of DeleteItem.
 func (g *GRPCServer) DelCard(ctx context.Context, in *pb.DelCardRequest) (*pb.DelCardResponse, error) {
-	if in.Title == `` {
-		return nil, status.Error(codes.InvalidArgument, "invalid argument")
-	}
-
-	err := storage.Vault.CardDelete(in.Title, ctxfunc.GetUserIDFromCTX(ctx))
+	version, err := deleteItem(ctxfunc.GetUserIDFromCTX(ctx), "card", in.Id, in.Title)
 	if err != nil {
-		log.Println(err)
-		return nil, status.Error(codes.Internal, "Delete failed. Please try again")
+		return nil, err
 	}
 
-	return &pb.DelCardResponse{Status: "success"}, nil
+	return &pb.DelCardResponse{Status: "success", Version: version}, nil
 }
 
+// SyncVault handler saves the pushed local changes of the client and returns the per-item results,
+// the user items changed after the request cursor, and the new cursor.
 func (g *GRPCServer) SyncVault(ctx context.Context, in *pb.SyncVaultRequest) (*pb.SyncVaultResponse, error) {
-	data, err := storage.Vault.AllUserLatestData(ctxfunc.GetUserIDFromCTX(ctx))
+	if in.GetCursor() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid argument")
+	}
+
+	// local changes of the client are saved first, so the pulled data already includes them.
+	results := applySyncPush(ctxfunc.GetUserIDFromCTX(ctx), in)
+
+	data, cursor, err := storage.Vault.UserChangesSince(ctxfunc.GetUserIDFromCTX(ctx), in.GetCursor())
 	if err != nil {
 		log.Println(err)
 		return nil, status.Error(codes.Internal, "failed to obtain latest data")
@@ -451,6 +341,9 @@ func (g *GRPCServer) SyncVault(ctx context.Context, in *pb.SyncVaultRequest) (*p
 		Texts:   data.Texts,
 		BinData: data.Bins,
 		Cards:   data.Cards,
+		Items:   data.Items,
 		Status:  "success",
+		Cursor:  cursor,
+		Results: results,
 	}, nil
 }
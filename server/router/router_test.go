@@ -2,6 +2,7 @@ package grpcserver
 
 import (
 	"context"
+	"database/sql"
 	"github.com/EestiChameleon/gophkeeper/models"
 	pb "github.com/EestiChameleon/gophkeeper/proto"
 	"github.com/EestiChameleon/gophkeeper/server/service"
@@ -13,8 +14,10 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
 	"log"
 	"net"
+	"strings"
 	"testing"
 )
 
@@ -222,6 +225,8 @@ func TestLoginUser(t *testing.T) {
 			id, err2 := service.JWTDecodeUserID(resp.GetJwt())
 			assert.NoError(t, err2)
 			assert.Equal(t, tt.want.jwtUserID, id)
+			// legacy md5 hash is upgraded after successful login
+			assert.True(t, strings.HasPrefix(testdb.TestUser.PasswordHash, "$argon2id$"))
 		}
 	}
 }
@@ -249,6 +254,7 @@ func TestGetPair(t *testing.T) {
 	tests := []struct {
 		name   string
 		number uint8
+		id     string
 		title  string
 		want   want
 	}{
@@ -275,14 +281,41 @@ func TestGetPair(t *testing.T) {
 			number: 3,
 			title:  testdb.TestPair.Title,
 			want: want{
-				data:   models.ModelsToProtoPair(testdb.TestPair),
+				data:   models.SealedToProtoPair(testdb.TestPair),
+				status: "success",
+			},
+		},
+		{
+			name:   "Test #4: ambiguous title",
+			number: 1,
+			title:  testdb.TestAmbiguousTitle,
+			want: want{
+				errStatusCode: codes.FailedPrecondition,
+				errStatusMsg:  ambiguousTitle,
+			},
+		},
+		{
+			name:   "Test #5: invalid id",
+			number: 1,
+			id:     "testPair",
+			want: want{
+				errStatusCode: codes.InvalidArgument,
+				errStatusMsg:  "invalid argument",
+			},
+		},
+		{
+			name:   "Test #6: correct id",
+			number: 3,
+			id:     testdb.TestPair.ItemID,
+			want: want{
+				data:   models.SealedToProtoPair(testdb.TestPair),
 				status: "success",
 			},
 		},
 	}
 	for _, tt := range tests {
 		// make request
-		resp, err := client.GetPair(ctx, &pb.GetPairRequest{Title: tt.title})
+		resp, err := client.GetPair(ctx, &pb.GetPairRequest{Id: tt.id, Title: tt.title})
 		switch tt.number {
 		case 1:
 			// empty values should return err InvalidArgument
@@ -327,10 +360,9 @@ func TestPostPair(t *testing.T) {
 	tests := []struct {
 		name    string
 		number  uint8
+		id      string
 		title   string
-		login   string
-		pass    string
-		comment string
+		payload []byte
 		version uint32
 		want    want
 	}{
@@ -346,9 +378,7 @@ func TestPostPair(t *testing.T) {
 			name:    "Test #2: data already exists",
 			number:  2,
 			title:   testdb.TestPair.Title,
-			login:   testdb.TestPair.Login,
-			pass:    testdb.TestPair.Pass,
-			comment: testdb.TestPair.Comment,
+			payload: testdb.TestPair.Payload,
 			version: testdb.TestPair.Version,
 			want: want{
 				errStatusCode: codes.AlreadyExists,
@@ -359,21 +389,38 @@ func TestPostPair(t *testing.T) {
 			name:    "Test #3: correct new data",
 			number:  3,
 			title:   "new pair",
-			login:   "new login",
-			pass:    "new pass",
-			comment: "new comment",
+			payload: []byte("new sealed payload"),
 			version: 1,
 			want:    want{status: "success"},
 		},
+		{
+			name:    "Test #4: rename by id",
+			number:  4,
+			id:      testdb.TestPair.ItemID,
+			title:   "renamed pair",
+			payload: []byte("new sealed payload"),
+			version: testdb.TestPair.Version + 1,
+			want:    want{status: "success"},
+		},
+		{
+			name:    "Test #5: ambiguous title without id",
+			number:  1,
+			title:   testdb.TestAmbiguousTitle,
+			payload: []byte("new sealed payload"),
+			version: 1,
+			want: want{
+				errStatusCode: codes.FailedPrecondition,
+				errStatusMsg:  ambiguousTitle,
+			},
+		},
 	}
 	for _, tt := range tests {
 		// make request
 		resp, err := client.PostPair(ctx,
 			&pb.PostPairRequest{Pair: &pb.Pair{
+				Id:      tt.id,
 				Title:   tt.title,
-				Login:   tt.login,
-				Pass:    tt.pass,
-				Comment: tt.comment,
+				Payload: tt.payload,
 				Version: tt.version,
 			}})
 		switch tt.number {
@@ -392,6 +439,11 @@ func TestPostPair(t *testing.T) {
 		case 3:
 			// check successful response
 			assert.Equal(t, tt.want.status, resp.GetStatus())
+			assert.Equal(t, models.LegacyItemID("pair", tt.title), testdb.TestAdded.ItemID)
+		case 4:
+			assert.Equal(t, tt.want.status, resp.GetStatus())
+			assert.Equal(t, tt.id, testdb.TestAdded.ItemID)
+			assert.Equal(t, tt.title, testdb.TestAdded.Title)
 		}
 	}
 }
@@ -399,7 +451,8 @@ func TestPostPair(t *testing.T) {
 // TestDelPair verifies, that:
 // 1) null title is not accepted
 // 2) not found received after delete
-// 3) in case of success - we receive success message
+// 3) in case of success - we receive success message and the tombstone version
+// 4) deleted item can't be deleted again
 func TestDelPair(t *testing.T) {
 	// init connect
 	ctx, conn := keeperTestConn(t)
@@ -413,6 +466,7 @@ func TestDelPair(t *testing.T) {
 		errStatusCode codes.Code
 		errStatusMsg  string
 		status        string
+		version       uint32
 	}
 
 	tests := []struct {
@@ -436,6 +490,16 @@ func TestDelPair(t *testing.T) {
 			title:  testdb.TestPair.Title,
 			want: want{
 				status:        "success",
+				version:       testdb.TestPair.Version + 1,
+				errStatusCode: codes.NotFound,
+				errStatusMsg:  "not found",
+			},
+		},
+		{
+			name:   "Test #3: already deleted",
+			number: 3,
+			title:  testdb.TestPair.Title,
+			want: want{
 				errStatusCode: codes.NotFound,
 				errStatusMsg:  "not found",
 			},
@@ -445,8 +509,8 @@ func TestDelPair(t *testing.T) {
 		// make request
 		resp, err := client.DelPair(ctx, &pb.DelPairRequest{Title: tt.title})
 		switch tt.number {
-		case 1:
-			// empty values should return err InvalidArgument
+		case 1, 3:
+			// empty values should return err InvalidArgument, already deleted item - NotFound
 			st, ok := status.FromError(err)
 			assert.True(t, ok)
 			assert.Equal(t, tt.want.errStatusCode, st.Code())
@@ -454,6 +518,8 @@ func TestDelPair(t *testing.T) {
 		case 2:
 			// check successful response of delete
 			assert.Equal(t, tt.want.status, resp.GetStatus())
+			// tombstone is the next version
+			assert.Equal(t, tt.want.version, resp.GetVersion())
 			// make get request to receive not found
 			_, err2 := client.GetPair(ctx, &pb.GetPairRequest{Title: tt.title})
 			// empty values should return err InvalidArgument
@@ -515,7 +581,7 @@ func TestGetText(t *testing.T) {
 			number: 3,
 			title:  testdb.TestText.Title,
 			want: want{
-				data:   models.ModelsToProtoText(testdb.TestText),
+				data:   models.SealedToProtoText(testdb.TestText),
 				status: "success",
 			},
 		},
@@ -568,8 +634,7 @@ func TestPostText(t *testing.T) {
 		name    string
 		number  uint8
 		title   string
-		body    string
-		comment string
+		payload []byte
 		version uint32
 		want    want
 	}{
@@ -585,8 +650,7 @@ func TestPostText(t *testing.T) {
 			name:    "Test #2: data already exists",
 			number:  2,
 			title:   testdb.TestText.Title,
-			body:    testdb.TestText.Body,
-			comment: testdb.TestText.Comment,
+			payload: testdb.TestText.Payload,
 			version: testdb.TestText.Version,
 			want: want{
 				errStatusCode: codes.AlreadyExists,
@@ -597,8 +661,7 @@ func TestPostText(t *testing.T) {
 			name:    "Test #3: correct new data",
 			number:  3,
 			title:   "new text",
-			body:    "new body",
-			comment: "new comment",
+			payload: []byte("new sealed payload"),
 			version: 1,
 			want:    want{status: "success"},
 		},
@@ -608,8 +671,7 @@ func TestPostText(t *testing.T) {
 		resp, err := client.PostText(ctx,
 			&pb.PostTextRequest{Text: &pb.Text{
 				Title:   tt.title,
-				Body:    tt.body,
-				Comment: tt.comment,
+				Payload: tt.payload,
 				Version: tt.version,
 			}})
 		switch tt.number {
@@ -635,7 +697,8 @@ func TestPostText(t *testing.T) {
 // TestDelText verifies, that:
 // 1) null title is not accepted
 // 2) not found received after delete
-// 3) in case of success - we receive success message
+// 3) in case of success - we receive success message and the tombstone version
+// 4) deleted item can't be deleted again
 func TestDelText(t *testing.T) {
 	// init connect
 	ctx, conn := keeperTestConn(t)
@@ -649,6 +712,7 @@ func TestDelText(t *testing.T) {
 		errStatusCode codes.Code
 		errStatusMsg  string
 		status        string
+		version       uint32
 	}
 
 	tests := []struct {
@@ -672,6 +736,16 @@ func TestDelText(t *testing.T) {
 			title:  testdb.TestText.Title,
 			want: want{
 				status:        "success",
+				version:       testdb.TestText.Version + 1,
+				errStatusCode: codes.NotFound,
+				errStatusMsg:  "not found",
+			},
+		},
+		{
+			name:   "Test #3: already deleted",
+			number: 3,
+			title:  testdb.TestText.Title,
+			want: want{
 				errStatusCode: codes.NotFound,
 				errStatusMsg:  "not found",
 			},
@@ -681,8 +755,8 @@ func TestDelText(t *testing.T) {
 		// make request
 		resp, err := client.DelText(ctx, &pb.DelTextRequest{Title: tt.title})
 		switch tt.number {
-		case 1:
-			// empty values should return err InvalidArgument
+		case 1, 3:
+			// empty values should return err InvalidArgument, already deleted item - NotFound
 			st, ok := status.FromError(err)
 			assert.True(t, ok)
 			assert.Equal(t, tt.want.errStatusCode, st.Code())
@@ -690,6 +764,8 @@ func TestDelText(t *testing.T) {
 		case 2:
 			// check successful response of delete
 			assert.Equal(t, tt.want.status, resp.GetStatus())
+			// tombstone is the next version
+			assert.Equal(t, tt.want.version, resp.GetVersion())
 			// make get request to receive not found
 			_, err2 := client.GetText(ctx, &pb.GetTextRequest{Title: tt.title})
 			// empty values should return err InvalidArgument
@@ -751,7 +827,7 @@ func TestGetBin(t *testing.T) {
 			number: 3,
 			title:  testdb.TestBin.Title,
 			want: want{
-				data:   models.ModelsToProtoBin(testdb.TestBin),
+				data:   models.SealedToProtoBin(testdb.TestBin),
 				status: "success",
 			},
 		},
@@ -801,13 +877,14 @@ func TestPostBin(t *testing.T) {
 	}
 
 	tests := []struct {
-		name    string
-		number  uint8
-		title   string
-		body    []byte
-		comment string
-		version uint32
-		want    want
+		name     string
+		number   uint8
+		id       string
+		title    string
+		payload  []byte
+		version  uint32
+		streamed bool
+		want     want
 	}{
 		{
 			name:   "Test #1: empty data",
@@ -821,8 +898,7 @@ func TestPostBin(t *testing.T) {
 			name:    "Test #2: data already exists",
 			number:  2,
 			title:   testdb.TestBin.Title,
-			body:    testdb.TestBin.Body,
-			comment: testdb.TestBin.Comment,
+			payload: testdb.TestBin.Payload,
 			version: testdb.TestBin.Version,
 			want: want{
 				errStatusCode: codes.AlreadyExists,
@@ -833,20 +909,43 @@ func TestPostBin(t *testing.T) {
 			name:    "Test #3: correct new data",
 			number:  3,
 			title:   "new text",
-			body:    []byte("new body"),
-			comment: "new comment",
+			payload: []byte("new sealed payload"),
 			version: 1,
 			want:    want{status: "success"},
 		},
+		{
+			name:     "Test #4: streamed version keeps the body",
+			number:   3,
+			id:       testdb.TestStreamedBin.ItemID,
+			title:    testdb.TestStreamedBin.Title,
+			payload:  []byte("new sealed payload"),
+			version:  testdb.TestStreamedBin.Version + 1,
+			streamed: true,
+			want:     want{status: "success"},
+		},
+		{
+			name:     "Test #5: streamed version without the body to keep",
+			number:   2,
+			id:       testdb.TestBin.ItemID,
+			title:    testdb.TestBin.Title,
+			payload:  []byte("new sealed payload"),
+			version:  testdb.TestBin.Version + 1,
+			streamed: true,
+			want: want{
+				errStatusCode: codes.FailedPrecondition,
+				errStatusMsg:  noStreamedBody,
+			},
+		},
 	}
 	for _, tt := range tests {
 		// make request
 		resp, err := client.PostBin(ctx,
 			&pb.PostBinRequest{BinData: &pb.Bin{
-				Title:   tt.title,
-				Body:    tt.body,
-				Comment: tt.comment,
-				Version: tt.version,
+				Id:       tt.id,
+				Title:    tt.title,
+				Payload:  tt.payload,
+				Version:  tt.version,
+				Streamed: tt.streamed,
 			}})
 		switch tt.number {
 		case 1:
@@ -871,7 +970,8 @@ func TestPostBin(t *testing.T) {
 // TestDelBin verifies, that:
 // 1) null title is not accepted
 // 2) not found received after delete
-// 3) in case of success - we receive success message
+// 3) in case of success - we receive success message and the tombstone version
+// 4) deleted item can't be deleted again
 func TestDelBin(t *testing.T) {
 	// init connect
 	ctx, conn := keeperTestConn(t)
@@ -885,6 +985,7 @@ func TestDelBin(t *testing.T) {
 		errStatusCode codes.Code
 		errStatusMsg  string
 		status        string
+		version       uint32
 	}
 
 	tests := []struct {
@@ -908,6 +1009,16 @@ func TestDelBin(t *testing.T) {
 			title:  testdb.TestBin.Title,
 			want: want{
 				status:        "success",
+				version:       testdb.TestBin.Version + 1,
+				errStatusCode: codes.NotFound,
+				errStatusMsg:  "not found",
+			},
+		},
+		{
+			name:   "Test #3: already deleted",
+			number: 3,
+			title:  testdb.TestBin.Title,
+			want: want{
 				errStatusCode: codes.NotFound,
 				errStatusMsg:  "not found",
 			},
@@ -917,8 +1028,8 @@ func TestDelBin(t *testing.T) {
 		// make request
 		resp, err := client.DelBin(ctx, &pb.DelBinRequest{Title: tt.title})
 		switch tt.number {
-		case 1:
-			// empty values should return err InvalidArgument
+		case 1, 3:
+			// empty values should return err InvalidArgument, already deleted item - NotFound
 			st, ok := status.FromError(err)
 			assert.True(t, ok)
 			assert.Equal(t, tt.want.errStatusCode, st.Code())
@@ -926,6 +1037,8 @@ func TestDelBin(t *testing.T) {
 		case 2:
 			// check successful response of delete
 			assert.Equal(t, tt.want.status, resp.GetStatus())
+			// tombstone is the next version
+			assert.Equal(t, tt.want.version, resp.GetVersion())
 			// make get request to receive not found
 			_, err2 := client.GetBin(ctx, &pb.GetBinRequest{Title: tt.title})
 			// empty values should return err InvalidArgument
@@ -987,7 +1100,7 @@ func TestGetCard(t *testing.T) {
 			number: 3,
 			title:  testdb.TestCard.Title,
 			want: want{
-				data:   models.ModelsToProtoCard(testdb.TestCard),
+				data:   models.SealedToProtoCard(testdb.TestCard),
 				status: "success",
 			},
 		},
@@ -1037,14 +1150,12 @@ func TestPostCard(t *testing.T) {
 	}
 
 	tests := []struct {
-		name       string
-		number     uint8
-		title      string
-		cardNumber string
-		expdate    string
-		comment    string
-		version    uint32
-		want       want
+		name    string
+		number  uint8
+		title   string
+		payload []byte
+		version uint32
+		want    want
 	}{
 		{
 			name:   "Test #1: empty data",
@@ -1055,27 +1166,23 @@ func TestPostCard(t *testing.T) {
 			},
 		},
 		{
-			name:       "Test #2: data already exists",
-			number:     2,
-			title:      testdb.TestCard.Title,
-			cardNumber: testdb.TestCard.Number,
-			expdate:    testdb.TestCard.ExpirationDate,
-			comment:    testdb.TestCard.Comment,
-			version:    testdb.TestCard.Version,
+			name:    "Test #2: data already exists",
+			number:  2,
+			title:   testdb.TestCard.Title,
+			payload: testdb.TestCard.Payload,
+			version: testdb.TestCard.Version,
 			want: want{
 				errStatusCode: codes.AlreadyExists,
 				errStatusMsg:  "Current / newer version found in database. Please synchronize you app to get the most actual data.",
 			},
 		},
 		{
-			name:       "Test #3: correct new data",
-			number:     3,
-			title:      "new card",
-			cardNumber: "new number",
-			expdate:    "new exp date",
-			comment:    "new comment",
-			version:    1,
-			want:       want{status: "success"},
+			name:    "Test #3: correct new data",
+			number:  3,
+			title:   "new card",
+			payload: []byte("new sealed payload"),
+			version: 1,
+			want:    want{status: "success"},
 		},
 	}
 	for _, tt := range tests {
@@ -1083,9 +1190,7 @@ func TestPostCard(t *testing.T) {
 		resp, err := client.PostCard(ctx,
 			&pb.PostCardRequest{Card: &pb.Card{
 				Title:   tt.title,
-				Number:  tt.cardNumber,
-				Expdate: tt.expdate,
-				Comment: tt.comment,
+				Payload: tt.payload,
 				Version: tt.version,
 			}})
 		switch tt.number {
@@ -1111,7 +1216,8 @@ func TestPostCard(t *testing.T) {
 // TestDelCard verifies, that:
 // 1) null title is not accepted
 // 2) not found received after delete
-// 3) in case of success - we receive success message
+// 3) in case of success - we receive success message and the tombstone version
+// 4) deleted item can't be deleted again
 func TestDelCard(t *testing.T) {
 	// init connect
 	ctx, conn := keeperTestConn(t)
@@ -1125,6 +1231,7 @@ func TestDelCard(t *testing.T) {
 		errStatusCode codes.Code
 		errStatusMsg  string
 		status        string
+		version       uint32
 	}
 
 	tests := []struct {
@@ -1148,6 +1255,16 @@ func TestDelCard(t *testing.T) {
 			title:  testdb.TestCard.Title,
 			want: want{
 				status:        "success",
+				version:       testdb.TestCard.Version + 1,
+				errStatusCode: codes.NotFound,
+				errStatusMsg:  "not found",
+			},
+		},
+		{
+			name:   "Test #3: already deleted",
+			number: 3,
+			title:  testdb.TestCard.Title,
+			want: want{
 				errStatusCode: codes.NotFound,
 				errStatusMsg:  "not found",
 			},
@@ -1157,8 +1274,8 @@ func TestDelCard(t *testing.T) {
 		// make request
 		resp, err := client.DelCard(ctx, &pb.DelCardRequest{Title: tt.title})
 		switch tt.number {
-		case 1:
-			// empty values should return err InvalidArgument
+		case 1, 3:
+			// empty values should return err InvalidArgument, already deleted item - NotFound
 			st, ok := status.FromError(err)
 			assert.True(t, ok)
 			assert.Equal(t, tt.want.errStatusCode, st.Code())
@@ -1166,6 +1283,8 @@ func TestDelCard(t *testing.T) {
 		case 2:
 			// check successful response of delete
 			assert.Equal(t, tt.want.status, resp.GetStatus())
+			// tombstone is the next version
+			assert.Equal(t, tt.want.version, resp.GetVersion())
 			// make get request to receive not found
 			_, err2 := client.GetCard(ctx, &pb.GetCardRequest{Title: tt.title})
 			// empty values should return err InvalidArgument
@@ -1177,3 +1296,337 @@ func TestDelCard(t *testing.T) {
 		}
 	}
 }
+
+// TestRefreshToken verifies, that:
+// 1) null refresh token is not accepted
+// 2) unknown refresh token is rejected
+// 3) valid refresh token is exchanged for a new token pair
+// 4) used refresh token can't be used again (rotation)
+// 5) the session is revoked, after the used refresh token was passed: the rotated token is rejected too
+func TestRefreshToken(t *testing.T) {
+	// init connect
+	ctx, conn := keeperTestConn(t)
+	defer conn.Close()
+
+	// create client
+	client := pb.NewKeeperClient(conn)
+	// init test storage
+	storage.InitTest()
+
+	// obtain a valid refresh token
+	login, err := client.LoginUser(ctx, &pb.LoginUserRequest{ServiceLogin: "user7", ServicePass: "pass7"})
+	assert.NoError(t, err)
+
+	type want struct {
+		errStatusCode codes.Code
+		errStatusMsg  string
+		jwtUserID     int
+	}
+
+	tests := []struct {
+		name    string
+		number  uint8
+		refresh string
+		want    want
+	}{
+		{
+			name:   "Test #1: empty data",
+			number: 1,
+			want: want{
+				errStatusCode: codes.InvalidArgument,
+				errStatusMsg:  "invalid argument",
+			},
+		},
+		{
+			name:    "Test #2: unknown refresh token",
+			number:  2,
+			refresh: "unknown",
+			want: want{
+				errStatusCode: codes.Unauthenticated,
+				errStatusMsg:  "session expired. please login",
+			},
+		},
+		{
+			name:    "Test #3: correct refresh token",
+			number:  3,
+			refresh: login.GetRefreshToken(),
+			want: want{
+				jwtUserID: 7,
+			},
+		},
+		{
+			name:    "Test #4: already used refresh token",
+			number:  4,
+			refresh: login.GetRefreshToken(),
+			want: want{
+				errStatusCode: codes.Unauthenticated,
+				errStatusMsg:  "session expired. please login",
+			},
+		},
+		{
+			name:   "Test #5: refresh token, issued before the reuse",
+			number: 5,
+			want: want{
+				errStatusCode: codes.Unauthenticated,
+				errStatusMsg:  "session expired. please login",
+			},
+		},
+	}
+	var rotated string
+	for _, tt := range tests {
+		if tt.number == 5 {
+			tt.refresh = rotated
+		}
+		// make request
+		resp, err := client.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: tt.refresh})
+		switch tt.number {
+		case 1, 2, 4, 5:
+			st, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want.errStatusCode, st.Code(), tt.name)
+			assert.Equal(t, tt.want.errStatusMsg, st.Message(), tt.name)
+		case 3:
+			// check successfully created token pair
+			id, err2 := service.JWTDecodeUserID(resp.GetJwt())
+			assert.NoError(t, err2)
+			assert.Equal(t, tt.want.jwtUserID, id)
+			assert.NotEqual(t, tt.refresh, resp.GetRefreshToken())
+			assert.Greater(t, resp.GetExpiresAt(), login.GetExpiresAt()-1)
+			rotated = resp.GetRefreshToken()
+		}
+	}
+}
+
+// TestSetVaultKDF verifies, that:
+// 1) null data is not accepted
+// 2) kdf is saved and returned on login
+// 3) kdf can't be overwritten
+func TestSetVaultKDF(t *testing.T) {
+	// init connect
+	ctx, conn := keeperTestConn(t)
+	defer conn.Close()
+
+	// create client
+	client := pb.NewKeeperClient(conn)
+	// init test storage
+	storage.InitTest()
+	// restore test user
+	defer func() {
+		testdb.TestUser.KDFParams = sql.NullString{}
+		testdb.TestUser.KDFCheck = nil
+	}()
+
+	type want struct {
+		errStatusCode codes.Code
+		errStatusMsg  string
+		status        string
+	}
+
+	kdf := &pb.VaultKDF{Params: "argon2id$v=19$m=65536,t=3,p=4$c2FsdA", Check: []byte("check")}
+
+	tests := []struct {
+		name   string
+		number uint8
+		kdf    *pb.VaultKDF
+		want   want
+	}{
+		{
+			name:   "Test #1: empty data",
+			number: 1,
+			want: want{
+				errStatusCode: codes.InvalidArgument,
+				errStatusMsg:  "invalid argument",
+			},
+		},
+		{
+			name:   "Test #2: correct new data",
+			number: 2,
+			kdf:    kdf,
+			want:   want{status: "success"},
+		},
+		{
+			name:   "Test #3: kdf already set",
+			number: 3,
+			kdf:    &pb.VaultKDF{Params: kdf.Params, Check: []byte("other check")},
+			want: want{
+				errStatusCode: codes.AlreadyExists,
+				errStatusMsg:  "master password is already set",
+			},
+		},
+	}
+	for _, tt := range tests {
+		// make request
+		resp, err := client.SetVaultKDF(ctx, &pb.SetVaultKDFRequest{Kdf: tt.kdf})
+		switch tt.number {
+		case 1, 3:
+			st, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want.errStatusCode, st.Code(), tt.name)
+			assert.Equal(t, tt.want.errStatusMsg, st.Message(), tt.name)
+		case 2:
+			assert.Equal(t, tt.want.status, resp.GetStatus())
+			// login returns saved kdf
+			login, err2 := client.LoginUser(ctx, &pb.LoginUserRequest{ServiceLogin: "user7", ServicePass: "pass7"})
+			assert.NoError(t, err2)
+			assert.Equal(t, kdf.Params, login.GetKdf().GetParams())
+			assert.Equal(t, kdf.Check, login.GetKdf().GetCheck())
+		}
+	}
+}
+
+// TestSyncVault verifies, that:
+// 1) negative cursor is not accepted
+// 2) in case of success - we receive success status and the new cursor
+// 3) pushed items are applied with the version rules and the per-item results are returned,
+// changes made on top of a conflicting one conflict too
+func TestSyncVault(t *testing.T) {
+	// init connect
+	ctx, conn := keeperTestConn(t)
+	defer conn.Close()
+
+	// create client
+	client := pb.NewKeeperClient(conn)
+	// init test storage
+	storage.InitTest()
+	type want struct {
+		errStatusCode codes.Code
+		errStatusMsg  string
+		status        string
+		cursor        int64
+		results       []*pb.SyncItemResult
+	}
+
+	tests := []struct {
+		name    string
+		number  uint8
+		request *pb.SyncVaultRequest
+		want    want
+	}{
+		{
+			name:    "Test #1: negative cursor",
+			number:  1,
+			request: &pb.SyncVaultRequest{Cursor: -1},
+			want: want{
+				errStatusCode: codes.InvalidArgument,
+				errStatusMsg:  "invalid argument",
+			},
+		},
+		{
+			name:    "Test #2: full synchronization",
+			number:  2,
+			request: &pb.SyncVaultRequest{},
+			want:    want{status: "success"},
+		},
+		{
+			name:   "Test #3: push new, outdated, dependent on the outdated and invalid items",
+			number: 2,
+			request: &pb.SyncVaultRequest{
+				Pairs: []*pb.Pair{{Title: "newPair", Version: 1, Payload: []byte("sealed")}},
+				Texts: []*pb.Text{
+					{Title: testdb.TestText.Title, Version: 1, Payload: []byte("sealed")},
+					{Title: testdb.TestText.Title, Version: 100, Payload: []byte("sealed")},
+				},
+				BinData: []*pb.Bin{{Title: "newBin", Version: 1}},
+			},
+			want: want{
+				status: "success",
+				results: []*pb.SyncItemResult{
+					{Type: "pair", Id: models.LegacyItemID("pair", "newPair"), Title: "newPair", Version: 1,
+						Status: pb.SyncItemStatus_SYNC_ACCEPTED},
+					{Type: "text", Id: testdb.TestText.ItemID, Title: testdb.TestText.Title,
+						Version: testdb.TestText.Version, Status: pb.SyncItemStatus_SYNC_CONFLICT,
+						Message: newerVersionDetected},
+					{Type: "text", Title: testdb.TestText.Title, Version: 100,
+						Status: pb.SyncItemStatus_SYNC_CONFLICT, Message: dependsOnConflict},
+					{Type: "bin", Title: "newBin", Version: 1,
+						Status: pb.SyncItemStatus_SYNC_REJECTED, Message: "invalid argument"},
+				},
+			},
+		},
+		{
+			name:   "Test #4: push by id, ambiguous title, invalid id and streamed bins",
+			number: 2,
+			request: &pb.SyncVaultRequest{
+				Pairs: []*pb.Pair{
+					{Id: testdb.TestPair.ItemID, Title: "renamedPair", Version: 100, Payload: []byte("sealed")},
+					{Title: testdb.TestAmbiguousTitle, Version: 1, Payload: []byte("sealed")},
+					{Id: "newPair", Title: "newPair", Version: 1, Payload: []byte("sealed")},
+				},
+				BinData: []*pb.Bin{
+					{Id: testdb.TestStreamedBin.ItemID, Title: testdb.TestStreamedBin.Title, Version: 100,
+						Payload: []byte("sealed"), Streamed: true},
+					{Id: testdb.TestBin.ItemID, Title: testdb.TestBin.Title, Version: 100,
+						Payload: []byte("sealed"), Streamed: true},
+				},
+			},
+			want: want{
+				status: "success",
+				results: []*pb.SyncItemResult{
+					{Type: "pair", Id: testdb.TestPair.ItemID, Title: "renamedPair", Version: 100,
+						Status: pb.SyncItemStatus_SYNC_ACCEPTED},
+					{Type: "pair", Title: testdb.TestAmbiguousTitle, Version: 1,
+						Status: pb.SyncItemStatus_SYNC_REJECTED, Message: ambiguousTitle},
+					{Type: "pair", Id: "newPair", Title: "newPair", Version: 1,
+						Status: pb.SyncItemStatus_SYNC_REJECTED, Message: "invalid argument"},
+					{Type: "bin", Id: testdb.TestStreamedBin.ItemID, Title: testdb.TestStreamedBin.Title,
+						Version: 100, Status: pb.SyncItemStatus_SYNC_ACCEPTED},
+					{Type: "bin", Id: testdb.TestBin.ItemID, Title: testdb.TestBin.Title, Version: 100,
+						Status: pb.SyncItemStatus_SYNC_REJECTED, Message: noStreamedBody},
+				},
+			},
+		},
+		{
+			name:   "Test #5: push the items without the typed message",
+			number: 2,
+			request: &pb.SyncVaultRequest{
+				Items: []*pb.Item{
+					{Type: "otp", Id: testdb.TestOTP.ItemID, Title: testdb.TestOTP.Title,
+						Version: testdb.TestOTP.Version + 1, Payload: []byte("sealed")},
+					{Type: "note", Title: "newNote", Version: 1, Payload: []byte("sealed")},
+				},
+			},
+			want: want{
+				status: "success",
+				results: []*pb.SyncItemResult{
+					{Type: "otp", Id: testdb.TestOTP.ItemID, Title: testdb.TestOTP.Title,
+						Version: testdb.TestOTP.Version + 1, Status: pb.SyncItemStatus_SYNC_ACCEPTED},
+					{Type: "note", Title: "newNote", Version: 1,
+						Status: pb.SyncItemStatus_SYNC_REJECTED, Message: "invalid argument"},
+				},
+			},
+		},
+		{
+			name:   "Test #6: push the version, saved by the concurrent request first",
+			number: 2,
+			request: &pb.SyncVaultRequest{
+				Pairs: []*pb.Pair{{Title: testdb.TestRacedTitle, Version: 1, Payload: []byte("sealed")}},
+			},
+			want: want{
+				status: "success",
+				results: []*pb.SyncItemResult{
+					{Type: "pair", Id: models.LegacyItemID("pair", testdb.TestRacedTitle), Title: testdb.TestRacedTitle,
+						Version: 1, Status: pb.SyncItemStatus_SYNC_CONFLICT, Message: newerVersionDetected},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		// make request
+		resp, err := client.SyncVault(ctx, tt.request)
+		switch tt.number {
+		case 1:
+			st, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want.errStatusCode, st.Code())
+			assert.Equal(t, tt.want.errStatusMsg, st.Message())
+		case 2:
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.status, resp.GetStatus())
+			assert.Equal(t, tt.want.cursor, resp.GetCursor())
+			assert.Equal(t, len(tt.want.results), len(resp.GetResults()))
+			for i, r := range resp.GetResults() {
+				assert.True(t, proto.Equal(tt.want.results[i], r), tt.name)
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package grpcserver
+
+import (
+	"errors"
+	"github.com/EestiChameleon/gophkeeper/models"
+	pb "github.com/EestiChameleon/gophkeeper/proto"
+	"github.com/EestiChameleon/gophkeeper/server/storage"
+	"github.com/EestiChameleon/gophkeeper/server/storage/postgre"
+	"log"
+)
+
+const dependsOnConflict = "Previous change of the item is in conflict. Please synchronize you app and resolve it."
+
+// applySyncPush saves the items, pushed with the sync request. Every item gets its own result,
+// one failed item doesn't stop the others. Versions of the same item are applied in order: if one of them conflicts,
+// the next ones were made on top of it and conflict too.
+func applySyncPush(uID int, in *pb.SyncVaultRequest) []*pb.SyncItemResult {
+	var items []*pb.Item
+	for _, v := range in.GetPairs() {
+		items = append(items, models.PairToItem(v))
+	}
+	for _, v := range in.GetTexts() {
+		items = append(items, models.TextToItem(v))
+	}
+	for _, v := range in.GetBinData() {
+		items = append(items, models.BinToItem(v))
+	}
+	for _, v := range in.GetCards() {
+		items = append(items, models.CardToItem(v))
+	}
+	items = append(items, in.GetItems()...)
+
+	var results []*pb.SyncItemResult
+	conflicted := make(map[string]bool)
+	for _, it := range items {
+		key := it.Type + "/" + it.Id
+		if it.Id == `` {
+			// clients without the item ids
+			key += "title:" + it.Title
+		}
+		var res *pb.SyncItemResult
+		if conflicted[key] {
+			res = &pb.SyncItemResult{Type: it.Type, Id: it.Id, Title: it.Title, Version: it.Version,
+				Status: pb.SyncItemStatus_SYNC_CONFLICT, Message: dependsOnConflict}
+		} else {
+			res = pushItem(uID, it)
+		}
+		if res.Status == pb.SyncItemStatus_SYNC_CONFLICT {
+			conflicted[key] = true
+		}
+		results = append(results, res)
+	}
+
+	return results
+}
+
+// pushItem applies one item with the PutItem rules: the item is saved only if its version is newer,
+// than the latest one in DB (tombstone included). Pushed tombstone deletes the item. Pushed streamed bin keeps
+// the body of the latest version.
+func pushItem(uID int, it *pb.Item) *pb.SyncItemResult {
+	res := &pb.SyncItemResult{Type: it.Type, Id: it.Id, Title: it.Title, Version: it.Version}
+
+	if !models.ValidItemType(it.Type) || it.Version < 1 || it.Title == `` || (!it.Deleted && len(it.Payload) == 0) ||
+		(it.Id != `` && !models.ValidItemID(it.Id)) {
+		res.Status = pb.SyncItemStatus_SYNC_REJECTED
+		res.Message = "invalid argument"
+		return res
+	}
+
+	id, err := itemID(uID, it.Type, it.Id, it.Title)
+	if errors.Is(err, postgre.ErrAmbiguous) {
+		res.Status = pb.SyncItemStatus_SYNC_REJECTED
+		res.Message = ambiguousTitle
+		return res
+	}
+	if err != nil {
+		log.Println(err)
+		res.Status = pb.SyncItemStatus_SYNC_FAILED
+		res.Message = failedDBQuery
+		return res
+	}
+	res.Id = id
+
+	db, err := storage.Vault.ItemByID(it.Type, id, uID)
+	if err != nil && !errors.Is(err, postgre.ErrNotFound) {
+		log.Println(err)
+		res.Status = pb.SyncItemStatus_SYNC_FAILED
+		res.Message = failedDBQuery
+		return res
+	}
+
+	found := err == nil
+	if found && it.Version <= db.Version {
+		// DB has actual or newer version
+		res.Status = pb.SyncItemStatus_SYNC_CONFLICT
+		res.Version = db.Version
+		res.Message = newerVersionDetected
+		return res
+	}
+
+	switch {
+	case it.Deleted && (!found || db.DeletedAt.Valid):
+		// nothing to delete: the item is unknown or already deleted on the server.
+		if found {
+			res.Version = db.Version
+		}
+	case it.Deleted:
+		res.Version, err = storage.Vault.ItemDelete(it.Type, id, uID)
+	default:
+		if err = saveVersion(uID, id, it); errors.Is(err, postgre.ErrNotFound) {
+			res.Status = pb.SyncItemStatus_SYNC_REJECTED
+			res.Message = noStreamedBody
+			return res
+		}
+	}
+	if errors.Is(err, postgre.ErrRecordAlreadyExists) {
+		// the concurrent request has saved the version first.
+		res.Status = pb.SyncItemStatus_SYNC_CONFLICT
+		res.Message = newerVersionDetected
+		if db, err = storage.Vault.ItemByID(it.Type, id, uID); err == nil {
+			res.Version = db.Version
+		}
+		return res
+	}
+	if err != nil {
+		log.Println(err)
+		res.Status = pb.SyncItemStatus_SYNC_FAILED
+		res.Version = it.Version
+		res.Message = failedToSaveNewVersion
+	}
+
+	return res
+}
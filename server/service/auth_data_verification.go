@@ -2,7 +2,9 @@ package service
 
 import (
 	"errors"
+	"github.com/EestiChameleon/gophkeeper/models"
 	"github.com/EestiChameleon/gophkeeper/server/storage"
+	"log"
 )
 
 var (
@@ -16,16 +18,43 @@ type LoginData struct {
 }
 
 // CheckAuthData verifies the provided login&password values.
-// If user found with such login and password - return JWT with encoded userID.
-func CheckAuthData(ld LoginData) (string, error) {
+// If user found with such login and password - starts a new session and returns the user data and session tokens.
+// Password hashes with outdated scheme or parameters are upgraded to the DefaultHasher.
+func CheckAuthData(ld LoginData) (*models.User, *TokenPair, error) {
 	u, err := storage.Vault.UserLogin(ld.Login)
 	if err != nil {
-		return "", err
+		return nil, nil, err
 	}
 
-	if EncryptPass(ld.Password) != u.Password {
-		return "", ErrWrongAuthData
+	ok, needsRehash, err := VerifyPassword(ld.Password, u.PasswordHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, ErrWrongAuthData
+	}
+
+	if needsRehash {
+		// failed upgrade shouldn't block the login - we will try again next time.
+		if err = upgradePasswordHash(u.ID, ld.Password); err != nil {
+			log.Println("password hash upgrade failed:", err)
+		}
+	}
+
+	tokens, err := NewSession(u.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return u, tokens, nil
+}
+
+// upgradePasswordHash rehashes the password with the DefaultHasher and saves it.
+func upgradePasswordHash(usrID int, pass string) error {
+	hash, err := HashPassword(pass)
+	if err != nil {
+		return err
 	}
 
-	return JWTEncodeUserID(u.ID)
+	return storage.Vault.UserPasswordUpdate(usrID, hash)
 }
@@ -1,63 +1,121 @@
 package service
 
 import (
-	"crypto/md5"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"github.com/EestiChameleon/gophkeeper/server/cfg"
 	"github.com/robbert229/jwt"
 	"log"
+	"strings"
+	"time"
 )
 
 var (
 	ErrInvalidToken = errors.New("failed to decode the provided Token")
+	ErrTokenExpired = errors.New("provided Token has expired")
 )
 
-// EncryptPass creates encrypted password based on md5 hash algorithm.
-func EncryptPass(pass string) string {
-	h := md5.New()
-	h.Write([]byte(pass))
-	return hex.EncodeToString(h.Sum(nil))
+// AccessClaims are the claims of the JWT access token.
+type AccessClaims struct {
+	UserID    int       // sub
+	SessionID int       // sid - login session, that issued the token.
+	TokenID   string    // jti
+	IssuedAt  time.Time // iat
+	ExpiresAt time.Time // exp
 }
 
-// JWTEncodeUserID creates JWT with userID encoded inside.
-func JWTEncodeUserID(value interface{}) (string, error) {
-	return JWTEncode("sub", value)
-}
+// JWTEncodeAccess creates JWT access token for the user session. Token lives cfg.AccessTokenTTL.
+func JWTEncodeAccess(usrID, sID int) (string, time.Time, error) {
+	jti, err := randomToken(16)
+	if err != nil {
+		return ``, time.Time{}, err
+	}
 
-// JWTEncode creates JWT with encoded inside passed value.
-func JWTEncode(key string, value interface{}) (string, error) {
-	algorithm := jwt.HmacSha256(cfg.CryptoKey)
+	now := time.Now()
+	exp := now.Add(cfg.AccessTokenTTL)
 
 	claims := jwt.NewClaim()
-	claims.Set(key, value)
+	claims.Set("sub", usrID)
+	claims.Set("sid", sID)
+	claims.Set("jti", jti)
+	claims.SetTime("iat", now)
+	claims.SetTime("exp", exp)
+
+	token, err := JWTEncode(claims)
+	if err != nil {
+		return ``, time.Time{}, err
+	}
+
+	// exp is encoded in unix seconds
+	return token, exp.Truncate(time.Second), nil
+}
+
+// JWTEncode creates JWT with the passed claims encoded inside.
+func JWTEncode(claims *jwt.Claims) (string, error) {
+	algorithm := jwt.HmacSha256(cfg.Current.CryptoKey)
 
 	token, err := algorithm.Encode(claims)
 	if err != nil {
 		return ``, err
 	}
 
-	if err = algorithm.Validate(token); err != nil {
+	if err = validateSignature(&algorithm, token); err != nil {
 		return ``, err
 	}
 
 	return token, nil
 }
 
-// JWTDecodeUserID provides userID from JWT, if decoding is successful.
+// JWTDecodeAccess provides the access token claims, if decoding is successful and the token is not expired.
+func JWTDecodeAccess(token string) (*AccessClaims, error) {
+	claims, err := JWTDecode(token)
+	if err != nil {
+		return nil, err
+	}
+
+	out := new(AccessClaims)
+	if out.ExpiresAt, err = claims.GetTime("exp"); err != nil {
+		// tokens without expiration are not accepted anymore
+		return nil, ErrInvalidToken
+	}
+	if out.IssuedAt, err = claims.GetTime("iat"); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if out.UserID, err = intClaim(claims, "sub"); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if out.SessionID, err = intClaim(claims, "sid"); err != nil {
+		return nil, ErrInvalidToken
+	}
+	jti, err := claims.Get("jti")
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if out.TokenID, _ = jti.(string); out.TokenID == `` {
+		return nil, ErrInvalidToken
+	}
+
+	return out, nil
+}
+
+// JWTDecodeUserID provides userID from JWT access token, if decoding is successful.
 func JWTDecodeUserID(token string) (int, error) {
-	value, err := JWTDecode(token, "sub")
+	claims, err := JWTDecodeAccess(token)
 	if err != nil {
 		return -1, err
 	}
-	return int(value.(float64)), nil
+	return claims.UserID, nil
 }
 
-// JWTDecode decodes the passed JWT and returns the interface value.
-func JWTDecode(token, key string) (interface{}, error) {
-	algorithm := jwt.HmacSha256(cfg.CryptoKey)
+// JWTDecode verifies the passed JWT signature and expiration and returns its claims.
+func JWTDecode(token string) (*jwt.Claims, error) {
+	algorithm := jwt.HmacSha256(cfg.Current.CryptoKey)
 
-	if err := algorithm.Validate(token); err != nil {
+	// signature first - expiration of a forged token doesn't matter.
+	if err := validateSignature(&algorithm, token); err != nil {
 		log.Println(err)
 		return nil, ErrInvalidToken
 	}
@@ -68,5 +126,51 @@ func JWTDecode(token, key string) (interface{}, error) {
 		return nil, ErrInvalidToken
 	}
 
-	return claims.Get(key)
+	// expired token is reported separately - client could refresh it.
+	if exp, err := claims.GetTime("exp"); err == nil && !exp.After(time.Now()) {
+		return nil, ErrTokenExpired
+	}
+
+	return claims, nil
+}
+
+// validateSignature compares the JWT signature with the signature calculated for its header and payload.
+func validateSignature(algorithm *jwt.Algorithm, token string) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ErrInvalidToken
+	}
+
+	signature, err := algorithm.Sign(parts[0] + "." + parts[1])
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal([]byte(parts[2]), []byte(base64.RawURLEncoding.EncodeToString(signature))) {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+// intClaim returns the claim value as int. JSON numbers are decoded as float64.
+func intClaim(claims *jwt.Claims, key string) (int, error) {
+	v, err := claims.Get(key)
+	if err != nil {
+		return -1, err
+	}
+	f, ok := v.(float64)
+	if !ok {
+		return -1, ErrInvalidToken
+	}
+	return int(f), nil
+}
+
+// randomToken returns n random bytes encoded to hex.
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return ``, err
+	}
+	return hex.EncodeToString(b), nil
 }
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/server/cfg"
+	"golang.org/x/crypto/argon2"
+	"strings"
+)
+
+var (
+	ErrUnknownHashScheme = errors.New("unknown password hash scheme")
+	ErrMalformedHash     = errors.New("malformed password hash")
+)
+
+// PasswordHasher describes a password hashing scheme. Encoded hashes must carry all the data
+// (algorithm, parameters, salt) needed to verify a password later.
+type PasswordHasher interface {
+	// ID returns the scheme identifier, used as the first segment of the encoded hash.
+	ID() string
+	// Hash creates an encoded hash of the password.
+	Hash(pass string) (string, error)
+	// Verify compares the password with the encoded hash in constant time.
+	Verify(pass, encoded string) (bool, error)
+	// NeedsRehash reports if the encoded hash was created with outdated parameters.
+	NeedsRehash(encoded string) bool
+}
+
+var (
+	// DefaultHasher is used for all new password hashes.
+	DefaultHasher PasswordHasher = NewArgon2idHasher(Argon2Params{
+		Time:    cfg.Argon2Time,
+		Memory:  cfg.Argon2MemoryKiB,
+		Threads: cfg.Argon2Threads,
+		SaltLen: 16,
+		KeyLen:  32,
+	})
+
+	hashers = map[string]PasswordHasher{}
+)
+
+func init() {
+	RegisterHasher(DefaultHasher)
+	RegisterHasher(legacyMD5Hasher{})
+}
+
+// RegisterHasher adds the scheme to the list of schemes accepted by VerifyPassword.
+func RegisterHasher(h PasswordHasher) {
+	hashers[h.ID()] = h
+}
+
+// HashPassword creates an encoded password hash with the DefaultHasher.
+func HashPassword(pass string) (string, error) {
+	return DefaultHasher.Hash(pass)
+}
+
+// VerifyPassword checks the password against the encoded hash with the scheme recorded in it.
+// needsRehash is true, when the password is correct, but the hash should be upgraded to the DefaultHasher.
+func VerifyPassword(pass, encoded string) (ok, needsRehash bool, err error) {
+	h, ok := hashers[hashSchemeID(encoded)]
+	if !ok {
+		return false, false, ErrUnknownHashScheme
+	}
+
+	ok, err = h.Verify(pass, encoded)
+	if err != nil || !ok {
+		return false, false, err
+	}
+
+	return true, h.ID() != DefaultHasher.ID() || h.NeedsRehash(encoded), nil
+}
+
+// hashSchemeID returns the scheme identifier of the encoded hash: "$<id>$...".
+// Hashes without the prefix are legacy unsalted md5 hex strings.
+func hashSchemeID(encoded string) string {
+	if !strings.HasPrefix(encoded, "$") {
+		return legacyMD5ID
+	}
+	parts := strings.SplitN(encoded[1:], "$", 2)
+	return parts[0]
+}
+
+//-------------------- ARGON2ID --------------------
+
+const argon2idID = "argon2id"
+
+// Argon2Params are the tunable cost parameters of the argon2id scheme.
+type Argon2Params struct {
+	Time    uint32 // number of passes over the memory
+	Memory  uint32 // memory size in KiB
+	Threads uint8  // degree of parallelism
+	SaltLen uint32 // length of the random salt in bytes
+	KeyLen  uint32 // length of the derived key in bytes
+}
+
+// Argon2idHasher implements PasswordHasher with the memory-hard argon2id algorithm.
+// Encoded format: $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt b64>$<key b64>.
+type Argon2idHasher struct {
+	params Argon2Params
+}
+
+// NewArgon2idHasher creates a new argon2id hasher with the provided parameters.
+func NewArgon2idHasher(p Argon2Params) *Argon2idHasher {
+	return &Argon2idHasher{params: p}
+}
+
+// ID returns the scheme identifier.
+func (a *Argon2idHasher) ID() string {
+	return argon2idID
+}
+
+// Hash creates an encoded argon2id hash with a random per-password salt.
+func (a *Argon2idHasher) Hash(pass string) (string, error) {
+	salt := make([]byte, a.params.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return ``, err
+	}
+
+	key := argon2.IDKey([]byte(pass), salt, a.params.Time, a.params.Memory, a.params.Threads, a.params.KeyLen)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idID, argon2.Version, a.params.Memory, a.params.Time, a.params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify recalculates the key with the salt and parameters from the encoded hash and compares them.
+func (a *Argon2idHasher) Verify(pass, encoded string) (bool, error) {
+	p, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	other := argon2.IDKey([]byte(pass), salt, p.Time, p.Memory, p.Threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+// NeedsRehash reports if the encoded hash parameters differ from the hasher parameters.
+func (a *Argon2idHasher) NeedsRehash(encoded string) bool {
+	p, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+
+	return p.Time != a.params.Time || p.Memory != a.params.Memory || p.Threads != a.params.Threads ||
+		uint32(len(salt)) != a.params.SaltLen || uint32(len(key)) != a.params.KeyLen
+}
+
+// decodeArgon2id parses the encoded argon2id hash.
+func decodeArgon2id(encoded string) (p Argon2Params, salt, key []byte, err error) {
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, key
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != argon2idID {
+		return p, nil, nil, ErrMalformedHash
+	}
+
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, ErrMalformedHash
+	}
+
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Threads); err != nil {
+		return p, nil, nil, ErrMalformedHash
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return p, nil, nil, ErrMalformedHash
+	}
+
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(key) == 0 {
+		return p, nil, nil, ErrMalformedHash
+	}
+
+	return p, salt, key, nil
+}
+
+//-------------------- LEGACY MD5 --------------------
+
+const legacyMD5ID = "md5"
+
+// legacyMD5Hasher verifies the unsalted md5 hashes created before argon2id was introduced.
+// It is never used for new hashes.
+type legacyMD5Hasher struct{}
+
+func (legacyMD5Hasher) ID() string {
+	return legacyMD5ID
+}
+
+func (legacyMD5Hasher) Hash(pass string) (string, error) {
+	h := md5.Sum([]byte(pass))
+	return hex.EncodeToString(h[:]), nil
+}
+
+func (l legacyMD5Hasher) Verify(pass, encoded string) (bool, error) {
+	h, _ := l.Hash(pass)
+	return subtle.ConstantTimeCompare([]byte(h), []byte(encoded)) == 1, nil
+}
+
+func (legacyMD5Hasher) NeedsRehash(string) bool {
+	return true
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// TestHashPassword verifies, that:
+// 1) the encoded hash records the algorithm and parameters
+// 2) the same password gets different hashes (per-password salt)
+func TestHashPassword(t *testing.T) {
+	h1, err := HashPassword("pass7")
+	assert.NoError(t, err)
+	h2, err := HashPassword("pass7")
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(h1, "$argon2id$v=19$m="))
+	assert.NotEqual(t, h1, h2)
+}
+
+// TestVerifyPassword verifies, that:
+// 1) correct and wrong passwords are recognized for all registered schemes
+// 2) legacy and outdated hashes are marked for upgrade
+// 3) unknown and malformed hashes are rejected
+func TestVerifyPassword(t *testing.T) {
+	current, err := HashPassword("pass7")
+	assert.NoError(t, err)
+	outdated, err := NewArgon2idHasher(Argon2Params{Time: 1, Memory: 8 * 1024, Threads: 1, SaltLen: 8, KeyLen: 16}).Hash("pass7")
+	assert.NoError(t, err)
+
+	type want struct {
+		ok          bool
+		needsRehash bool
+		err         error
+	}
+
+	tests := []struct {
+		name    string
+		pass    string
+		encoded string
+		want    want
+	}{
+		{
+			name:    "Test #1: current scheme, correct password",
+			pass:    "pass7",
+			encoded: current,
+			want:    want{ok: true},
+		},
+		{
+			name:    "Test #2: current scheme, wrong password",
+			pass:    "pass1",
+			encoded: current,
+			want:    want{},
+		},
+		{
+			name:    "Test #3: outdated parameters, correct password",
+			pass:    "pass7",
+			encoded: outdated,
+			want:    want{ok: true, needsRehash: true},
+		},
+		{
+			name:    "Test #4: legacy md5, correct password",
+			pass:    "pass7",
+			encoded: "8c96c3884a827355aed2c0f744594a52",
+			want:    want{ok: true, needsRehash: true},
+		},
+		{
+			name:    "Test #5: legacy md5, wrong password",
+			pass:    "pass1",
+			encoded: "8c96c3884a827355aed2c0f744594a52",
+			want:    want{},
+		},
+		{
+			name:    "Test #6: unknown scheme",
+			pass:    "pass7",
+			encoded: "$bcrypt$10$abc",
+			want:    want{err: ErrUnknownHashScheme},
+		},
+		{
+			name:    "Test #7: malformed hash",
+			pass:    "pass7",
+			encoded: "$argon2id$v=19$m=abc$salt",
+			want:    want{err: ErrMalformedHash},
+		},
+	}
+	for _, tt := range tests {
+		ok, needsRehash, err := VerifyPassword(tt.pass, tt.encoded)
+		assert.ErrorIs(t, err, tt.want.err, tt.name)
+		assert.Equal(t, tt.want.ok, ok, tt.name)
+		assert.Equal(t, tt.want.needsRehash, needsRehash, tt.name)
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"github.com/EestiChameleon/gophkeeper/server/storage"
+	"log"
+	"time"
+)
+
+// RetentionPolicy describes, how long the item versions are kept. Zero values keep the data forever.
+type RetentionPolicy struct {
+	KeepVersions  int           // last versions of every item to keep.
+	TombstoneAge  time.Duration // deleted items are erased after.
+	CheckInterval time.Duration
+}
+
+// Enabled reports, whether the policy erases anything.
+func (p RetentionPolicy) Enabled() bool {
+	return p.KeepVersions > 0 || p.TombstoneAge > 0
+}
+
+// RunRetention applies the retention policy at once and then every CheckInterval, until ctx is done.
+// Failed run is logged and retried with the next check.
+func RunRetention(ctx context.Context, p RetentionPolicy) {
+	if !p.Enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(p.CheckInterval)
+	defer ticker.Stop()
+	for {
+		purged, err := storage.Vault.PurgeExpired(p.KeepVersions, p.TombstoneAge)
+		if err != nil {
+			log.Println("retention policy:", err)
+		} else if purged > 0 {
+			log.Printf("retention policy: %d item version(s) erased", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"github.com/EestiChameleon/gophkeeper/server/storage"
+	"github.com/EestiChameleon/gophkeeper/server/storage/testdb"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRunRetention verifies, that:
+// 1) disabled policy doesn't run
+// 2) enabled policy runs at once and then periodically, until the context is done
+func TestRunRetention(t *testing.T) {
+	storage.InitTest()
+
+	tests := []struct {
+		name   string
+		number uint8
+		policy RetentionPolicy
+	}{
+		{name: "Test #1: disabled policy", number: 1, policy: RetentionPolicy{CheckInterval: time.Millisecond}},
+		{name: "Test #2: enabled policy", number: 2, policy: RetentionPolicy{KeepVersions: 3, CheckInterval: 10 * time.Millisecond}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&testdb.TestPurgeRuns, 0)
+			ctx, cancel := context.WithTimeout(context.Background(), 55*time.Millisecond)
+			defer cancel()
+
+			done := make(chan struct{})
+			go func() {
+				RunRetention(ctx, tt.policy)
+				close(done)
+			}()
+
+			switch tt.number {
+			case 1:
+				<-done
+				assert.Zero(t, atomic.LoadInt32(&testdb.TestPurgeRuns))
+			case 2:
+				select {
+				case <-done:
+					t.Fatal("policy stopped before the context is done")
+				case <-time.After(30 * time.Millisecond):
+				}
+				<-done
+				assert.GreaterOrEqual(t, atomic.LoadInt32(&testdb.TestPurgeRuns), int32(2))
+			}
+		})
+	}
+}
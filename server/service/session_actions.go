@@ -0,0 +1,118 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/EestiChameleon/gophkeeper/server/cfg"
+	"github.com/EestiChameleon/gophkeeper/server/storage"
+	"github.com/EestiChameleon/gophkeeper/server/storage/postgre"
+	"time"
+)
+
+var (
+	ErrSessionRevoked = errors.New("session is revoked or expired")
+)
+
+// TokenPair is a set of tokens, issued to the client for the login session.
+type TokenPair struct {
+	AccessToken  string    // JWT, lives cfg.AccessTokenTTL.
+	RefreshToken string    // opaque random string, lives cfg.RefreshTokenTTL.
+	ExpiresAt    time.Time // access token expiration.
+}
+
+// NewSession starts a new login session for the user and issues the token pair.
+func NewSession(usrID int) (*TokenPair, error) {
+	refresh, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	sID, err := storage.Vault.SessionAdd(usrID, hashRefreshToken(refresh), time.Now().Add(cfg.RefreshTokenTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	access, exp, err := JWTEncodeAccess(usrID, sID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{AccessToken: access, RefreshToken: refresh, ExpiresAt: exp}, nil
+}
+
+// RefreshSession exchanges the refresh token for a new token pair. Refresh token is rotated:
+// the passed one can't be used again. The rotated token is used again, only if it was stolen - or
+// the refresh was requested twice: the whole session is revoked then.
+func RefreshSession(refreshToken string) (*TokenPair, error) {
+	oldHash := hashRefreshToken(refreshToken)
+	s, err := storage.Vault.SessionByRefresh(oldHash)
+	if err != nil {
+		if errors.Is(err, postgre.ErrNotFound) {
+			return nil, ErrSessionRevoked
+		}
+		return nil, err
+	}
+	if s.RevokedAt.Valid || !s.ExpiresAt.After(time.Now()) {
+		return nil, ErrSessionRevoked
+	}
+	if s.RefreshHash != oldHash {
+		return nil, revokeReused(s.ID)
+	}
+
+	refresh, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	err = storage.Vault.SessionRotate(s.ID, oldHash, hashRefreshToken(refresh), time.Now().Add(cfg.RefreshTokenTTL))
+	// the concurrent refresh has rotated the token first.
+	if errors.Is(err, postgre.ErrNotFound) {
+		return nil, revokeReused(s.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	access, exp, err := JWTEncodeAccess(s.UserID, s.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{AccessToken: access, RefreshToken: refresh, ExpiresAt: exp}, nil
+}
+
+// CheckSession verifies, that the session, which issued the access token, is still active.
+func CheckSession(sID int) error {
+	s, err := storage.Vault.SessionByID(sID)
+	if err != nil {
+		if errors.Is(err, postgre.ErrNotFound) {
+			return ErrSessionRevoked
+		}
+		return err
+	}
+	if s.RevokedAt.Valid || !s.ExpiresAt.After(time.Now()) {
+		return ErrSessionRevoked
+	}
+
+	return nil
+}
+
+// RevokeSession ends the login session. Its refresh token and access tokens are not accepted anymore.
+func RevokeSession(sID int) error {
+	return storage.Vault.SessionRevoke(sID)
+}
+
+// revokeReused revokes the session, which refresh token was used again. Returns ErrSessionRevoked.
+func revokeReused(sID int) error {
+	if err := storage.Vault.SessionRevoke(sID); err != nil {
+		return err
+	}
+	return ErrSessionRevoked
+}
+
+// hashRefreshToken returns sha256 of the refresh token. Only the hash is stored in database.
+func hashRefreshToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
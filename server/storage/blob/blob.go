@@ -0,0 +1,63 @@
+package blob
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrNotFound     = errors.New("blob not found")
+	ErrMalformed    = errors.New("malformed blob hash")
+	ErrUnknownStore = errors.New("unknown blob store kind")
+)
+
+// Store keeps the binary item data apart from the database. Blobs are addressed by the hex sha256 of the content.
+// The content is sealed by the client with a random nonce, so the same file, uploaded twice, is two different blobs:
+// uploads are never deduplicated. A blob is shared only by the item versions, that keep the body of another version:
+// the metadata changes and the restored versions. Reference counting is done by the caller: Delete is called,
+// when the last reference is released. The caller serializes Commit and Delete of the same hash.
+type Store interface {
+	// Stage saves the content, read from r, aside. The content is kept under its hash after Commit.
+	Stage(r io.Reader) (Staged, error)
+	// Open returns the content reader or ErrNotFound.
+	Open(hash string) (io.ReadCloser, error)
+	// Delete removes the content. Missing content is not an error.
+	Delete(hash string) error
+}
+
+// Staged is the content, saved aside by Store.Stage.
+type Staged interface {
+	Hash() string
+	Size() int64
+	// Commit keeps the content under its hash. The existing content is replaced, so the content is in place after
+	// Commit, even if it was deleted after Stage.
+	Commit() error
+	// Discard removes the content, that was not committed. Does nothing after Commit.
+	Discard()
+}
+
+// New returns the blob store of the kind:
+// fs - the local directory dir.
+// S3 compatible storage could be added as another kind.
+func New(kind, dir string) (Store, error) {
+	switch kind {
+	case "fs":
+		return NewFSStore(dir)
+	default:
+		return nil, fmt.Errorf("%q: %w", kind, ErrUnknownStore)
+	}
+}
+
+// validHash checks, that the hash is a hex sha256, so it is safe to use it as a file or object name.
+func validHash(hash string) error {
+	if len(hash) != 64 {
+		return ErrMalformed
+	}
+	for _, c := range hash {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return ErrMalformed
+		}
+	}
+	return nil
+}
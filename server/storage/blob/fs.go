@@ -0,0 +1,129 @@
+package blob
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// FSStore keeps the blobs in the local directory: dir/<first 2 hash chars>/<hash>.
+type FSStore struct {
+	dir string
+}
+
+// NewFSStore creates the blob directory, if it doesn't exist.
+func NewFSStore(dir string) (*FSStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FSStore{dir: dir}, nil
+}
+
+// Stage implements Store. The content is written to a temporary file, hashed on the way and synced. The content is
+// never loaded to memory as a whole.
+func (s *FSStore) Stage(r io.Reader) (Staged, error) {
+	f, err := os.CreateTemp(s.dir, ".put-*")
+	if err != nil {
+		return nil, err
+	}
+	staged := &stagedFile{store: s, tmp: f.Name()}
+
+	h := sha256.New()
+	staged.size, err = io.Copy(io.MultiWriter(f, h), r)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		staged.Discard()
+		return nil, err
+	}
+
+	staged.hash = hex.EncodeToString(h.Sum(nil))
+	return staged, nil
+}
+
+// stagedFile is the temporary file with the staged content.
+type stagedFile struct {
+	store *FSStore
+	tmp   string
+	hash  string
+	size  int64
+}
+
+func (f *stagedFile) Hash() string {
+	return f.hash
+}
+
+func (f *stagedFile) Size() int64 {
+	return f.size
+}
+
+// Commit implements Staged. The temporary file is renamed to its hash name: the rename replaces the existing file
+// atomically.
+func (f *stagedFile) Commit() error {
+	path := f.store.path(f.hash)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	if err := os.Rename(f.tmp, path); err != nil {
+		return err
+	}
+	syncDir(filepath.Dir(path))
+	return nil
+}
+
+// Discard implements Staged. After the successful rename the temporary file is gone - remove fails quietly.
+func (f *stagedFile) Discard() {
+	os.Remove(f.tmp)
+}
+
+// Open implements Store.
+func (s *FSStore) Open(hash string) (io.ReadCloser, error) {
+	if err := validHash(hash); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(s.path(hash))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+// Delete implements Store.
+func (s *FSStore) Delete(hash string) error {
+	if err := validHash(hash); err != nil {
+		return err
+	}
+
+	if err := os.Remove(s.path(hash)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path returns the blob file path. Blobs are spread by subdirectories to keep them small.
+func (s *FSStore) path(hash string) string {
+	return filepath.Join(s.dir, hash[:2], hash)
+}
+
+// syncDir flushes the directory entry, so the rename survives a crash. Failure is only logged: the file is in place.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		log.Println(`[WARNING]: blob directory sync:`, err)
+		return
+	}
+	defer d.Close()
+
+	if err = d.Sync(); err != nil && !errors.Is(err, os.ErrInvalid) {
+		log.Println(`[WARNING]: blob directory sync:`, err)
+	}
+}
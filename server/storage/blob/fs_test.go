@@ -0,0 +1,102 @@
+package blob
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFSStore verifies, that:
+// 1) content is saved by its hash and read back
+// 2) the same content is kept once
+// 3) deleted and unknown blobs are not found, malformed hashes are rejected
+// 4) the content, deleted after Stage, is in place after Commit
+func TestFSStore(t *testing.T) {
+	content := bytes.Repeat([]byte("sealed body "), 10000)
+	sum := sha256.Sum256(content)
+
+	tests := []struct {
+		name   string
+		number uint8
+	}{
+		{name: "Test #1: content is saved and read back", number: 1},
+		{name: "Test #2: same content is deduplicated", number: 2},
+		{name: "Test #3: deleted blob is not found", number: 3},
+		{name: "Test #4: malformed hash", number: 4},
+		{name: "Test #5: commit replaces the deleted content", number: 5},
+		{name: "Test #6: discarded content is not kept", number: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s, err := NewFSStore(dir)
+			require.NoError(t, err)
+
+			staged, err := s.Stage(bytes.NewReader(content))
+			require.NoError(t, err)
+			hash, size := staged.Hash(), staged.Size()
+			require.NoError(t, staged.Commit())
+
+			switch tt.number {
+			case 1:
+				assert.Equal(t, hex.EncodeToString(sum[:]), hash)
+				assert.Equal(t, int64(len(content)), size)
+
+				r, err := s.Open(hash)
+				require.NoError(t, err)
+				defer r.Close()
+				read, err := io.ReadAll(r)
+				require.NoError(t, err)
+				assert.Equal(t, content, read)
+			case 2:
+				again, err := s.Stage(bytes.NewReader(content))
+				require.NoError(t, err)
+				assert.Equal(t, hash, again.Hash())
+				require.NoError(t, again.Commit())
+
+				files, err := os.ReadDir(filepath.Join(dir, hash[:2]))
+				require.NoError(t, err)
+				assert.Len(t, files, 1)
+				// no temporary files are left
+				entries, err := os.ReadDir(dir)
+				require.NoError(t, err)
+				assert.Len(t, entries, 1)
+			case 3:
+				require.NoError(t, s.Delete(hash))
+				_, err = s.Open(hash)
+				assert.ErrorIs(t, err, ErrNotFound)
+				// second delete is fine
+				assert.NoError(t, s.Delete(hash))
+			case 4:
+				_, err = s.Open("../../etc/passwd")
+				assert.ErrorIs(t, err, ErrMalformed)
+				assert.ErrorIs(t, s.Delete(hash[:10]), ErrMalformed)
+			case 5:
+				again, err := s.Stage(bytes.NewReader(content))
+				require.NoError(t, err)
+				require.NoError(t, s.Delete(hash))
+				require.NoError(t, again.Commit())
+				again.Discard()
+				r, err := s.Open(hash)
+				require.NoError(t, err)
+				r.Close()
+			case 6:
+				require.NoError(t, s.Delete(hash))
+				again, err := s.Stage(bytes.NewReader(content))
+				require.NoError(t, err)
+				again.Discard()
+				_, err = s.Open(hash)
+				assert.ErrorIs(t, err, ErrNotFound)
+				entries, err := os.ReadDir(dir)
+				require.NoError(t, err)
+				assert.Len(t, entries, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package postgre
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"github.com/EestiChameleon/gophkeeper/server/storage/blob"
+	"github.com/jackc/pgx/v4"
+	"io"
+	"log"
+)
+
+const (
+	// binStreamColumns are the gk_bin attributes, kept apart from the payload. The payload of the items, saved as one
+	// message, and the streamed body are read from the blob store.
+	binStreamColumns = ", payload_hash, content_hash IS NOT NULL AS streamed, " +
+		"coalesce((SELECT size FROM gk_blob WHERE hash = content_hash), 0) AS size"
+
+	// binContentPart is the size of the body part, read by one query.
+	binContentPart = 1 << 20
+
+	// blobLockSpace is the first key of the blob advisory locks, the second one is the hash of the blob hash.
+	blobLockSpace = 0x626c6f62
+)
+
+// blobs keeps the binary item data. Set by Run.
+var blobs blob.Store
+
+// loadPayloads reads the payloads of the binary items, kept in the blob store.
+func loadPayloads(items ...*models.Sealed) error {
+	for _, item := range items {
+		if !item.PayloadHash.Valid {
+			continue
+		}
+
+		r, err := blobs.Open(item.PayloadHash.String)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+		item.Payload, err = io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// addBlobRef returns the query part, that saves the blob reference: the blob table expression. hash and size are
+// the query parameters. The reference and the gk_bin row are saved by one statement, so they can't go apart.
+func addBlobRef(hash, size string) string {
+	return "blob AS (INSERT INTO gk_blob (hash, size, refs) VALUES (" + hash + ", " + size + ", 1) " +
+		"ON CONFLICT (hash) DO UPDATE SET refs = gk_blob.refs + 1 RETURNING hash) "
+}
+
+// lockBlob takes the advisory lock of the blob hash till the end of the transaction. The blob is placed to the store
+// and removed from it under the lock only, so the blob, that has just got the reference, is never removed.
+func lockBlob(ctx context.Context, tx pgx.Tx, hash string) error {
+	_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1::int, hashtext($2));", blobLockSpace, hash)
+	return err
+}
+
+// putBlob saves the content to the blob store and runs save, that adds the blob reference (see addBlobRef), in one
+// transaction. The content is placed under its hash with the blob lock taken. Returns the content size.
+func putBlob(r io.Reader, save func(ctx context.Context, tx pgx.Tx, hash string, size int64) error) (int64, error) {
+	staged, err := blobs.Stage(r)
+	if err != nil {
+		return 0, err
+	}
+	defer staged.Discard()
+
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockBlob(ctx, tx, staged.Hash()); err != nil {
+		return 0, err
+	}
+	// failed save leaves the blob without a reference - it is kept in the store until the same content is added.
+	if err = staged.Commit(); err != nil {
+		return 0, err
+	}
+	if err = save(ctx, tx, staged.Hash(), staged.Size()); err != nil {
+		log.Println(err)
+		return 0, err
+	}
+
+	return staged.Size(), tx.Commit(ctx)
+}
+
+// releaseBlob drops the blob reference. The blob without references is removed, see sweepBlob.
+func releaseBlob(hash string) error {
+	var refs int
+	if err := GetSingleValue("UPDATE gk_blob SET refs = refs - 1 WHERE hash = $1 RETURNING refs;",
+		&refs, hash); err != nil {
+		return err
+	}
+	if refs > 0 {
+		return nil
+	}
+	return sweepBlob(hash)
+}
+
+// sweepBlob removes the blob, if it has no references, with the blob lock taken. If the server stops in between,
+// the row without references is left, sweepBlobs removes it on the next start.
+func sweepBlob(hash string) error {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockBlob(ctx, tx, hash); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, "DELETE FROM gk_blob WHERE hash = $1 AND refs <= 0;", hash)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	// the content is removed before the commit: if it fails, the row without references is kept. The content
+	// is placed again, when the reference is added.
+	if tag.RowsAffected() > 0 {
+		if err = blobs.Delete(hash); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// sweepBlobs removes the blobs, left without references.
+func sweepBlobs() error {
+	var hashes []string
+	if err := GetAll("SELECT hash FROM gk_blob WHERE refs <= 0;", &hashes); err != nil {
+		return err
+	}
+	for _, hash := range hashes {
+		if err := sweepBlob(hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveBinsToBlobs moves the gk_bin payloads and streamed bodies, saved before the blob store, to the blob store.
+// Rows are moved one by one, so the server could be stopped and the move continued on the next start.
+func moveBinsToBlobs() error {
+	var rows []struct {
+		ID       int
+		Streamed bool
+		Size     int64
+	}
+	if err := GetAll("SELECT id, content IS NOT NULL AS streamed, coalesce(octet_length(content), 0) AS size "+
+		"FROM gk_bin WHERE content IS NOT NULL "+
+		"OR (payload_hash IS NULL AND content_hash IS NULL AND octet_length(payload) > 0);", &rows); err != nil {
+		return err
+	}
+	if len(rows) > 0 {
+		log.Printf("moving %d binary items to the blob store", len(rows))
+	}
+
+	for _, row := range rows {
+		// streamed body or the payload of the item, saved as one message
+		var src io.Reader = &binContentReader{id: row.ID, size: row.Size}
+		update := "UPDATE gk_bin SET content = NULL, content_hash = $1 WHERE id = $3;"
+		if !row.Streamed {
+			var payload []byte
+			if err := GetSingleValue("SELECT payload FROM gk_bin WHERE id = $1;", &payload, row.ID); err != nil {
+				return err
+			}
+			src = bytes.NewReader(payload)
+			update = "UPDATE gk_bin SET payload = ''::bytea, payload_hash = $1 WHERE id = $3;"
+		}
+
+		_, err := putBlob(src, func(ctx context.Context, tx pgx.Tx, hash string, size int64) error {
+			_, err := tx.Exec(ctx, "WITH "+addBlobRef("$1", "$2")+update, hash, size, row.ID)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// binContentReader reads the gk_bin body, saved before the blob store, by parts, so the whole body is never
+// loaded at once.
+type binContentReader struct {
+	id     int
+	size   int64
+	offset int64
+	part   []byte
+}
+
+// Read implements io.Reader.
+func (r *binContentReader) Read(p []byte) (int, error) {
+	if len(r.part) == 0 {
+		if r.offset >= r.size {
+			return 0, io.EOF
+		}
+
+		// substring positions start with 1.
+		if err := GetSingleValue("SELECT substring(content FROM $2::bigint FOR $3::bigint) FROM gk_bin WHERE id = $1;",
+			&r.part, r.id, r.offset+1, binContentPart); err != nil {
+			return 0, err
+		}
+		if len(r.part) == 0 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		r.offset += int64(len(r.part))
+	}
+
+	n := copy(p, r.part)
+	r.part = r.part[n:]
+	return n, nil
+}
+
+// addBlobPayload inserts new item version in database. The payload is saved to the blob store, the table keeps its hash.
+// The row is stamped with the next user change sequence value.
+func addBlobPayload(t itemTable, uID int, id, title string, payload []byte, v uint32) error {
+	_, err := putBlob(bytes.NewReader(payload), func(ctx context.Context, tx pgx.Tx, hash string, size int64) error {
+		var resultID int
+		return tx.QueryRow(ctx, nextChangeSeq+", "+addBlobRef("$4", "$5")+
+			"INSERT INTO "+t.name+" (user_id, item_id, title, payload, payload_hash, version, change_seq) "+
+			"SELECT $1, $2::uuid, $3::varchar, ''::bytea, blob.hash, $6::smallint, change_seq FROM seq, blob RETURNING id;",
+			uID, id, title, hash, size, v).Scan(&resultID)
+	})
+	return versionExists(err)
+}
+
+// BinAddContent inserts new streamed binary data in database: the payload and the sealed body, read from content.
+// The body is saved to the blob store, gk_bin keeps its hash. Returns the body size. Nothing is saved, if content
+// fails.
+func (p *PostgreVault) BinAddContent(uID int, id, title string, payload []byte, content io.Reader, v uint32) (int64, error) {
+	size, err := putBlob(content, func(ctx context.Context, tx pgx.Tx, hash string, size int64) error {
+		var resultID int
+		return tx.QueryRow(ctx, nextChangeSeq+", "+addBlobRef("$4", "$5")+
+			"INSERT INTO gk_bin (user_id, item_id, title, payload, content_hash, version, change_seq) "+
+			"SELECT $1, $2::uuid, $3::varchar, $6::bytea, blob.hash, $7::smallint, change_seq FROM seq, blob RETURNING id;",
+			uID, id, title, hash, size, payload, v).Scan(&resultID)
+	})
+	return size, versionExists(err)
+}
+
+// BinAddMeta inserts new version of the streamed binary data with the new payload and the sealed body of the latest
+// version: the body blob gets one more reference. Returns ErrNotFound, if the latest version is not streamed.
+func (p *PostgreVault) BinAddMeta(uID int, id, title string, payload []byte, v uint32) error {
+	var resultID int
+	err := GetSingleValue(nextChangeSeq+
+		", src AS (SELECT content_hash FROM gk_bin WHERE user_id = $1 AND item_id = $2 AND sealed ORDER BY version DESC LIMIT 1), "+
+		"refs AS (UPDATE gk_blob SET refs = refs + 1 WHERE hash IN (SELECT content_hash FROM src)) "+
+		"INSERT INTO gk_bin (user_id, item_id, title, payload, content_hash, version, change_seq) "+
+		"SELECT $1, $2::uuid, $3::varchar, $4::bytea, src.content_hash, $5::smallint, seq.change_seq FROM seq, src "+
+		"WHERE src.content_hash IS NOT NULL RETURNING id;",
+		&resultID,
+		uID, id, title, payload, v)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	return versionExists(err)
+}
+
+// BinContent returns the reader of the streamed binary data body by the row id. The body is read from the blob store.
+func (p *PostgreVault) BinContent(id int) (io.ReadCloser, error) {
+	var hash string
+	if err := GetSingleValue("SELECT content_hash FROM gk_bin WHERE id = $1 AND content_hash IS NOT NULL;",
+		&hash, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	r, err := blobs.Open(hash)
+	if errors.Is(err, blob.ErrNotFound) {
+		log.Printf("blob %s of gk_bin %d is missing", hash, id)
+		return nil, ErrNotFound
+	}
+	return r, err
+}
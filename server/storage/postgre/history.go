@@ -0,0 +1,87 @@
+package postgre
+
+import (
+	"errors"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"github.com/jackc/pgx/v4"
+)
+
+// ItemVersions provides all the stored versions of the item, the latest first. Returns ErrNotFound, if there are none.
+func (p *PostgreVault) ItemVersions(dataType, id string, usrID int) ([]*models.ItemVersion, error) {
+	t, err := tableOf(dataType)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []*models.ItemVersion
+	err = GetAll("SELECT version, title, deleted_at, "+t.tombstone+" AS tombstone, "+t.size+" AS size FROM "+t.name+" "+
+		"WHERE user_id = $1 AND item_id = $2 AND sealed ORDER BY version DESC;",
+		&versions, usrID, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return versions, nil
+}
+
+// ItemVersion provides the item version found in database by item id, user id and version. Could be a tombstone.
+func (p *PostgreVault) ItemVersion(dataType, id string, usrID int, v uint32) (*models.Sealed, error) {
+	t, err := tableOf(dataType)
+	if err != nil {
+		return nil, err
+	}
+
+	data := new(models.Sealed)
+	err = GetOneRow(
+		"SELECT id, item_id, user_id, title, payload, version, deleted_at"+t.columns+" FROM "+t.name+" "+
+			"WHERE item_id = $1 AND user_id = $2 AND version = $3 AND sealed;",
+		data, id, usrID, v)
+	if err != nil {
+		return data, err
+	}
+
+	return data, loadPayloads(data)
+}
+
+// ItemRestore saves the item version as the new latest version next: the title of the version with the payload,
+// sealed for next by the client (the payload is bound to the item version, so it is not copied). The old data is
+// published again and the deleted item is restored. The streamed body of the binary data gets one more reference,
+// the body itself is not copied. The row is stamped with the next user change sequence value.
+// Returns ErrNotFound or ErrRecordAlreadyExists, if the version next is saved already.
+func (p *PostgreVault) ItemRestore(dataType, id string, usrID int, v, next uint32, payload []byte) error {
+	t, err := tableOf(dataType)
+	if err != nil {
+		return err
+	}
+
+	columns, refs := "title", ``
+	if t.blobPayload {
+		var src struct {
+			Title    string
+			Streamed bool
+		}
+		if err = GetOneRow("SELECT title, content_hash IS NOT NULL AS streamed FROM "+t.name+" "+
+			"WHERE user_id = $1 AND item_id = $2 AND version = $3 AND sealed;", &src, usrID, id, v); err != nil {
+			return err
+		}
+		if !src.Streamed {
+			return addBlobPayload(t, usrID, id, src.Title, payload, next)
+		}
+		columns += ", content_hash"
+		refs = ", refs AS (UPDATE gk_blob SET refs = refs + 1 WHERE hash IN (SELECT content_hash FROM src)) "
+	}
+
+	var resultID int
+	err = GetSingleValue(nextChangeSeq+
+		", src AS (SELECT "+columns+" FROM "+t.name+" WHERE user_id = $1 AND item_id = $2 AND version = $3 AND sealed) "+refs+
+		"INSERT INTO "+t.name+" (user_id, item_id, "+columns+", payload, version, change_seq) "+
+		"SELECT $1, $2::uuid, src.*, $4::bytea, $5::smallint, seq.change_seq FROM seq, src RETURNING id;",
+		&resultID, usrID, id, v, payload, next)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	return versionExists(err)
+}
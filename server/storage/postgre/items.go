@@ -0,0 +1,195 @@
+package postgre
+
+import (
+	"errors"
+	"fmt"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"github.com/jackc/pgx/v4"
+)
+
+var ErrUnknownType = errors.New("unknown item type")
+
+// itemTable keeps the query parts of the item type table. The item queries read the sealed rows only:
+// the plaintext rows, saved before the items were sealed on the client side, are read by LegacyItems.
+type itemTable struct {
+	name        string
+	tombstone   string // condition of the tombstone row.
+	size        string // sealed data size of the row.
+	columns     string // columns, selected in addition to the common ones.
+	hash        string // blob store hash of the row.
+	blobPayload bool   // payload is kept in the blob store, see addBlobPayload.
+	legacy      string // plaintext columns of the rows, saved before the items were sealed. Empty - none.
+}
+
+var itemTables = map[string]itemTable{
+	"pair": {name: "gk_pair", tombstone: "octet_length(payload) = 0", size: "octet_length(payload)", hash: "NULL::varchar",
+		legacy: "coalesce(login, '') AS login, coalesce(pass, '') AS pass, coalesce(comment, '') AS comment"},
+	"text": {name: "gk_text", tombstone: "octet_length(payload) = 0", size: "octet_length(payload)", hash: "NULL::varchar",
+		legacy: "coalesce(body, '') AS body, coalesce(comment, '') AS comment"},
+	"bin": {
+		name:      "gk_bin",
+		tombstone: "octet_length(payload) = 0 AND payload_hash IS NULL AND content_hash IS NULL",
+		size: "octet_length(payload) + " +
+			"coalesce((SELECT size FROM gk_blob WHERE hash = coalesce(payload_hash, content_hash)), 0)",
+		columns:     binStreamColumns,
+		hash:        "coalesce(payload_hash, content_hash)",
+		blobPayload: true,
+		legacy:      "coalesce(body, ''::bytea) AS bin_body, coalesce(comment, '') AS comment",
+	},
+	"card": {name: "gk_card", tombstone: "octet_length(payload) = 0", size: "octet_length(payload)", hash: "NULL::varchar",
+		legacy: "coalesce(number, '') AS number, coalesce(expiration_date, '') AS expiration_date, " +
+			"coalesce(comment, '') AS comment"},
+	"otp": {name: "gk_otp", tombstone: "octet_length(payload) = 0", size: "octet_length(payload)", hash: "NULL::varchar"},
+}
+
+// tableOf returns the table of the item type.
+func tableOf(dataType string) (itemTable, error) {
+	t, ok := itemTables[dataType]
+	if !ok {
+		return itemTable{}, fmt.Errorf("%q: %w", dataType, ErrUnknownType)
+	}
+	return t, nil
+}
+
+// ItemByID provides the latest version of the item found in database by item id and user id. Could be a tombstone.
+// Payloads, kept in the blob store, are read from it.
+func (p *PostgreVault) ItemByID(dataType, id string, usrID int) (*models.Sealed, error) {
+	t, err := tableOf(dataType)
+	if err != nil {
+		return nil, err
+	}
+
+	data := new(models.Sealed)
+	err = GetOneRow(
+		"SELECT id, item_id, user_id, title, payload, version, deleted_at"+t.columns+" FROM "+t.name+" "+
+			"WHERE item_id = $1 AND user_id = $2 AND sealed ORDER BY version DESC LIMIT 1;",
+		data, id, usrID)
+	if err != nil {
+		return data, err
+	}
+
+	return data, loadPayloads(data)
+}
+
+// ItemByTitle provides the latest version of the item found in database by item title and user id, see itemByTitle.
+// Payloads, kept in the blob store, are read from it.
+func (p *PostgreVault) ItemByTitle(dataType, title string, usrID int) (*models.Sealed, error) {
+	t, err := tableOf(dataType)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := itemByTitle(t.name, t.columns, title, usrID)
+	if err != nil {
+		return data, err
+	}
+
+	return data, loadPayloads(data)
+}
+
+// ItemAdd inserts new item version in database. The row is stamped with the next user change sequence value.
+func (p *PostgreVault) ItemAdd(dataType string, uID int, id, title string, payload []byte, v uint32) error {
+	t, err := tableOf(dataType)
+	if err != nil {
+		return err
+	}
+	if t.blobPayload {
+		return addBlobPayload(t, uID, id, title, payload, v)
+	}
+
+	var resultID int
+	return versionExists(GetSingleValue(nextChangeSeq+
+		"INSERT INTO "+t.name+" (user_id, item_id, title, payload, version, change_seq) "+
+		"SELECT $1, $2::uuid, $3::varchar, $4::bytea, $5::smallint, change_seq FROM seq RETURNING id;",
+		&resultID,
+		uID, id, title, payload, v))
+}
+
+// ItemDelete makes a soft delete of the item from database: set deleted_at parameter to current_date for all
+// the versions and adds a tombstone - the next version with an empty payload and the latest title. Rows are stamped
+// with the next user change sequence value. Returns the tombstone version or ErrNotFound, if there is nothing to delete.
+func (p *PostgreVault) ItemDelete(dataType, id string, uID int) (uint32, error) {
+	t, err := tableOf(dataType)
+	if err != nil {
+		return 0, err
+	}
+
+	var version uint32
+	err = GetSingleValue(nextChangeSeq+
+		", del AS (UPDATE "+t.name+" SET deleted_at = current_timestamp, change_seq = seq.change_seq FROM seq "+
+		"WHERE item_id = $2 AND user_id = $1 AND deleted_at isnull AND sealed RETURNING "+t.name+".version, "+t.name+".title) "+
+		"INSERT INTO "+t.name+" (user_id, item_id, title, payload, version, deleted_at, change_seq) "+
+		"SELECT $1, $2::uuid, (SELECT title FROM del ORDER BY version DESC LIMIT 1), ''::bytea, "+
+		"(SELECT max(version) FROM del) + 1, current_timestamp, change_seq FROM seq "+
+		"WHERE EXISTS (SELECT 1 FROM del) RETURNING version;",
+		&version, uID, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	return version, versionExists(err)
+}
+
+// ItemList provides the latest versions of all the user items of the type, ordered by title. Tombstones included.
+// Payloads, kept in the blob store, are read from it.
+func (p *PostgreVault) ItemList(dataType string, usrID int) ([]*models.Sealed, error) {
+	t, err := tableOf(dataType)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*models.Sealed
+	err = GetAll("SELECT * FROM (SELECT DISTINCT ON (item_id) id, item_id, user_id, title, payload, version, deleted_at"+
+		t.columns+" FROM "+t.name+" WHERE user_id = $1 AND sealed ORDER BY item_id, version DESC) latest ORDER BY title, item_id;",
+		&items, usrID)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, loadPayloads(items...)
+}
+
+// ItemTitles provides the latest versions of all the user items of the type, like ItemList, without the payloads:
+// the items are searched by title, only the found ones are read then.
+func (p *PostgreVault) ItemTitles(dataType string, usrID int) ([]*models.Sealed, error) {
+	t, err := tableOf(dataType)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*models.Sealed
+	err = GetAll("SELECT * FROM (SELECT DISTINCT ON (item_id) id, item_id, title, version, deleted_at"+
+		" FROM "+t.name+" WHERE user_id = $1 AND sealed ORDER BY item_id, version DESC) latest ORDER BY title, item_id;",
+		&items, usrID)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// LegacyItems provides the latest plaintext versions of the user items of the type, that have no sealed version yet.
+// The deleted items are not returned. The plaintext rows are erased, when the item gets the sealed version.
+func (p *PostgreVault) LegacyItems(dataType string, usrID int) ([]*models.Legacy, error) {
+	t, err := tableOf(dataType)
+	if err != nil {
+		return nil, err
+	}
+	if t.legacy == `` {
+		return nil, nil
+	}
+
+	var items []*models.Legacy
+	err = GetAll("SELECT DISTINCT ON (item_id) item_id, title, version, "+t.legacy+" FROM "+t.name+" l "+
+		"WHERE user_id = $1 AND NOT sealed AND deleted_at isnull AND NOT EXISTS "+
+		"(SELECT 1 FROM "+t.name+" s WHERE s.user_id = l.user_id AND s.item_id = l.item_id AND s.sealed) "+
+		"ORDER BY item_id, version DESC, id DESC;",
+		&items, usrID)
+	if err != nil {
+		return nil, err
+	}
+	for _, it := range items {
+		it.Type = dataType
+	}
+
+	return items, nil
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/EestiChameleon/gophkeeper/models"
 	"github.com/EestiChameleon/gophkeeper/server/cfg"
 	migration "github.com/EestiChameleon/gophkeeper/server/migrations"
+	"github.com/EestiChameleon/gophkeeper/server/storage/blob"
 	"github.com/docker/distribution/context"
 	"github.com/georgysavva/scany/pgxscan"
 	"github.com/jackc/pgx/v4"
@@ -15,23 +16,36 @@ import (
 var (
 	ErrNotFound            = errors.New("no records found")
 	ErrRecordAlreadyExists = errors.New("provided data already exists")
+	ErrAmbiguous           = errors.New("several items have the title")
 	db                     *pgxpool.Pool
 )
 
-// Run method initiates the DB connection and creates the gophkeeper tables.
-func Run() (*PostgreVault, error) {
+// uniqueViolation is the PostgreSQL error code of the unique constraint violation.
+const uniqueViolation = "23505"
+
+// Run method initiates the DB connection and creates the gophkeeper tables. store keeps the binary item data.
+func Run(store blob.Store) (*PostgreVault, error) {
 	//create tables if it doesn't exist
-	if err := migration.InitMigration(); err != nil {
+	if err := migration.InitMigration(cfg.Current.DatabaseURI); err != nil {
 		return nil, err
 	}
 
 	// connect to DB
-	conn, err := pgxpool.Connect(context.Background(), cfg.PostgreDatabaseURI)
+	conn, err := pgxpool.Connect(context.Background(), cfg.Current.DatabaseURI)
 	if err != nil {
 		return nil, err
 	}
 
 	db = conn
+	blobs = store
+	// binary data, saved before the blob store
+	if err = moveBinsToBlobs(); err != nil {
+		return nil, err
+	}
+	// blobs, left without references by the stopped server
+	if err = sweepBlobs(); err != nil {
+		return nil, err
+	}
 	//Vault.MU = new(sync.Mutex)
 	return &PostgreVault{}, nil
 	// or may be init all and then synchronizes ?
@@ -42,6 +56,16 @@ func ShutDown() error {
 	return nil
 }
 
+// versionExists returns ErrRecordAlreadyExists, if the item version is saved already: the concurrent request has saved
+// it first (the item versions are unique). Other errors are returned as is.
+func versionExists(err error) error {
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) && pgErr.SQLState() == uniqueViolation {
+		return ErrRecordAlreadyExists
+	}
+	return err
+}
+
 //-------------------- DATABASE QUERIES--------------------
 
 // ExecuteQuery is used for SQL queries that returns nothing. Like DELETE or UPDATE.
@@ -84,26 +108,67 @@ func GetAll(query string, dest interface{}, args ...interface{}) (err error) {
 	return
 }
 
-// getAllUserDataLastVersion returns all user's data found in database. Last version.
-func getAllUserDataLastVersion(usrID int) (*models.ActualData, error) {
-	var err error
+// getUserDataChangedSince returns the user's data, changed after the cursor. Last version of every changed item.
+func getUserDataChangedSince(usrID int, cursor int64) (*models.ActualData, error) {
 	data := new(models.ActualData)
-	if err = GetAll("SELECT DISTINCT ON (title) title, login, pass, comment, version FROM gk_pair WHERE user_id = $1 AND deleted_at isnull ORDER BY title, version DESC;",
-		&data.Pairs, usrID); err != nil {
-		return nil, err
-	}
-	if err = GetAll(" SELECT DISTINCT ON (title) title, body, comment, version FROM gk_text WHERE user_id = $1 AND deleted_at isnull ORDER BY title, version DESC;",
-		&data.Texts, usrID); err != nil {
-		return nil, err
+	dest := map[string]*[]*models.Sealed{"pair": &data.Pairs, "text": &data.Texts, "bin": &data.Bins, "card": &data.Cards,
+		"otp": &data.OTPs}
+	for _, dataType := range models.ItemTypes {
+		t, err := tableOf(dataType)
+		if err != nil {
+			return nil, err
+		}
+		if err = GetAll(changedSinceQuery(t.name, t.columns), dest[dataType], usrID, cursor); err != nil {
+			return nil, err
+		}
+		if err = loadPayloads(*dest[dataType]...); err != nil {
+			return nil, err
+		}
 	}
-	if err = GetAll("SELECT DISTINCT ON (title) title, body, comment, version FROM gk_bin WHERE user_id = $1 AND deleted_at isnull ORDER BY title, version DESC;",
-		&data.Bins, usrID); err != nil {
+
+	return data, nil
+}
+
+// changedSinceQuery returns the last version of the items, that have a row changed after the cursor.
+// Deleted items are returned as tombstones (deleted_at is set). columns are selected in addition to the common ones.
+func changedSinceQuery(table, columns string) string {
+	return "SELECT DISTINCT ON (item_id) item_id, title, payload, version, deleted_at" + columns + " FROM " + table + " " +
+		"WHERE user_id = $1 AND sealed AND item_id IN " +
+		"(SELECT item_id FROM " + table + " WHERE user_id = $1 AND change_seq > $2) " +
+		"ORDER BY item_id, version DESC;"
+}
+
+// itemByTitle returns the latest version of the item, titled so in its latest version. The item, that is not deleted,
+// is preferred to the deleted ones. Returns ErrAmbiguous, if the title is shared by several items.
+// columns are selected in addition to the common ones.
+func itemByTitle(table, columns, title string, usrID int) (*models.Sealed, error) {
+	var items []*models.Sealed
+	err := GetAll("SELECT * FROM (SELECT DISTINCT ON (item_id) id, item_id, user_id, title, payload, version, deleted_at"+
+		columns+" FROM "+table+" WHERE user_id = $2 AND sealed AND item_id IN "+
+		"(SELECT item_id FROM "+table+" WHERE user_id = $2 AND title = $1) "+
+		"ORDER BY item_id, version DESC) latest WHERE title = $1;",
+		&items, title, usrID)
+	if err != nil {
 		return nil, err
 	}
-	if err = GetAll("SELECT DISTINCT ON (title) title, number, expiration_date, comment, version FROM gk_card WHERE user_id = $1 AND deleted_at isnull ORDER BY title, version DESC;",
-		&data.Cards, usrID); err != nil {
-		return nil, err
+
+	var found []*models.Sealed
+	for _, deleted := range []bool{false, true} {
+		for _, item := range items {
+			if item.DeletedAt.Valid == deleted {
+				found = append(found, item)
+			}
+		}
+		if len(found) > 0 {
+			break
+		}
 	}
 
-	return data, nil
+	switch len(found) {
+	case 0:
+		return nil, ErrNotFound
+	case 1:
+		return found[0], nil
+	}
+	return nil, ErrAmbiguous
 }
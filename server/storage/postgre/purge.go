@@ -0,0 +1,108 @@
+package postgre
+
+import (
+	"database/sql"
+	"github.com/EestiChameleon/gophkeeper/models"
+	"time"
+)
+
+// latestFirst orders the item rows from the latest one. The plaintext rows are older, than the sealed ones, and could
+// repeat the version: the deleted item was added again from version 1.
+const latestFirst = "sealed DESC, version DESC, id DESC"
+
+// purgedRow is a row, erased by the purge queries. Hash is the blob store hash of the binary data.
+type purgedRow struct {
+	Version uint32
+	Hash    sql.NullString
+}
+
+// ItemPurge erases all the versions of the item and adds the tombstone without data: the next version, marked deleted,
+// with the latest title. The plaintext rows of the item are erased too: the item could have no sealed version yet.
+// Blobs of the binary data are released. The tombstone is stamped with the next user change sequence value.
+// Returns the tombstone version and the number of erased versions or ErrNotFound.
+func (p *PostgreVault) ItemPurge(dataType, id string, usrID int) (uint32, int, error) {
+	t, err := tableOf(dataType)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// the deleted rows are not visible to the insert and vice versa - both see the data before the statement.
+	var rows []purgedRow
+	err = GetAll(nextChangeSeq+
+		", old AS (SELECT version, title FROM "+t.name+" WHERE user_id = $1 AND item_id = $2 "+
+		"ORDER BY "+latestFirst+" LIMIT 1), "+
+		"del AS (DELETE FROM "+t.name+" WHERE user_id = $1 AND item_id = $2 AND EXISTS (SELECT 1 FROM old) RETURNING "+t.hash+" AS hash), "+
+		"ins AS (INSERT INTO "+t.name+" (user_id, item_id, title, payload, version, deleted_at, change_seq) "+
+		"SELECT $1, $2::uuid, old.title, ''::bytea, old.version + 1, current_timestamp, seq.change_seq FROM seq, old "+
+		"RETURNING version) "+
+		"SELECT ins.version, del.hash FROM ins, del;",
+		&rows, usrID, id)
+	if err != nil {
+		return 0, 0, versionExists(err)
+	}
+	if len(rows) == 0 {
+		return 0, 0, ErrNotFound
+	}
+
+	return rows[0].Version, len(rows), releasePurged(rows)
+}
+
+// PurgeExpired applies the retention policy to all the items: erases the versions older, than the last keepVersions
+// (0 - keeps all), and all the versions of the items, deleted longer than tombstoneAge ago (0 - keeps forever).
+// The plaintext rows are counted as the versions, older than the sealed ones.
+// Blobs of the binary data are released. Returns the number of erased versions.
+func (p *PostgreVault) PurgeExpired(keepVersions int, tombstoneAge time.Duration) (int, error) {
+	var total int
+	for _, dataType := range models.ItemTypes {
+		t, _ := tableOf(dataType)
+
+		if tombstoneAge > 0 {
+			var rows []purgedRow
+			err := GetAll("WITH latest AS (SELECT DISTINCT ON (user_id, item_id) user_id, item_id, deleted_at, "+
+				"sealed AND "+t.tombstone+" AS tombstone FROM "+t.name+" ORDER BY user_id, item_id, "+latestFirst+") "+
+				"DELETE FROM "+t.name+" USING latest "+
+				"WHERE "+t.name+".user_id = latest.user_id AND "+t.name+".item_id = latest.item_id AND latest.tombstone "+
+				"AND latest.deleted_at < current_timestamp - $1::bigint * interval '1 second' "+
+				"RETURNING "+t.name+".version, "+t.hash+" AS hash;",
+				&rows, int64(tombstoneAge.Seconds()))
+			if err != nil {
+				return total, err
+			}
+			total += len(rows)
+			if err = releasePurged(rows); err != nil {
+				return total, err
+			}
+		}
+
+		if keepVersions > 0 {
+			var rows []purgedRow
+			err := GetAll("DELETE FROM "+t.name+" WHERE id IN (SELECT id FROM "+
+				"(SELECT id, row_number() OVER (PARTITION BY user_id, item_id ORDER BY "+latestFirst+") AS n FROM "+t.name+") v "+
+				"WHERE n > $1) RETURNING version, "+t.hash+" AS hash;",
+				&rows, keepVersions)
+			if err != nil {
+				return total, err
+			}
+			total += len(rows)
+			if err = releasePurged(rows); err != nil {
+				return total, err
+			}
+		}
+	}
+
+	return total, nil
+}
+
+// releasePurged releases the blobs of the erased rows. The rows are erased first: if the server stops in between,
+// the blob is left with an extra reference, but never lost while used.
+func releasePurged(rows []purgedRow) error {
+	for _, r := range rows {
+		if !r.Hash.Valid {
+			continue
+		}
+		if err := releaseBlob(r.Hash.String); err != nil {
+			return err
+		}
+	}
+	return nil
+}
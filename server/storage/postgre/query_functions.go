@@ -3,16 +3,23 @@ package postgre
 import (
 	"github.com/EestiChameleon/gophkeeper/models"
 	"log"
+	"time"
 )
 
 type PostgreVault struct{}
 
-// UserAdd inserts new user in database.
-func (p *PostgreVault) UserAdd(login, pass string) (int, error) {
+// nextChangeSeq increments the user change sequence. Query parameter $1 must be the user id.
+// The user row stays locked until the end of the statement transaction, so the sequence values are committed in order.
+const nextChangeSeq = "WITH seq AS (UPDATE gophkeeper_users SET change_seq = change_seq + 1 WHERE id = $1 RETURNING change_seq) "
+
+// UserAdd inserts new user in database. passHash is an encoded password hash.
+// kdfParams & kdfCheck are the client vault key derivation data, empty values are saved as NULL.
+func (p *PostgreVault) UserAdd(login, passHash, kdfParams string, kdfCheck []byte) (int, error) {
 	var usrID int
 	err := GetSingleValue(
-		"INSERT INTO gophkeeper_users (login, password) VALUES ($1, $2) RETURNING id;",
-		&usrID, login, pass)
+		"INSERT INTO gophkeeper_users (login, password_hash, password_updated_at, kdf_params, kdf_check) "+
+			"VALUES ($1, $2, current_timestamp, NULLIF($3, ''), $4) RETURNING id;",
+		&usrID, login, passHash, kdfParams, kdfCheck)
 	if err != nil {
 		return -1, err
 	}
@@ -20,9 +27,10 @@ func (p *PostgreVault) UserAdd(login, pass string) (int, error) {
 	return usrID, nil
 }
 
+// UserLogin provides user data found in database by login. Includes the encoded password hash.
 func (p *PostgreVault) UserLogin(log string) (*models.User, error) {
 	u := new(models.User)
-	if err := GetOneRow("SELECT id, login, password FROM gophkeeper_users WHERE login = $1;",
+	if err := GetOneRow("SELECT id, login, password_hash, kdf_params, kdf_check FROM gophkeeper_users WHERE login = $1;",
 		u, log); err != nil {
 		return nil, err
 	}
@@ -30,126 +38,102 @@ func (p *PostgreVault) UserLogin(log string) (*models.User, error) {
 	return u, nil
 }
 
-// PairByTitle provides pair data found in database by title and user id.
-func (p *PostgreVault) PairByTitle(title string, usrID int) (*models.Pair, error) {
-	data := new(models.Pair)
-	err := GetOneRow(
-		"SELECT id, user_id, title, login, pass, comment, version, deleted_at FROM gk_pair "+
-			"WHERE title = $1 AND user_id = $2 AND deleted_at isnull ORDER BY version DESC LIMIT 1;",
-		data, title, usrID)
-
-	return data, err
-}
-
-// PairAdd inserts new pair data in database.
-func (p *PostgreVault) PairAdd(uID int, title, login, pass, comment string, v uint32) error {
-	var resultID int
-	return GetSingleValue("INSERT INTO gk_pair (user_id, title, login, pass, comment, version) "+
-		"VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;",
-		&resultID,
-		uID, title, login, pass, comment, v)
+// UserPasswordUpdate replaces the user password hash. Used to upgrade outdated hashes.
+func (p *PostgreVault) UserPasswordUpdate(usrID int, passHash string) error {
+	_, err := ExecuteQuery(
+		"UPDATE gophkeeper_users SET password_hash = $1, password_updated_at = current_timestamp WHERE id = $2;",
+		passHash, usrID)
+	return err
 }
 
-// PairDelete makes a soft delete of a pair data from database. Set deleted_at parameter to current_date.
-func (p *PostgreVault) PairDelete(title string, uID int) error {
+// UserSetKDF saves the vault key derivation data. Once set, it is never overwritten: items sealed
+// with the old key would be lost. Returns ErrRecordAlreadyExists, if the data is already set.
+func (p *PostgreVault) UserSetKDF(usrID int, kdfParams string, kdfCheck []byte) error {
 	affRows, err := ExecuteQuery(
-		"UPDATE gk_pair SET deleted_at = current_timestamp WHERE title = $1 AND user_id = $2;",
-		title, uID)
-	log.Println("PairDelete affected rows:", affRows)
-	return err
+		"UPDATE gophkeeper_users SET kdf_params = $1, kdf_check = $2 WHERE id = $3 AND kdf_params isnull;",
+		kdfParams, kdfCheck, usrID)
+	if err != nil {
+		return err
+	}
+	if affRows == 0 {
+		return ErrRecordAlreadyExists
+	}
+	return nil
 }
 
-// TextByTitle provides text data found in database by title and user id.
-func (p *PostgreVault) TextByTitle(title string, usrID int) (*models.Text, error) {
-	data := new(models.Text)
-	err := GetOneRow(
-		"SELECT id, user_id, title, body, comment, version, deleted_at FROM gk_text "+
-			"WHERE title = $1 AND user_id = $2 AND deleted_at isnull ORDER BY version DESC LIMIT 1;",
-		data, title, usrID)
+// SessionAdd inserts new login session in database. Returns session id.
+func (p *PostgreVault) SessionAdd(usrID int, refreshHash string, expiresAt time.Time) (int, error) {
+	var sID int
+	err := GetSingleValue(
+		"INSERT INTO gk_session (user_id, refresh_hash, expires_at) VALUES ($1, $2, $3) RETURNING id;",
+		&sID, usrID, refreshHash, expiresAt.UTC())
+	if err != nil {
+		return -1, err
+	}
 
-	return data, err
+	return sID, nil
 }
 
-// TextAdd inserts new text data in database.
-func (p *PostgreVault) TextAdd(uID int, title, body, comment string, v uint32) error {
-	var resultID int
-	return GetSingleValue(
-		"INSERT INTO gk_text (user_id, title, body, comment, version) "+
-			"VALUES ($1, $2, $3, $4, $5) RETURNING id;",
-		&resultID,
-		uID, title, body, comment, v)
-}
+// SessionByID provides session data found in database by id.
+func (p *PostgreVault) SessionByID(sID int) (*models.Session, error) {
+	s := new(models.Session)
+	err := GetOneRow(
+		"SELECT id, user_id, refresh_hash, previous_refresh_hash, created_at, expires_at, revoked_at "+
+			"FROM gk_session WHERE id = $1;",
+		s, sID)
 
-// TextDelete makes a soft delete of a text data from database. Set deleted_at parameter to current_date.
-func (p *PostgreVault) TextDelete(title string, uID int) error {
-	affRows, err := ExecuteQuery(
-		"UPDATE gk_text SET deleted_at = current_timestamp "+
-			"WHERE title = $1 AND user_id = $2;",
-		title, uID)
-	log.Println("TextDelete affected rows:", affRows)
-	return err
+	return s, err
 }
 
-// BinByTitle provides binary data found in database by title and user id.
-func (p *PostgreVault) BinByTitle(title string, usrID int) (*models.Bin, error) {
-	data := new(models.Bin)
+// SessionByRefresh provides session data found in database by refresh token hash. The session is found
+// by the rotated token too: RefreshHash doesn't match the passed hash then.
+func (p *PostgreVault) SessionByRefresh(refreshHash string) (*models.Session, error) {
+	s := new(models.Session)
 	err := GetOneRow(
-		"SELECT id, user_id, title, body, comment, version, deleted_at FROM gk_bin "+
-			"WHERE title = $1 AND user_id = $2 AND deleted_at isnull ORDER BY version DESC LIMIT 1;",
-		data, title, usrID)
+		"SELECT id, user_id, refresh_hash, previous_refresh_hash, created_at, expires_at, revoked_at FROM gk_session "+
+			"WHERE refresh_hash = $1 OR previous_refresh_hash = $1 ORDER BY refresh_hash = $1 DESC LIMIT 1;",
+		s, refreshHash)
 
-	return data, err
+	return s, err
 }
 
-// BinAdd inserts new binary data in database.
-func (p *PostgreVault) BinAdd(uID int, title string, body []byte, comment string, v uint32) error {
-	var resultID int
-	return GetSingleValue("INSERT INTO gk_bin (user_id, title, body, comment, version) "+
-		"VALUES ($1, $2, $3, $4, $5) RETURNING id;",
-		&resultID,
-		uID, title, body, comment, v)
+// SessionRotate replaces the refresh token hash of the session and prolongs it. The token is rotated only once:
+// returns ErrNotFound, if the session is revoked or its refresh token is not oldHash anymore.
+func (p *PostgreVault) SessionRotate(sID int, oldHash, refreshHash string, expiresAt time.Time) error {
+	affRows, err := ExecuteQuery(
+		"UPDATE gk_session SET previous_refresh_hash = refresh_hash, refresh_hash = $1, expires_at = $2 "+
+			"WHERE id = $3 AND refresh_hash = $4 AND revoked_at isnull;",
+		refreshHash, expiresAt.UTC(), sID, oldHash)
+	if err != nil {
+		return err
+	}
+	if affRows == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
 
-// BinDelete makes a soft delete of a binary data from database. Set deleted_at parameter to current_date.
-func (p *PostgreVault) BinDelete(title string, uID int) error {
+// SessionRevoke marks the session as revoked. Set revoked_at parameter to current_timestamp.
+func (p *PostgreVault) SessionRevoke(sID int) error {
 	affRows, err := ExecuteQuery(
-		"UPDATE gk_bin SET deleted_at = current_timestamp WHERE title = $1 AND user_id = $2;",
-		title, uID)
-	log.Println("BinDelete affected rows:", affRows)
+		"UPDATE gk_session SET revoked_at = current_timestamp WHERE id = $1 AND revoked_at isnull;",
+		sID)
+	log.Println("SessionRevoke affected rows:", affRows)
 	return err
 }
 
-// CardByTitle provides card data found in database by title and user id.
-func (p *PostgreVault) CardByTitle(title string, usrID int) (*models.Card, error) {
-	data := new(models.Card)
-	err := GetOneRow(
-		"SELECT id, user_id, title, number, expiration_date, comment, version, deleted_at FROM gk_card "+
-			"WHERE title = $1 AND user_id = $2 AND deleted_at isnull ORDER BY version DESC LIMIT 1;",
-		data, title, usrID)
-
-	return data, err
-}
-
-// CardAdd inserts new card data in database.
-func (p *PostgreVault) CardAdd(uID int, title, number, expdate, comment string, v uint32) error {
-	var resultID int
-	return GetSingleValue(
-		"INSERT INTO gk_card (user_id, title, number, expiration_date, comment, version) "+
-			"VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;",
-		&resultID,
-		uID, title, number, expdate, comment, v)
-}
+// UserChangesSince provides the latest versions of the user items, changed after the cursor, and the new cursor.
+// Cursor is read before the items, so the changes made during the sync are returned again next time, never lost.
+func (p *PostgreVault) UserChangesSince(usrID int, cursor int64) (*models.ActualProtoData, int64, error) {
+	var newCursor int64
+	if err := GetSingleValue("SELECT change_seq FROM gophkeeper_users WHERE id = $1;", &newCursor, usrID); err != nil {
+		return nil, 0, err
+	}
 
-// CardDelete makes a soft delete of a card data from database. Set deleted_at parameter to current_date.
-func (p *PostgreVault) CardDelete(title string, uID int) error {
-	affRows, err := ExecuteQuery(
-		"UPDATE gk_card SET deleted_at = current_timestamp WHERE title = $1 AND user_id = $2;",
-		title, uID)
-	log.Println("CardDelete affected rows:", affRows)
-	return err
-}
+	data, err := getUserDataChangedSince(usrID, cursor)
+	if err != nil {
+		return nil, 0, err
+	}
 
-func (p *PostgreVault) AllUserLatestData(usrID int) (*models.ActualProtoData, error) {
-	data, err := getAllUserDataLastVersion(usrID)
-	return models.ActualDataToProto(data), err
+	return models.ActualDataToProto(data), newCursor, nil
 }
@@ -2,8 +2,12 @@ package storage
 
 import (
 	"github.com/EestiChameleon/gophkeeper/models"
+	"github.com/EestiChameleon/gophkeeper/server/cfg"
+	"github.com/EestiChameleon/gophkeeper/server/storage/blob"
 	"github.com/EestiChameleon/gophkeeper/server/storage/postgre"
 	"github.com/EestiChameleon/gophkeeper/server/storage/testdb"
+	"io"
+	"time"
 )
 
 var (
@@ -11,42 +15,90 @@ var (
 )
 
 type Vaulter interface {
-	UserAdd(login, pass string) (int, error)
+	UserAdd(login, passHash, kdfParams string, kdfCheck []byte) (int, error)
 	UserLogin(log string) (*models.User, error)
-	PairInt
-	TextInt
+	UserPasswordUpdate(usrID int, passHash string) error
+	UserSetKDF(usrID int, kdfParams string, kdfCheck []byte) error
+	SessionInt
+	ItemInt
 	BinInt
-	CardInt
-	AllUserLatestData(usrID int) (*models.ActualProtoData, error)
+	HistoryInt
+	PurgeInt
+	LegacyInt
+	UserChangesSince(usrID int, cursor int64) (*models.ActualProtoData, int64, error)
 }
 
-type PairInt interface {
-	PairByTitle(title string, usrID int) (*models.Pair, error)
-	PairAdd(uID int, title, login, pass, comment string, v uint32) error
-	PairDelete(title string, uID int) error
+type SessionInt interface {
+	SessionAdd(usrID int, refreshHash string, expiresAt time.Time) (int, error)
+	SessionByID(sID int) (*models.Session, error)
+	SessionByRefresh(refreshHash string) (*models.Session, error)
+	SessionRotate(sID int, oldHash, refreshHash string, expiresAt time.Time) error
+	SessionRevoke(sID int) error
 }
 
-type TextInt interface {
-	TextByTitle(title string, usrID int) (*models.Text, error)
-	TextAdd(uID int, title, body, comment string, v uint32) error
-	TextDelete(title string, uID int) error
+// Item data is sealed on the client side, storage keeps the payload as is. dataType is pair, text, bin, card or otp.
+// Items are identified by the item id, the title of the latest version is the item title.
+// ItemByID returns the latest version of the item, it could be a tombstone (DeletedAt is set).
+// ItemByTitle does the same by the item title. Titles are not unique: returns ErrAmbiguous, if several items have it.
+// ItemDelete marks all the item versions deleted and adds a tombstone. Returns the tombstone version.
+// ItemList returns the latest versions of all the user items of the type, tombstones included.
+// ItemTitles does the same without the payloads: only ID, ItemID, Title, Version and DeletedAt are set.
+
+type ItemInt interface {
+	ItemByID(dataType, id string, usrID int) (*models.Sealed, error)
+	ItemByTitle(dataType, title string, usrID int) (*models.Sealed, error)
+	ItemAdd(dataType string, uID int, id, title string, payload []byte, v uint32) error
+	ItemDelete(dataType, id string, uID int) (uint32, error)
+	ItemList(dataType string, usrID int) ([]*models.Sealed, error)
+	ItemTitles(dataType string, usrID int) ([]*models.Sealed, error)
 }
 
+// Streamed binary data. BinAddContent saves the new version with the sealed body, read from content.
+// BinAddMeta saves the new version of the streamed item with the body of the latest version.
+// BinContent returns the body by the row id.
+
 type BinInt interface {
-	BinByTitle(title string, usrID int) (*models.Bin, error)
-	BinAdd(uID int, title string, body []byte, comment string, v uint32) error
-	BinDelete(title string, uID int) error
+	BinAddContent(uID int, id, title string, payload []byte, content io.Reader, v uint32) (int64, error)
+	BinAddMeta(uID int, id, title string, payload []byte, v uint32) error
+	BinContent(id int) (io.ReadCloser, error)
 }
 
-type CardInt interface {
-	CardByTitle(title string, usrID int) (*models.Card, error)
-	CardAdd(uID int, title, number, expdate, comment string, v uint32) error
-	CardDelete(title string, uID int) error
+// Item history. dataType is pair, text, bin, card or otp, id is the item id.
+// ItemVersions returns all the stored versions of the item, the latest first. ItemVersion returns the version data.
+// ItemRestore saves the version data as the new latest version next with the payload, sealed for next by the client.
+
+type HistoryInt interface {
+	ItemVersions(dataType, id string, usrID int) ([]*models.ItemVersion, error)
+	ItemVersion(dataType, id string, usrID int, v uint32) (*models.Sealed, error)
+	ItemRestore(dataType, id string, usrID int, v, next uint32, payload []byte) error
 }
 
-// Init initializes the DB connection.
+// Item purge. ItemPurge erases all the versions of the item and adds a tombstone without data, so the other devices
+// learn about the deletion. Returns the tombstone version and the number of erased versions.
+// PurgeExpired applies the retention policy to all the users: keeps the last keepVersions versions of every item
+// (0 - all) and erases the items, deleted longer than tombstoneAge ago (0 - never). Returns the number of erased versions.
+
+type PurgeInt interface {
+	ItemPurge(dataType, id string, usrID int) (uint32, int, error)
+	PurgeExpired(keepVersions int, tombstoneAge time.Duration) (int, error)
+}
+
+// Plaintext items, saved before the items were sealed on the client side. They are not served as the item versions.
+// LegacyItems returns the latest plaintext versions of the live items of the type, that have no sealed version.
+// The client seals them and saves as the next version: the plaintext rows are erased then.
+
+type LegacyInt interface {
+	LegacyItems(dataType string, usrID int) ([]*models.Legacy, error)
+}
+
+// Init initializes the blob store and the DB connection.
 func Init() (err error) {
-	Vault, err = postgre.Run()
+	store, err := blob.New(cfg.Current.BlobStore, cfg.Current.BlobDir)
+	if err != nil {
+		return err
+	}
+
+	Vault, err = postgre.Run(store)
 	if err != nil {
 		return err
 	}
@@ -1,72 +1,146 @@
 package testdb
 
 import (
+	"bytes"
 	"database/sql"
 	"github.com/EestiChameleon/gophkeeper/models"
 	pb "github.com/EestiChameleon/gophkeeper/proto"
 	"github.com/EestiChameleon/gophkeeper/server/storage/postgre"
+	"io"
 	"log"
+	"sync/atomic"
+	"time"
 )
 
 var (
 	TestUser = &models.User{
-		ID:       7,
-		Login:    "user7",
-		Password: "8c96c3884a827355aed2c0f744594a52", //service.EncryptPass("pass7")
+		ID:           7,
+		Login:        "user7",
+		PasswordHash: "8c96c3884a827355aed2c0f744594a52", // legacy unsalted md5("pass7")
 	}
 
-	TestPair = &models.Pair{
+	// TestCursor imitates TestUser change sequence.
+	TestCursor int64 = 4
+
+	// TestSessions imitates gk_session table. Key - session id.
+	TestSessions = make(map[int]*models.Session)
+
+	TestPair = &models.Sealed{
 		ID:        1,
+		ItemID:    "00000000-0000-4000-8000-000000000001",
 		UserID:    7,
 		Title:     "testPair",
-		Login:     "testLogin",
-		Pass:      "testPass",
-		Comment:   "testComment",
+		Payload:   []byte("testPairPayload"),
 		Version:   7,
 		DeletedAt: sql.NullTime{},
 	}
 
-	TestText = &models.Text{
+	TestText = &models.Sealed{
 		ID:        2,
+		ItemID:    "00000000-0000-4000-8000-000000000002",
 		UserID:    7,
 		Title:     "testText",
-		Body:      "testBody",
-		Comment:   "testComment",
+		Payload:   []byte("testTextPayload"),
 		Version:   7,
 		DeletedAt: sql.NullTime{},
 	}
 
-	TestBin = &models.Bin{
+	TestBin = &models.Sealed{
 		ID:        3,
+		ItemID:    "00000000-0000-4000-8000-000000000003",
 		UserID:    7,
 		Title:     "testBin",
-		Body:      []byte("testBody"),
-		Comment:   "testComment",
+		Payload:   []byte("testBinPayload"),
+		Version:   7,
+		DeletedAt: sql.NullTime{},
+	}
+
+	// TestStreamedBin is a binary data item with the streamed body TestBinContent.
+	TestStreamedBin = &models.Sealed{
+		ID:        5,
+		ItemID:    "00000000-0000-4000-8000-000000000005",
+		UserID:    7,
+		Title:     "testStreamedBin",
+		Payload:   []byte("testStreamedBinPayload"),
+		Version:   7,
+		DeletedAt: sql.NullTime{},
+		Streamed:  true,
+		Size:      int64(len(TestBinContent)),
+	}
+	TestBinContent = bytes.Repeat([]byte("testBinContent"), 10000)
+
+	// TestUploaded keeps the last body, received by BinAddContent.
+	TestUploaded []byte
+
+	// TestAdded keeps the item version, saved by the last XAdd call. Payload is not kept.
+	TestAdded models.Sealed
+
+	// TestAmbiguousTitle is the title, shared by several test items of every type.
+	TestAmbiguousTitle = "testAmbiguous"
+
+	// TestRacedTitle is the title of the version, the concurrent request saves first: XAdd calls fail
+	// with ErrRecordAlreadyExists.
+	TestRacedTitle = "testRaced"
+
+	// TestPairHistory imitates the older versions of TestPair: the item was deleted, restored and renamed.
+	TestPairHistory = []*models.Sealed{
+		{ID: 11, ItemID: "00000000-0000-4000-8000-000000000001", UserID: 7, Title: "testPairOld",
+			Payload: []byte("testPairPayloadV5"), Version: 5,
+			DeletedAt: sql.NullTime{Time: time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC), Valid: true}},
+		{ID: 12, ItemID: "00000000-0000-4000-8000-000000000001", UserID: 7, Title: "testPairOld",
+			Payload: []byte{}, Version: 6,
+			DeletedAt: sql.NullTime{Time: time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC), Valid: true}},
+	}
+
+	// TestRestored keeps the version, restored by the last ItemRestore call.
+	TestRestored uint32
+
+	// TestPurged keeps the item id, erased by the last ItemPurge call.
+	TestPurged string
+	// TestPurgeRuns counts the PurgeExpired calls.
+	TestPurgeRuns int32
+
+	TestCard = &models.Sealed{
+		ID:        4,
+		ItemID:    "00000000-0000-4000-8000-000000000004",
+		UserID:    7,
+		Title:     "testCard",
+		Payload:   []byte("testCardPayload"),
+		Version:   7,
+		DeletedAt: sql.NullTime{},
+	}
+
+	TestOTP = &models.Sealed{
+		ID:        6,
+		ItemID:    "00000000-0000-4000-8000-000000000006",
+		UserID:    7,
+		Title:     "testOTP",
+		Payload:   []byte("testOTPPayload"),
 		Version:   7,
 		DeletedAt: sql.NullTime{},
 	}
 
-	TestCard = &models.Card{
-		ID:             4,
-		UserID:         7,
-		Title:          "testCard",
-		Number:         "testNumber",
-		ExpirationDate: "testExpDate",
-		Comment:        "testComment",
-		Version:        7,
-		DeletedAt:      sql.NullTime{},
+	// TestLegacyPair is the plaintext pair, saved before the items were sealed on the client side.
+	TestLegacyPair = &models.Legacy{
+		Type:    "pair",
+		ItemID:  "00000000-0000-4000-8000-000000000008",
+		Title:   "testLegacyPair",
+		Version: 2,
+		Login:   "legacyLogin",
+		Pass:    "legacyPass",
+		Comment: "legacyComment",
 	}
 )
 
 type TestVault struct{}
 
 // UserAdd imitates user creation method. Returns id = 7.
-func (t *TestVault) UserAdd(login, pass string) (int, error) {
-	log.Printf("Test UserAdd: login %s, encrypted password %s", login, pass)
+func (t *TestVault) UserAdd(login, passHash, kdfParams string, kdfCheck []byte) (int, error) {
+	log.Printf("Test UserAdd: login %s, password hash %s, kdf %s", login, passHash, kdfParams)
 	return 7, nil
 }
 
-// UserAdd imitates user creation method. Returns id = 7.
+// UserLogin imitates user search method. Returns TestUser.
 func (t *TestVault) UserLogin(login string) (*models.User, error) {
 	log.Printf("Test UserLogin: login %s", login)
 	if login != TestUser.Login {
@@ -76,98 +150,327 @@ func (t *TestVault) UserLogin(login string) (*models.User, error) {
 	return TestUser, nil
 }
 
-// PairByTitle provides test pair data.
-// All int values = 7. All string values = "test" + fieldName. Like Title = "testTitle".
-func (t *TestVault) PairByTitle(title string, usrID int) (*models.Pair, error) {
-	log.Printf("Test PairByTitle: title %s, user %d", title, usrID)
-	if title != TestPair.Title || TestPair.DeletedAt.Valid {
-		return nil, postgre.ErrNotFound
+// UserPasswordUpdate imitates password hash upgrade. Updates TestUser.
+func (t *TestVault) UserPasswordUpdate(usrID int, passHash string) error {
+	log.Printf("Test UserPasswordUpdate: user %d, password hash %s", usrID, passHash)
+	if usrID == TestUser.ID {
+		TestUser.PasswordHash = passHash
 	}
-	return TestPair, nil
+	return nil
 }
 
-func (t *TestVault) PairAdd(uID int, title, login, pass, comment string, v uint32) error {
-	log.Printf("Test PairAdd: %v, %v, %v, %v, %v, %v", uID, title, login, pass, comment, v)
+// UserSetKDF imitates vault key derivation data saving. Updates TestUser, if not set yet.
+func (t *TestVault) UserSetKDF(usrID int, kdfParams string, kdfCheck []byte) error {
+	log.Printf("Test UserSetKDF: user %d, kdf %s", usrID, kdfParams)
+	if TestUser.KDFParams.Valid {
+		return postgre.ErrRecordAlreadyExists
+	}
+	TestUser.KDFParams = sql.NullString{String: kdfParams, Valid: true}
+	TestUser.KDFCheck = kdfCheck
 	return nil
 }
 
-func (t *TestVault) PairDelete(title string, uID int) error {
-	log.Printf("Test PairDelete: %v, %v", title, uID)
-	if title == TestPair.Title {
-		TestPair.DeletedAt.Valid = true
+// SessionAdd imitates session creation method. Saves the session to TestSessions.
+func (t *TestVault) SessionAdd(usrID int, refreshHash string, expiresAt time.Time) (int, error) {
+	log.Printf("Test SessionAdd: user %d", usrID)
+	sID := len(TestSessions) + 1
+	TestSessions[sID] = &models.Session{
+		ID:          sID,
+		UserID:      usrID,
+		RefreshHash: refreshHash,
+		CreatedAt:   time.Now(),
+		ExpiresAt:   expiresAt,
 	}
-	return nil
+	return sID, nil
 }
 
-func (t *TestVault) TextByTitle(title string, usrID int) (*models.Text, error) {
-	if title != TestText.Title || TestText.DeletedAt.Valid {
+// SessionByID provides test session data from TestSessions.
+func (t *TestVault) SessionByID(sID int) (*models.Session, error) {
+	s, ok := TestSessions[sID]
+	if !ok {
 		return nil, postgre.ErrNotFound
 	}
-	return TestText, nil
+	return s, nil
 }
 
-func (t *TestVault) TextAdd(uID int, title, body, comment string, v uint32) error {
-	log.Printf("Test TextAdd: %v, %v, %v, %v", uID, title, body, comment)
-	return nil
+// SessionByRefresh provides test session data from TestSessions. Found by the rotated token too.
+func (t *TestVault) SessionByRefresh(refreshHash string) (*models.Session, error) {
+	for _, s := range TestSessions {
+		if s.RefreshHash == refreshHash || (s.PreviousRefreshHash.Valid && s.PreviousRefreshHash.String == refreshHash) {
+			return s, nil
+		}
+	}
+	return nil, postgre.ErrNotFound
 }
 
-func (t *TestVault) TextDelete(title string, uID int) error {
-	log.Printf("Test TextDelete: %v, %v", title, uID)
-	if title == TestText.Title {
-		TestText.DeletedAt.Valid = true
+// SessionRotate imitates refresh token rotation. ErrNotFound, if the token was rotated already.
+func (t *TestVault) SessionRotate(sID int, oldHash, refreshHash string, expiresAt time.Time) error {
+	s, ok := TestSessions[sID]
+	if !ok || s.RevokedAt.Valid || s.RefreshHash != oldHash {
+		return postgre.ErrNotFound
 	}
+	s.PreviousRefreshHash = sql.NullString{String: s.RefreshHash, Valid: true}
+	s.RefreshHash = refreshHash
+	s.ExpiresAt = expiresAt
 	return nil
 }
 
-func (t *TestVault) BinByTitle(title string, usrID int) (*models.Bin, error) {
-	if title != TestBin.Title || TestBin.DeletedAt.Valid {
-		return nil, postgre.ErrNotFound
+// SessionRevoke imitates session revocation.
+func (t *TestVault) SessionRevoke(sID int) error {
+	log.Printf("Test SessionRevoke: session %d", sID)
+	if s, ok := TestSessions[sID]; ok && !s.RevokedAt.Valid {
+		s.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
 	}
-	return TestBin, nil
+	return nil
 }
 
-func (t *TestVault) BinAdd(uID int, title string, body []byte, comment string, v uint32) error {
-	log.Printf("Test BinAdd: %v, %v, %v, %v", uID, title, body, comment)
+// testItems returns the test items of the type. Deleted test items are returned as tombstones.
+// All int values = 7. All string values = "test" + fieldName. Like Title = "testTitle".
+func testItems(dataType string) []*models.Sealed {
+	switch dataType {
+	case "pair":
+		return []*models.Sealed{TestPair}
+	case "text":
+		return []*models.Sealed{TestText}
+	case "bin":
+		return []*models.Sealed{TestBin, TestStreamedBin}
+	case "card":
+		return []*models.Sealed{TestCard}
+	case "otp":
+		return []*models.Sealed{TestOTP}
+	}
 	return nil
 }
 
-func (t *TestVault) BinDelete(title string, uID int) error {
-	log.Printf("Test BinDelete: %v, %v", title, uID)
-	if title == TestBin.Title {
-		TestBin.DeletedAt.Valid = true
+// ItemByID provides the test item with the id.
+func (t *TestVault) ItemByID(dataType, id string, usrID int) (*models.Sealed, error) {
+	log.Printf("Test ItemByID: %s %s, user %d", dataType, id, usrID)
+	if !models.ValidItemType(dataType) {
+		return nil, postgre.ErrUnknownType
+	}
+	for _, item := range testItems(dataType) {
+		if item.ItemID == id {
+			return item, nil
+		}
+	}
+	return nil, postgre.ErrNotFound
+}
+
+// ItemByTitle provides the test item with the title. TestAmbiguousTitle is shared by several items.
+func (t *TestVault) ItemByTitle(dataType, title string, usrID int) (*models.Sealed, error) {
+	log.Printf("Test ItemByTitle: %s %s, user %d", dataType, title, usrID)
+	if !models.ValidItemType(dataType) {
+		return nil, postgre.ErrUnknownType
+	}
+	if title == TestAmbiguousTitle {
+		return nil, postgre.ErrAmbiguous
+	}
+	for _, item := range testItems(dataType) {
+		if item.Title == title {
+			return item, nil
+		}
+	}
+	return nil, postgre.ErrNotFound
+}
+
+// ItemAdd imitates the item saving. The saved version is kept in TestAdded.
+func (t *TestVault) ItemAdd(dataType string, uID int, id, title string, payload []byte, v uint32) error {
+	log.Printf("Test ItemAdd: %v, %v, %v, %v, %d bytes, %v", dataType, uID, id, title, len(payload), v)
+	if !models.ValidItemType(dataType) {
+		return postgre.ErrUnknownType
 	}
+	return testAdd(uID, id, title, v)
+}
+
+// ItemDelete imitates the soft delete: the test item becomes a tombstone with the next version.
+// TestStreamedBin is never deleted.
+func (t *TestVault) ItemDelete(dataType, id string, uID int) (uint32, error) {
+	log.Printf("Test ItemDelete: %v, %v, %v", dataType, id, uID)
+	items := testItems(dataType)
+	if len(items) == 0 {
+		return 0, postgre.ErrUnknownType
+	}
+	item := items[0]
+	if id != item.ItemID || item.DeletedAt.Valid {
+		return 0, postgre.ErrNotFound
+	}
+	item.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	item.Version++
+	return item.Version, nil
+}
+
+// ItemList provides the test items of the type.
+func (t *TestVault) ItemList(dataType string, usrID int) ([]*models.Sealed, error) {
+	log.Printf("Test ItemList: %v, %v", dataType, usrID)
+	if !models.ValidItemType(dataType) {
+		return nil, postgre.ErrUnknownType
+	}
+	return testItems(dataType), nil
+}
+
+// ItemTitles provides the test items of the type without the payloads.
+func (t *TestVault) ItemTitles(dataType string, usrID int) ([]*models.Sealed, error) {
+	log.Printf("Test ItemTitles: %v, %v", dataType, usrID)
+	if !models.ValidItemType(dataType) {
+		return nil, postgre.ErrUnknownType
+	}
+	var items []*models.Sealed
+	for _, item := range testItems(dataType) {
+		items = append(items, &models.Sealed{ID: item.ID, ItemID: item.ItemID, Title: item.Title, Version: item.Version,
+			DeletedAt: item.DeletedAt})
+	}
+	return items, nil
+}
+
+// testAdd keeps the saved item version in TestAdded. The version with TestRacedTitle is not saved.
+func testAdd(uID int, id, title string, v uint32) error {
+	if title == TestRacedTitle {
+		return postgre.ErrRecordAlreadyExists
+	}
+	TestAdded = models.Sealed{ItemID: id, UserID: uID, Title: title, Version: v}
 	return nil
 }
 
-func (t *TestVault) CardByTitle(title string, usrID int) (*models.Card, error) {
-	if title != TestCard.Title || TestCard.DeletedAt.Valid {
+// BinAddContent imitates streamed binary data saving. The body is kept in TestUploaded.
+func (t *TestVault) BinAddContent(uID int, id, title string, payload []byte, content io.Reader, v uint32) (int64, error) {
+	body, err := io.ReadAll(content)
+	if err != nil {
+		return 0, err
+	}
+	log.Printf("Test BinAddContent: %v, %v, %v, %d bytes, %d bytes body, %v", uID, id, title, len(payload), len(body), v)
+	if err = testAdd(uID, id, title, v); err != nil {
+		return 0, err
+	}
+	TestUploaded = body
+	return int64(len(body)), nil
+}
+
+// BinAddMeta imitates streamed binary data saving without the body. Only TestStreamedBin has the body.
+func (t *TestVault) BinAddMeta(uID int, id, title string, payload []byte, v uint32) error {
+	log.Printf("Test BinAddMeta: %v, %v, %v, %d bytes, %v", uID, id, title, len(payload), v)
+	if id != TestStreamedBin.ItemID {
+		return postgre.ErrNotFound
+	}
+	return testAdd(uID, id, title, v)
+}
+
+// BinContent returns TestBinContent for TestStreamedBin.
+func (t *TestVault) BinContent(id int) (io.ReadCloser, error) {
+	if id != TestStreamedBin.ID {
 		return nil, postgre.ErrNotFound
 	}
-	return TestCard, nil
+	return io.NopCloser(bytes.NewReader(TestBinContent)), nil
 }
 
-func (t *TestVault) CardAdd(uID int, title, number, expdate, comment string, v uint32) error {
-	log.Printf("Test CardAdd: %v, %v, %v, %v, %v, %v", uID, title, number, expdate, comment, v)
-	return nil
+// UserChangesSince imitates the delta sync. Test items belong to TestUser and were changed at TestCursor.
+func (t *TestVault) UserChangesSince(usrID int, cursor int64) (*models.ActualProtoData, int64, error) {
+	log.Printf("Test UserChangesSince: user %d, cursor %d", usrID, cursor)
+	if usrID != TestUser.ID {
+		return new(models.ActualProtoData), 0, nil
+	}
+	if cursor >= TestCursor {
+		return new(models.ActualProtoData), TestCursor, nil
+	}
+
+	return &models.ActualProtoData{
+		Pairs: []*pb.Pair{models.SealedToProtoPair(TestPair)},
+		Texts: []*pb.Text{models.SealedToProtoText(TestText)},
+		Bins:  []*pb.Bin{models.SealedToProtoBin(TestBin)},
+		Cards: []*pb.Card{models.SealedToProtoCard(TestCard)},
+		Items: []*pb.Item{models.SealedToProtoItem("otp", TestOTP)},
+	}, TestCursor, nil
+}
+
+// testHistory returns the test item versions, the latest first. Only TestPair has the history.
+func testHistory(dataType, id string) []*models.Sealed {
+	if dataType != "pair" || id != TestPair.ItemID {
+		return nil
+	}
+	history := []*models.Sealed{TestPair}
+	for i := len(TestPairHistory) - 1; i >= 0; i-- {
+		history = append(history, TestPairHistory[i])
+	}
+	return history
+}
+
+// ItemVersions imitates the item history listing, see testHistory.
+func (t *TestVault) ItemVersions(dataType, id string, usrID int) ([]*models.ItemVersion, error) {
+	log.Printf("Test ItemVersions: %v, %v, %v", dataType, id, usrID)
+	history := testHistory(dataType, id)
+	if len(history) == 0 {
+		return nil, postgre.ErrNotFound
+	}
+
+	versions := make([]*models.ItemVersion, 0, len(history))
+	for _, v := range history {
+		versions = append(versions, &models.ItemVersion{
+			Version:   v.Version,
+			Title:     v.Title,
+			DeletedAt: v.DeletedAt,
+			Tombstone: len(v.Payload) == 0,
+			Size:      int64(len(v.Payload)),
+		})
+	}
+	return versions, nil
+}
+
+// ItemVersion provides the test item version, see testHistory.
+func (t *TestVault) ItemVersion(dataType, id string, usrID int, v uint32) (*models.Sealed, error) {
+	log.Printf("Test ItemVersion: %v, %v, %v, %v", dataType, id, usrID, v)
+	for _, s := range testHistory(dataType, id) {
+		if s.Version == v {
+			return s, nil
+		}
+	}
+	return nil, postgre.ErrNotFound
 }
 
-func (t *TestVault) CardDelete(title string, uID int) error {
-	log.Printf("Test CardDelete: %v, %v", title, uID)
-	if title == TestCard.Title {
-		TestCard.DeletedAt.Valid = true
+// ItemRestore imitates the version restore: the version is kept in TestRestored. The version next, that is not
+// the one after the latest, is saved already.
+func (t *TestVault) ItemRestore(dataType, id string, usrID int, v, next uint32, payload []byte) error {
+	log.Printf("Test ItemRestore: %v, %v, %v, %v, %v, %v", dataType, id, usrID, v, next, payload)
+	history := testHistory(dataType, id)
+	if len(history) == 0 {
+		return postgre.ErrNotFound
+	}
+	if next != history[0].Version+1 {
+		return postgre.ErrRecordAlreadyExists
 	}
+	TestRestored = v
 	return nil
 }
 
-func (t *TestVault) AllUserLatestData(usrID int) (*models.ActualProtoData, error) {
-	if usrID == 7 {
-		return &models.ActualProtoData{
-			Pairs: []*pb.Pair{models.ModelsToProtoPair(TestPair)},
-			Texts: []*pb.Text{models.ModelsToProtoText(TestText)},
-			Bins:  []*pb.Bin{models.ModelsToProtoBin(TestBin)},
-			Cards: []*pb.Card{models.ModelsToProtoCard(TestCard)},
-		}, nil
+// ItemPurge imitates the item erasing: the item id is kept in TestPurged, the test item itself is not changed.
+// TestLegacyPair is purged too: it has the only plaintext version.
+func (t *TestVault) ItemPurge(dataType, id string, usrID int) (uint32, int, error) {
+	log.Printf("Test ItemPurge: %v, %v, %v", dataType, id, usrID)
+	if dataType == TestLegacyPair.Type && id == TestLegacyPair.ItemID {
+		TestPurged = id
+		return TestLegacyPair.Version + 1, 1, nil
+	}
+	history := testHistory(dataType, id)
+	if len(history) == 0 {
+		return 0, 0, postgre.ErrNotFound
 	}
+	TestPurged = id
+	return history[0].Version + 1, len(history), nil
+}
+
+// PurgeExpired imitates the retention policy run. The calls are counted in TestPurgeRuns.
+func (t *TestVault) PurgeExpired(keepVersions int, tombstoneAge time.Duration) (int, error) {
+	log.Printf("Test PurgeExpired: keep %d versions, tombstones %v", keepVersions, tombstoneAge)
+	atomic.AddInt32(&TestPurgeRuns, 1)
+	return 0, nil
+}
 
-	return new(models.ActualProtoData), nil
+// LegacyItems provides TestLegacyPair for the pairs, the other types have no plaintext items.
+func (t *TestVault) LegacyItems(dataType string, usrID int) ([]*models.Legacy, error) {
+	log.Printf("Test LegacyItems: %v, %v", dataType, usrID)
+	if !models.ValidItemType(dataType) {
+		return nil, postgre.ErrUnknownType
+	}
+	if dataType == "pair" {
+		return []*models.Legacy{TestLegacyPair}, nil
+	}
+	return nil, nil
 }
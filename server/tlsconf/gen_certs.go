@@ -0,0 +1,131 @@
+package tlsconf
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	devCertLifetime = 365 * 24 * time.Hour
+
+	CAFile         = "ca.crt"
+	CAKeyFile      = "ca.key"
+	ServerCertFile = "server.crt"
+	ServerKeyFile  = "server.key"
+	ClientCertFile = "client.crt"
+	ClientKeyFile  = "client.key"
+)
+
+// GenerateDevCerts creates a self-signed development CA and the server and client certificates, signed by it.
+// Server certificate is valid for the passed hosts (DNS names or IPs). Files are written to dir:
+// ca.crt, ca.key, server.crt, server.key, client.crt, client.key. Not intended for production use.
+func GenerateDevCerts(dir string, hosts []string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	caTmpl, err := certTemplate("gophkeeper dev CA")
+	if err != nil {
+		return err
+	}
+	caTmpl.IsCA = true
+	caTmpl.BasicConstraintsValid = true
+	caTmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return err
+	}
+	if err = writePair(dir, CAFile, CAKeyFile, caDER, caKey); err != nil {
+		return err
+	}
+
+	// server certificate
+	srvTmpl, err := certTemplate("gophkeeper server")
+	if err != nil {
+		return err
+	}
+	srvTmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			srvTmpl.IPAddresses = append(srvTmpl.IPAddresses, ip)
+		} else {
+			srvTmpl.DNSNames = append(srvTmpl.DNSNames, h)
+		}
+	}
+	if err = signLeaf(dir, ServerCertFile, ServerKeyFile, srvTmpl, caCert, caKey); err != nil {
+		return err
+	}
+
+	// client certificate for mutual TLS
+	clTmpl, err := certTemplate("gophkeeper client")
+	if err != nil {
+		return err
+	}
+	clTmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+
+	return signLeaf(dir, ClientCertFile, ClientKeyFile, clTmpl, caCert, caKey)
+}
+
+// certTemplate returns the base certificate template with a random serial number.
+func certTemplate(commonName string) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"GophKeeper"}, CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(devCertLifetime),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, nil
+}
+
+// signLeaf generates a new key, signs the certificate with the CA and saves both.
+func signLeaf(dir, certName, keyName string, tmpl, ca *x509.Certificate, caKey *ecdsa.PrivateKey) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+
+	return writePair(dir, certName, keyName, der, key)
+}
+
+// writePair saves PEM encoded certificate and PKCS #8 private key. Key file is readable only by the owner.
+func writePair(dir, certName, keyName string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err = os.WriteFile(filepath.Join(dir, certName), certPEM, 0644); err != nil {
+		return err
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	return os.WriteFile(filepath.Join(dir, keyName), keyPEM, 0600)
+}
@@ -0,0 +1,76 @@
+package tlsconf
+
+import (
+	"crypto/tls"
+	"github.com/EestiChameleon/gophkeeper/server/tlsconf/tlstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+// TestServerConfig verifies, that:
+// 1) generated server certificate is trusted by the generated CA
+// 2) mutual TLS rejects clients without certificate
+// 3) mutual TLS accepts clients with certificate signed by the CA
+func TestServerConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, GenerateDevCerts(dir, []string{"localhost", "127.0.0.1"}))
+
+	roots, err := LoadCertPool(filepath.Join(dir, CAFile))
+	require.NoError(t, err)
+	clientCert, err := tls.LoadX509KeyPair(filepath.Join(dir, ClientCertFile), filepath.Join(dir, ClientKeyFile))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		clientCA string
+		clConf   *tls.Config
+		wantErr  bool
+	}{
+		{
+			name:   "Test #1: TLS, trusted server",
+			clConf: &tls.Config{RootCAs: roots, ServerName: "localhost"},
+		},
+		{
+			name:    "Test #2: TLS, unknown server name",
+			clConf:  &tls.Config{RootCAs: roots, ServerName: "example.com"},
+			wantErr: true,
+		},
+		{
+			name:     "Test #3: mutual TLS, client without certificate",
+			clientCA: filepath.Join(dir, CAFile),
+			clConf:   &tls.Config{RootCAs: roots, ServerName: "127.0.0.1"},
+			wantErr:  true,
+		},
+		{
+			name:     "Test #4: mutual TLS, client with certificate",
+			clientCA: filepath.Join(dir, CAFile),
+			clConf:   &tls.Config{RootCAs: roots, ServerName: "127.0.0.1", Certificates: []tls.Certificate{clientCert}},
+		},
+	}
+	for _, tt := range tests {
+		srvConf, err := ServerConfig(filepath.Join(dir, ServerCertFile), filepath.Join(dir, ServerKeyFile), tt.clientCA)
+		require.NoError(t, err, tt.name)
+
+		err = tlstest.Handshake(srvConf, tt.clConf)
+		if tt.wantErr {
+			assert.Error(t, err, tt.name)
+		} else {
+			assert.NoError(t, err, tt.name)
+		}
+	}
+}
+
+// TestFingerprint verifies, that fingerprint is calculated for certificates and rejected for keys.
+func TestFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, GenerateDevCerts(dir, []string{"localhost"}))
+
+	fp, err := Fingerprint(filepath.Join(dir, ServerCertFile))
+	assert.NoError(t, err)
+	assert.Len(t, fp, 64)
+
+	_, err = Fingerprint(filepath.Join(dir, ServerKeyFile))
+	assert.ErrorIs(t, err, ErrNoCertificates)
+}
@@ -0,0 +1,26 @@
+// Package tlstest implements the TLS helpers of the tests.
+package tlstest
+
+import (
+	"crypto/tls"
+	"net"
+)
+
+// Handshake runs TLS handshake between the server and client configs over in-memory connection.
+func Handshake(srvConf, clConf *tls.Config) error {
+	srvConn, clConn := net.Pipe()
+	defer srvConn.Close()
+	defer clConn.Close()
+
+	srvErr := make(chan error, 1)
+	go func() {
+		srvErr <- tls.Server(srvConn, srvConf).Handshake()
+	}()
+
+	clErr := tls.Client(clConn, clConf).Handshake()
+	clConn.Close()
+	if err := <-srvErr; err != nil {
+		return err
+	}
+	return clErr
+}